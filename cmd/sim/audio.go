@@ -0,0 +1,62 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"physicsSim/physics"
+)
+
+// quietestVolume is the level below which a sound isn't worth playing.
+const quietestVolume = 0.01
+
+// sounds plays collision sounds through Ebiten's audio package.
+type sounds struct {
+	context *audio.Context
+	impacts impactSounds
+	playing []*audio.Player
+	muted   bool
+	// hits counts the sounds played, so each one gets different noise
+	hits int64
+}
+
+func newSounds() *sounds {
+	return &sounds{context: audio.NewContext(sampleRate)}
+}
+
+// hear queues the impacts of a step just taken.
+func (s *sounds) hear(impacts []physics.Impact) {
+	s.impacts.hear(impacts)
+}
+
+// play starts a sound for each impact worth hearing this tick, in every
+// material involved, pitched up and louder the harder the hit and placed
+// in stereo by where it happened in view v. It also closes players
+// that have finished.
+func (s *sounds) play(objects []physics.Body, v *viewport) {
+	s.playing = slices.DeleteFunc(s.playing, func(p *audio.Player) bool {
+		if p.IsPlaying() {
+			return false
+		}
+		p.Close()
+		return true
+	})
+
+	for _, hit := range s.impacts.next() {
+		if s.muted {
+			continue
+		}
+		volume := loudness(hit.Impulse)
+		left, right := spatialize(hit.Position, v)
+		if max(left, right)*volume < quietestVolume {
+			continue
+		}
+		for _, m := range hit.Materials(objects) {
+			s.hits++
+			samples := soundSets[m].render(1+0.25*volume, volume, s.hits)
+			player := s.context.NewPlayerF32FromBytes(stereo(samples, left, right))
+			player.Play()
+			s.playing = append(s.playing, player)
+		}
+	}
+}
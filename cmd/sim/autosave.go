@@ -0,0 +1,310 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"time"
+
+	"physicsSim/physics"
+)
+
+// autosaveFiles is how many autosaves are kept before the oldest is
+// written over.
+const autosaveFiles = 3
+
+// savedWorld is the state of a world written to an autosave file. It names
+// the preset that built the world, which supplies everything that doesn't
+// change as it runs, such as static geometry. Water and rubber bands are
+// not saved and start again as the preset sets them up, and nothing
+// outside the world is saved, such as a game's score.
+type savedWorld struct {
+	Preset      string            `json:"preset"`
+	Steps       uint64            `json:"steps"`
+	Bodies      []savedBody       `json:"bodies"`
+	Constraints []savedConstraint `json:"constraints,omitempty"`
+	Welds       []savedWeld       `json:"welds,omitempty"`
+	Wheels      []savedWheel      `json:"wheels,omitempty"`
+}
+
+type savedBody struct {
+	Position        [2]float64       `json:"position"`
+	Velocity        [2]float64       `json:"velocity"`
+	Spin            [3]float64       `json:"spin,omitempty"`
+	Mass            float64          `json:"mass,omitempty"`
+	Radius          float64          `json:"radius,omitempty"`
+	Restitution     *float64         `json:"restitution,omitempty"`
+	Grip            float64          `json:"grip,omitempty"`
+	Drag            float64          `json:"drag,omitempty"`
+	SpeedLimit      float64          `json:"speedLimit,omitempty"`
+	Material        physics.Material `json:"material,omitempty"`
+	Name            string           `json:"name,omitempty"`
+	Color           [4]uint8         `json:"color,omitempty"`
+	Expires         uint64           `json:"expires,omitempty"`
+	Moment          [2]float64       `json:"moment,omitempty"`
+	AngularVelocity float64          `json:"angularVelocity,omitempty"`
+	Angle           float64          `json:"angle,omitempty"`
+	Flock           int              `json:"flock,omitempty"`
+	Frozen          bool             `json:"frozen,omitempty"`
+}
+
+type savedConstraint struct {
+	A         int        `json:"a"`
+	B         int        `json:"b"`
+	Anchor    [2]float64 `json:"anchor,omitempty"`
+	Length    float64    `json:"length"`
+	Strength  float64    `json:"strength,omitempty"`
+	Stiffness float64    `json:"stiffness,omitempty"`
+	Damping   float64    `json:"damping,omitempty"`
+}
+
+type savedWeld struct {
+	A        int        `json:"a"`
+	B        int        `json:"b"`
+	AnchorA  [2]float64 `json:"anchorA"`
+	AnchorB  [2]float64 `json:"anchorB"`
+	Angle    float64    `json:"angle,omitempty"`
+	Strength float64    `json:"strength,omitempty"`
+}
+
+type savedWheel struct {
+	A           int        `json:"a"`
+	B           int        `json:"b"`
+	Anchor      [2]float64 `json:"anchor"`
+	Axis        [2]float64 `json:"axis"`
+	Stiffness   float64    `json:"stiffness"`
+	Damping     float64    `json:"damping,omitempty"`
+	MotorSpeed  float64    `json:"motorSpeed,omitempty"`
+	MotorTorque float64    `json:"motorTorque,omitempty"`
+}
+
+// saveWorld records the world built from the named preset as of its last
+// step.
+func saveWorld(preset string, w *physics.World) *savedWorld {
+	s := &savedWorld{Preset: preset, Steps: w.Steps}
+	for _, b := range w.Snapshot() {
+		s.Bodies = append(s.Bodies, savedBody{
+			Position:        [2]float64{b.Position.X, b.Position.Y},
+			Velocity:        [2]float64{b.Velocity.X, b.Velocity.Y},
+			Spin:            [3]float64{b.Spin.X, b.Spin.Y, b.Spin.Z},
+			Mass:            b.Mass,
+			Radius:          b.Radius,
+			Restitution:     savedRestitution(b),
+			Grip:            b.Grip,
+			Drag:            b.Drag,
+			SpeedLimit:      b.SpeedLimit,
+			Material:        b.Material,
+			Name:            b.Name,
+			Color:           [4]uint8{b.Color.R, b.Color.G, b.Color.B, b.Color.A},
+			Expires:         b.Expires,
+			Moment:          [2]float64{b.Moment.X, b.Moment.Y},
+			AngularVelocity: b.AngularVelocity,
+			Angle:           b.Angle,
+			Flock:           b.Flock,
+			Frozen:          b.Frozen,
+		})
+	}
+	for _, c := range w.Constraints {
+		s.Constraints = append(s.Constraints, savedConstraint{
+			A:         c.A,
+			B:         c.B,
+			Anchor:    [2]float64{c.Anchor.X, c.Anchor.Y},
+			Length:    c.Length,
+			Strength:  c.Strength,
+			Stiffness: c.Stiffness,
+			Damping:   c.Damping,
+		})
+	}
+	for _, wd := range w.Welds {
+		s.Welds = append(s.Welds, savedWeld{
+			A:        wd.A,
+			B:        wd.B,
+			AnchorA:  [2]float64{wd.AnchorA.X, wd.AnchorA.Y},
+			AnchorB:  [2]float64{wd.AnchorB.X, wd.AnchorB.Y},
+			Angle:    wd.Angle,
+			Strength: wd.Strength,
+		})
+	}
+	for _, j := range w.Wheels {
+		s.Wheels = append(s.Wheels, savedWheel{
+			A:           j.A,
+			B:           j.B,
+			Anchor:      [2]float64{j.Anchor.X, j.Anchor.Y},
+			Axis:        [2]float64{j.Axis.X, j.Axis.Y},
+			Stiffness:   j.Stiffness,
+			Damping:     j.Damping,
+			MotorSpeed:  j.MotorSpeed,
+			MotorTorque: j.MotorTorque,
+		})
+	}
+	return s
+}
+
+// savedRestitution returns the body's own restitution to save, or nil if it is
+// left to its material, so a saved zero keeps meaning the ball doesn't
+// bounce.
+func savedRestitution(b physics.Body) *float64 {
+	if !b.HasRestitution {
+		return nil
+	}
+	return &b.Restitution
+}
+
+// body returns the body as it was saved.
+func (b savedBody) body() physics.Body {
+	body := physics.Body{
+		Position:        physics.Vector{X: b.Position[0], Y: b.Position[1]},
+		Velocity:        physics.Vector{X: b.Velocity[0], Y: b.Velocity[1]},
+		Spin:            physics.Vector{X: b.Spin[0], Y: b.Spin[1], Z: b.Spin[2]},
+		Mass:            b.Mass,
+		Radius:          b.Radius,
+		Grip:            b.Grip,
+		Drag:            b.Drag,
+		SpeedLimit:      b.SpeedLimit,
+		Material:        b.Material,
+		Name:            b.Name,
+		Color:           color.RGBA{b.Color[0], b.Color[1], b.Color[2], b.Color[3]},
+		Expires:         b.Expires,
+		Moment:          physics.Vector{X: b.Moment[0], Y: b.Moment[1]},
+		AngularVelocity: b.AngularVelocity,
+		Angle:           b.Angle,
+		Flock:           b.Flock,
+		Frozen:          b.Frozen,
+	}
+	if b.Restitution != nil {
+		body.Restitution, body.HasRestitution = *b.Restitution, true
+	}
+	return body
+}
+
+// apply puts a world built from the saved preset into the saved state.
+// It must not be called while a step runs.
+func (s *savedWorld) apply(w *physics.World) {
+	var c physics.Checkpoint
+	c.Capture(w)
+	c.Steps = s.Steps
+	c.Objects = c.Objects[:0]
+	for _, b := range s.Bodies {
+		c.Objects = append(c.Objects, b.body())
+	}
+	c.Constraints = c.Constraints[:0]
+	for _, r := range s.Constraints {
+		c.Constraints = append(c.Constraints, physics.DistanceConstraint{
+			A:         r.A,
+			B:         r.B,
+			Anchor:    physics.Vector{X: r.Anchor[0], Y: r.Anchor[1]},
+			Length:    r.Length,
+			Strength:  r.Strength,
+			Stiffness: r.Stiffness,
+			Damping:   r.Damping,
+		})
+	}
+	c.Welds = c.Welds[:0]
+	for _, wd := range s.Welds {
+		c.Welds = append(c.Welds, physics.Weld{
+			A:        wd.A,
+			B:        wd.B,
+			AnchorA:  physics.Vector{X: wd.AnchorA[0], Y: wd.AnchorA[1]},
+			AnchorB:  physics.Vector{X: wd.AnchorB[0], Y: wd.AnchorB[1]},
+			Angle:    wd.Angle,
+			Strength: wd.Strength,
+		})
+	}
+	c.Wheels = c.Wheels[:0]
+	for _, j := range s.Wheels {
+		c.Wheels = append(c.Wheels, physics.WheelJoint{
+			A:           j.A,
+			B:           j.B,
+			Anchor:      physics.Vector{X: j.Anchor[0], Y: j.Anchor[1]},
+			Axis:        physics.Vector{X: j.Axis[0], Y: j.Axis[1]},
+			Stiffness:   j.Stiffness,
+			Damping:     j.Damping,
+			MotorSpeed:  j.MotorSpeed,
+			MotorTorque: j.MotorTorque,
+		})
+	}
+	w.Restore(&c)
+}
+
+func loadSavedWorld(path string) (*savedWorld, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var s savedWorld
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, ok := presets[s.Preset]; !ok {
+		return nil, fmt.Errorf("%s: unknown preset %q", path, s.Preset)
+	}
+	return &s, nil
+}
+
+// save writes the state to path by way of a temporary file, so a crash
+// part way through leaves the last save at path whole.
+func (s *savedWorld) save(path string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	temporary := path + ".tmp"
+	if err := os.WriteFile(temporary, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temporary, path)
+}
+
+// autosaver saves the world every so many steps into a rotating set of
+// files in a directory.
+type autosaver struct {
+	dir    string
+	preset string
+	every  uint64
+	// slot is the file the next save goes in
+	slot int
+}
+
+// newAutosaver saves a world built from the named preset into dir every
+// interval of simulation time.
+func newAutosaver(dir, preset string, interval time.Duration) *autosaver {
+	return &autosaver{dir: dir, preset: preset, every: max(uint64(interval.Seconds()*physics.TicksPerSecond), 1)}
+}
+
+// update saves the world if it is due. Call it after every step.
+func (a *autosaver) update(w *physics.World) error {
+	if w.Steps%a.every != 0 {
+		return nil
+	}
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(a.dir, fmt.Sprintf("autosave-%d.json", a.slot))
+	a.slot = (a.slot + 1) % autosaveFiles
+	return saveWorld(a.preset, w).save(path)
+}
+
+// latestAutosave returns the autosave in dir written most recently.
+func latestAutosave(dir string) (string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "autosave-*.json"))
+	if err != nil {
+		return "", err
+	}
+	latest, newest := "", time.Time{}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		if latest == "" || info.ModTime().After(newest) {
+			latest, newest = path, info.ModTime()
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no autosaves in %s", dir)
+	}
+	return latest, nil
+}
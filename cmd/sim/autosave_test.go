@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"physicsSim/physics"
+)
+
+// TestResumeFromAutosave autosaves a run of the cradle, whose rods must
+// come back too, and checks only the last few saves are kept, that the
+// latest is found, and that a world resumed from it carries on exactly as
+// the original did.
+func TestResumeFromAutosave(t *testing.T) {
+	dir := t.TempDir()
+	w := presets["cradle"]().build(physics.WithDeterminism())
+	defer w.Close()
+	a := newAutosaver(dir, "cradle", time.Second)
+
+	for w.Steps < 5*physics.TicksPerSecond {
+		w.Step()
+		if err := a.update(w); err != nil {
+			t.Fatal(err)
+		}
+		// Apart enough for the latest save to be the newest file
+		if w.Steps%physics.TicksPerSecond == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if paths, _ := filepath.Glob(filepath.Join(dir, "*")); len(paths) != autosaveFiles {
+		t.Fatalf("%d files in the autosave directory, want %d: %v", len(paths), autosaveFiles, paths)
+	}
+
+	path, err := latestAutosave(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := loadSavedWorld(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Steps != w.Steps {
+		t.Fatalf("latest autosave is from step %d, want %d", saved.Steps, w.Steps)
+	}
+
+	resumed := presets[saved.Preset]().build(physics.WithDeterminism())
+	defer resumed.Close()
+	saved.apply(resumed)
+	if len(resumed.Constraints) != len(w.Constraints) {
+		t.Fatalf("resumed with %d rods, want %d", len(resumed.Constraints), len(w.Constraints))
+	}
+	for i := 0; i < physics.TicksPerSecond; i++ {
+		w.Step()
+		resumed.Step()
+	}
+	if got, want := resumed.Checksum(), w.Checksum(); got != want {
+		t.Errorf("resumed run at checksum %s, want %s", got, want)
+	}
+}
+
+// TestSavedRestitutionKeepsZero checks a ball set not to bounce at all
+// comes back from a save still set, and one left to its material still
+// left to it.
+func TestSavedRestitutionKeepsZero(t *testing.T) {
+	for _, b := range []physics.Body{{HasRestitution: true}, {}} {
+		data, err := json.Marshal(savedBody{Restitution: savedRestitution(b)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var saved savedBody
+		if err := json.Unmarshal(data, &saved); err != nil {
+			t.Fatal(err)
+		}
+		if got := saved.body(); got.HasRestitution != b.HasRestitution || got.Restitution != 0 {
+			t.Errorf("%s: loaded restitution %v, set %v, want 0, set %v", data, got.Restitution, got.HasRestitution, b.HasRestitution)
+		}
+	}
+}
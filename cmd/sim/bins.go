@@ -0,0 +1,92 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+
+	"physicsSim/physics"
+)
+
+// settleSpeed is how slow a ball in a bin must be moving to count as
+// landed.
+const settleSpeed = 0.5
+
+// bins is a row of equal-width slots along the bottom of a board. A ball
+// that settles below top is counted in the slot under it and then either
+// sent back to spawn to drop again or taken out of the world.
+type bins struct {
+	left   float64
+	width  float64
+	top    float64
+	floor  float64
+	counts []int
+	total  int
+
+	// expected is the share of balls each slot should collect, drawn over
+	// the counts for comparison. It may be nil.
+	expected []float64
+
+	respawn bool
+	spawn   physics.Vector
+	spread  float64
+	rng     *rand.Rand
+}
+
+// newBins lays count slots of the given width side by side from left,
+// catching balls between top and floor. Landed balls are removed unless
+// respawnAt is called.
+func newBins(left, width float64, count int, top, floor float64) *bins {
+	return &bins{
+		left:   left,
+		width:  width,
+		top:    top,
+		floor:  floor,
+		counts: make([]int, count),
+		rng:    rand.New(rand.NewSource(1)),
+	}
+}
+
+// respawnAt sends landed balls back to within spread of spawn, so the
+// counts keep growing into a histogram.
+func (b *bins) respawnAt(spawn physics.Vector, spread float64) {
+	b.respawn = true
+	b.spawn = spawn
+	b.spread = spread
+}
+
+// slot returns the slot under x, counting balls past either end in the
+// outermost slot.
+func (b *bins) slot(x float64) int {
+	i := int(math.Floor((x - b.left) / b.width))
+	return max(0, min(len(b.counts)-1, i))
+}
+
+// collect counts every ball that has settled in a slot and respawns or
+// removes it. It must not be called while a step runs.
+func (b *bins) collect(w *physics.World) {
+	objects := w.Snapshot()
+	for i := len(objects) - 1; i >= 0; i-- {
+		currBall := objects[i]
+		if currBall.Position.Y < b.top || currBall.Velocity.Magnitude() > settleSpeed {
+			continue
+		}
+		b.counts[b.slot(currBall.Position.X)]++
+		b.total++
+		if b.respawn {
+			w.Place(i, physics.Add(b.spawn, physics.Vector{X: (b.rng.Float64()*2 - 1) * b.spread}))
+		} else {
+			w.Remove(i)
+		}
+	}
+}
+
+// binomialShares returns the chance of a ball taking each number of
+// right-hand bounces out of rows, which is where a Galton board sends it.
+func binomialShares(rows int) []float64 {
+	shares := make([]float64, rows+1)
+	shares[0] = math.Pow(0.5, float64(rows))
+	for k := 1; k <= rows; k++ {
+		shares[k] = shares[k-1] * float64(rows-k+1) / float64(k)
+	}
+	return shares
+}
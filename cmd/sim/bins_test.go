@@ -0,0 +1,27 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBinomialShares(t *testing.T) {
+	shares := binomialShares(4)
+	want := []float64{1.0 / 16, 4.0 / 16, 6.0 / 16, 4.0 / 16, 1.0 / 16}
+	for k := range want {
+		if math.Abs(shares[k]-want[k]) > epsilon {
+			t.Errorf("binomialShares(4)[%d] = %v, want %v", k, shares[k], want[k])
+		}
+	}
+}
+
+// TestSlotClampsToEnds checks balls landing past either end of the bins
+// are counted in the outermost slot.
+func TestSlotClampsToEnds(t *testing.T) {
+	b := newBins(100, 50, 4, 0, 100)
+	for x, want := range map[float64]int{50: 0, 120: 0, 175: 1, 299: 3, 400: 3} {
+		if got := b.slot(x); got != want {
+			t.Errorf("slot(%v) = %d, want %d", x, got, want)
+		}
+	}
+}
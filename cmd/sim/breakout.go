@@ -0,0 +1,212 @@
+package main
+
+import (
+	"math"
+
+	"physicsSim/physics"
+)
+
+const (
+	paddleWidth  = 96
+	paddleHeight = 12
+	// paddleTop is how far down the screen the paddle's top edge sits
+	paddleTop = physics.ScreenHeight - 40
+	// paddleBox is the paddle's index among the world's static boxes
+	paddleBox = 0
+	// paddleAim is how far from straight up, in radians, a ball leaves the
+	// very end of the paddle; nearer the middle it leaves steeper
+	paddleAim = math.Pi / 3
+	// paddleCarry is how far, in radians, each unit per tick the paddle is
+	// moving at turns the ball its way, up to paddleMaxAim from straight
+	// up, so a paddle swept under the ball sends it off flatter
+	paddleCarry  = 0.05
+	paddleMaxAim = 5 * math.Pi / 12
+	// paddleTolerance is how far, in world units, an impact may be from
+	// the paddle's top and still count as a hit on it rather than on its
+	// ends, since the contact is found to rounding
+	paddleTolerance = 0.5
+
+	brickRows    = 5
+	brickColumns = 10
+	brickHeight  = 18
+	brickGap     = 4
+	brickTop     = 50
+
+	serveSpeed     = 6
+	breakoutLives  = 3
+	breakoutMargin = 2 * physics.BallRadius
+)
+
+// brick is one box of the wall, worth more the higher its row.
+type brick struct {
+	box    physics.StaticBox
+	row    int
+	points int
+}
+
+// breakout is a game of Breakout played in the world: a paddle that
+// follows the mouse, a wall of bricks that break when the ball hits them,
+// and a ball that is served again from the paddle each time it is lost
+// out of the bottom, until the lives run out.
+type breakout struct {
+	wall   []brick
+	bricks []brick
+	paddle float64
+	// paddleSpeed is how fast the paddle last moved, in world units per
+	// tick, and steered the step it was last steered at
+	paddleSpeed float64
+	steered     uint64
+	score       int
+	lives       int
+	// serving holds the ball on the paddle until a click sends it off
+	serving bool
+}
+
+func newBreakout() *breakout {
+	b := &breakout{paddle: physics.ScreenWidth / 2, lives: breakoutLives, serving: true}
+	width := (physics.ScreenWidth - brickGap) / float64(brickColumns)
+	for row := 0; row < brickRows; row++ {
+		for column := 0; column < brickColumns; column++ {
+			min := physics.Vector{X: brickGap + float64(column)*width, Y: brickTop + float64(row)*(brickHeight+brickGap)}
+			b.wall = append(b.wall, brick{
+				box:    physics.StaticBox{Min: min, Max: physics.Add(min, physics.Vector{X: width - brickGap, Y: brickHeight})},
+				row:    row,
+				points: 10 * (brickRows - row),
+			})
+		}
+	}
+	b.bricks = append(b.bricks, b.wall...)
+	return b
+}
+
+// paddleAt returns the paddle's box with its middle at x.
+func paddleAt(x float64) physics.StaticBox {
+	return physics.StaticBox{
+		Min: physics.Vector{X: x - paddleWidth/2, Y: paddleTop},
+		Max: physics.Vector{X: x + paddleWidth/2, Y: paddleTop + paddleHeight},
+	}
+}
+
+// serveFrom returns where the ball waits to be served from the paddle.
+func (b *breakout) serveFrom() physics.Vector {
+	return physics.Vector{X: b.paddle, Y: paddleTop - physics.BallRadius}
+}
+
+// options returns the walls, paddle and bricks the game is played in. The
+// bottom is open, so a ball that gets past the paddle leaves the world.
+func (b *breakout) options() []physics.WorldOption {
+	boxes := []physics.StaticBox{paddleAt(b.paddle)}
+	for _, br := range b.bricks {
+		boxes = append(boxes, br.box)
+	}
+	return []physics.WorldOption{
+		physics.WithOpenArena(breakoutMargin),
+		physics.WithStaticSegments(
+			physics.StaticSegment{A: physics.Vector{X: 0, Y: physics.ScreenHeight}, B: physics.Vector{}},
+			physics.StaticSegment{A: physics.Vector{}, B: physics.Vector{X: physics.ScreenWidth}},
+			physics.StaticSegment{A: physics.Vector{X: physics.ScreenWidth}, B: physics.Vector{X: physics.ScreenWidth, Y: physics.ScreenHeight}},
+		),
+		physics.WithStaticBoxes(boxes...),
+	}
+}
+
+// steer moves the paddle's middle to x, keeping it on the screen, and
+// takes its speed from how far it moved over the steps since it was last
+// steered. It must not be called while a step runs.
+func (b *breakout) steer(w *physics.World, x float64) {
+	x = math.Max(paddleWidth/2, math.Min(physics.ScreenWidth-paddleWidth/2, x))
+	if ticks := w.Steps - b.steered; ticks > 0 {
+		b.paddleSpeed, b.steered = (x-b.paddle)/float64(ticks), w.Steps
+	}
+	b.paddle = x
+	w.PlaceStaticBox(paddleBox, paddleAt(b.paddle))
+}
+
+// click serves the ball if it is waiting on the paddle, or starts a new
+// game once this one is over.
+func (b *breakout) click(w *physics.World) {
+	switch {
+	case b.serving:
+		b.serving = false
+		w.Teleport(0, b.serveFrom(), physics.RotateBy(physics.Vector{Y: -serveSpeed}, 0.2))
+	case b.lives == 0 || len(b.bricks) == 0:
+		b.restart(w)
+	}
+}
+
+// restart puts the whole wall back and serves a new ball with full lives.
+func (b *breakout) restart(w *physics.World) {
+	for _, br := range b.bricks {
+		w.RemoveStaticBox(br.box)
+	}
+	for _, br := range b.wall {
+		w.StaticBoxes = append(w.StaticBoxes, br.box)
+	}
+	b.bricks = append(b.bricks[:0], b.wall...)
+	b.score, b.lives = 0, breakoutLives
+	for len(w.Snapshot()) > 0 {
+		w.Remove(0)
+	}
+	w.Spawn(physics.Body{Position: b.serveFrom(), Material: physics.Steel})
+	b.serving = true
+}
+
+// update breaks every brick the ball hit in the last step and aims it off
+// the paddle, then serves a new ball if it was lost. Call it after every
+// step.
+func (b *breakout) update(w *physics.World) {
+	paddle := w.StaticBoxes[paddleBox]
+	for _, hit := range w.LastImpacts() {
+		if hit.B != physics.NoBody || hit.A >= len(w.Snapshot()) {
+			continue
+		}
+		onTop := math.Abs(hit.Position.Y-paddle.Min.Y) <= paddleTolerance
+		if onTop && hit.Position.X >= paddle.Min.X && hit.Position.X <= paddle.Max.X {
+			b.aim(w, hit.A)
+			continue
+		}
+		for k, br := range b.bricks {
+			if br.box.Contains(hit.Position) {
+				w.RemoveStaticBox(br.box)
+				b.bricks = append(b.bricks[:k], b.bricks[k+1:]...)
+				b.score += br.points
+				break
+			}
+		}
+	}
+
+	if len(w.Snapshot()) == 0 && b.lives > 0 {
+		b.lives--
+		if b.lives > 0 {
+			w.Spawn(physics.Body{Position: b.serveFrom(), Material: physics.Steel})
+			b.serving = true
+		}
+	}
+	if b.serving && len(w.Snapshot()) > 0 {
+		w.Teleport(0, b.serveFrom(), physics.Vector{})
+	}
+}
+
+// aim sends ball i off the paddle at its speed, angled by how far from the
+// paddle's middle it hit and turned the way the paddle is moving.
+func (b *breakout) aim(w *physics.World, i int) {
+	currBall := w.Snapshot()[i]
+	offset := math.Max(-1, math.Min(1, (currBall.Position.X-b.paddle)/(paddleWidth/2)))
+	angle := math.Max(-paddleMaxAim, math.Min(paddleMaxAim, offset*paddleAim+b.paddleSpeed*paddleCarry))
+	speed := math.Max(currBall.Velocity.Magnitude(), serveSpeed)
+	w.Teleport(i, currBall.Position, physics.RotateBy(physics.Vector{Y: -speed}, angle))
+}
+
+// reading describes the score and what to do next.
+func (b *breakout) reading() string {
+	status := text("breakout.score", b.score, b.lives)
+	switch {
+	case len(b.bricks) == 0:
+		return status + "  " + text("breakout.cleared")
+	case b.lives == 0:
+		return status + "  " + text("breakout.over")
+	case b.serving:
+		return status + "  " + text("breakout.serve")
+	}
+	return status
+}
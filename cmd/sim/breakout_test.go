@@ -0,0 +1,82 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestBreakoutScoresAndLosesLives plays Breakout with the paddle kept under
+// the ball and checks bricks break and score, then stops steering and
+// checks the lost ball costs a life and is served again from the paddle.
+func TestBreakoutScoresAndLosesLives(t *testing.T) {
+	s := breakoutScene()
+	b := s.breakout
+	w := s.build()
+	defer w.Close()
+
+	b.click(w)
+	for tick := 0; tick < 1500; tick++ {
+		if objects := w.Snapshot(); len(objects) > 0 {
+			b.steer(w, objects[0].Position.X)
+		}
+		w.Step()
+		b.update(w)
+	}
+	broken := brickRows*brickColumns - len(b.bricks)
+	if broken == 0 || b.score == 0 {
+		t.Fatalf("%d bricks broken for %d points, want some", broken, b.score)
+	}
+	if b.lives != breakoutLives {
+		t.Fatalf("%d lives left with the paddle under the ball, want all %d", b.lives, breakoutLives)
+	}
+	if got := len(w.StaticBoxes); got != 1+len(b.bricks) {
+		t.Errorf("%d boxes in the world, want the paddle and %d bricks", got, len(b.bricks))
+	}
+
+	// Park the paddle in a corner and wait for the ball to get past it
+	b.steer(w, 0)
+	for tick := 0; tick < 3000 && !b.serving; tick++ {
+		w.Step()
+		b.update(w)
+	}
+	if !b.serving || b.lives != breakoutLives-1 {
+		t.Fatalf("serving %v with %d lives after missing the ball, want a new serve with %d", b.serving, b.lives, breakoutLives-1)
+	}
+	if got := w.Snapshot()[0].Position; got != b.serveFrom() {
+		t.Errorf("new ball at %v, want it waiting on the paddle at %v", got, b.serveFrom())
+	}
+}
+
+// TestPaddleCarriesTheBall drops a ball onto the middle of the paddle, once
+// held still and once swept right under it, and checks the still paddle
+// sends it straight back up and the moving one turns it right.
+func TestPaddleCarriesTheBall(t *testing.T) {
+	for _, sweep := range []float64{0, 40} {
+		s := breakoutScene()
+		b := s.breakout
+		w := s.build()
+
+		b.serving = false
+		w.Teleport(0, physics.Vector{X: b.paddle + sweep, Y: paddleTop - 3*physics.BallRadius}, physics.Vector{Y: serveSpeed})
+		b.steer(w, b.paddle)
+		w.Step()
+		b.steer(w, b.paddle+sweep)
+		for tick := 0; tick < 30 && w.Snapshot()[0].Velocity.Y > 0; tick++ {
+			w.Step()
+			b.update(w)
+		}
+
+		got := w.Snapshot()[0].Velocity
+		switch {
+		case got.Y >= 0:
+			t.Errorf("sweep %v: ball still falling at %v, want it sent back up", sweep, got)
+		case sweep == 0 && math.Abs(got.X) > 1e-9:
+			t.Errorf("still paddle sent the ball off at %v, want straight up", got)
+		case sweep > 0 && got.X <= 0:
+			t.Errorf("paddle moving right sent the ball off at %v, want it turned right", got)
+		}
+		w.Close()
+	}
+}
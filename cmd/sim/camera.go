@@ -0,0 +1,26 @@
+package main
+
+import "physicsSim/physics"
+
+// camera maps world coordinates onto the screen. Position is the world
+// point shown at the top-left corner of the view.
+type camera struct {
+	position physics.Vector
+}
+
+func (c *camera) worldToScreen(p physics.Vector) physics.Vector {
+	return physics.Subtract(p, c.position)
+}
+
+func (c *camera) screenToWorld(p physics.Vector) physics.Vector {
+	return physics.Add(p, c.position)
+}
+
+// view returns the world-space rectangle visible on a screen of the given size.
+func (c *camera) view(width, height float64) (physics.Vector, physics.Vector) {
+	return c.position, physics.Add(c.position, physics.Vector{X: width, Y: height})
+}
+
+func (c *camera) pan(delta physics.Vector) {
+	c.position = physics.Add(c.position, delta)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"image/color"
+
+	"physicsSim/physics"
+)
+
+const (
+	// carSpeed is how fast the motors turn the wheels with a key held, in
+	// radians per tick, and carTorque and brakeTorque how hard they may
+	// push to turn them and to hold them still
+	carSpeed    = 0.2
+	carTorque   = 30
+	brakeTorque = 60
+
+	chassisBalls  = 3
+	chassisRadius = 12
+	wheelRadius   = 16
+	// carRide is how far below the chassis the wheels hang, middle to
+	// middle, and the suspension's spring holds them there
+	carRide        = 34
+	carStiffness   = 0.4
+	carDamping     = 0.6
+	chassisWeight  = 3
+	carWheelWeight = 1
+)
+
+// car is a vehicle driven with the arrow keys: a chassis of balls welded
+// in a row, with a sprung, gripping wheel under either end that Right and
+// Left drive forward and back and Down brakes. Letting go coasts.
+type car struct {
+	chassis int
+	// wheels are the indices of the car's wheel joints in the world
+	wheels []int
+}
+
+// newCar appends a car standing with its wheels' middles on the level of
+// at, the rear one at at itself, to objects. It returns the car, the
+// bodies with it added and the welds and wheel joints holding it
+// together. The car's wheel joints must be the world's first.
+func newCar(objects []physics.Body, at physics.Vector) (*car, []physics.Body, []physics.WorldOption) {
+	c := &car{chassis: len(objects)}
+	top := physics.Vector{X: at.X, Y: at.Y - carRide}
+	for k := 0; k < chassisBalls; k++ {
+		objects = append(objects, physics.Body{
+			Position: physics.Add(top, physics.Vector{X: float64(k) * 2 * chassisRadius}),
+			Mass:     chassisWeight,
+			Radius:   chassisRadius,
+		})
+	}
+	var welds []physics.Weld
+	for k := 1; k < chassisBalls; k++ {
+		welds = append(welds, physics.NewWeld(objects, c.chassis+k-1, c.chassis+k, 0))
+	}
+
+	var wheels []physics.WheelJoint
+	for _, end := range []int{c.chassis, c.chassis + chassisBalls - 1} {
+		objects = append(objects, physics.Body{
+			Position: physics.Vector{X: objects[end].Position.X, Y: at.Y},
+			Mass:     carWheelWeight,
+			Radius:   wheelRadius,
+			Grip:     1,
+		})
+		c.wheels = append(c.wheels, len(wheels))
+		wheels = append(wheels, physics.NewWheelJoint(objects, end, len(objects)-1, physics.Vector{Y: 1}, carStiffness, carDamping))
+	}
+	return c, objects, []physics.WorldOption{physics.WithWelds(welds...), physics.WithWheelJoints(wheels...)}
+}
+
+// colors returns the colours the car's bodies are drawn in, in order.
+func (c *car) colors() []color.RGBA {
+	var colors []color.RGBA
+	for k := 0; k < chassisBalls; k++ {
+		colors = append(colors, color.RGBA{0xe0, 0x40, 0x40, 0xff})
+	}
+	for range c.wheels {
+		colors = append(colors, color.RGBA{0x40, 0x40, 0x48, 0xff})
+	}
+	return colors
+}
+
+// drive sets the wheels' motors for a throttle of 1 forward, -1 back or 0
+// to coast, or holds the wheels still instead if brake is set. It must not
+// be called while a step runs.
+func (c *car) drive(w *physics.World, throttle float64, brake bool) {
+	for _, k := range c.wheels {
+		j := &w.Wheels[k]
+		switch {
+		case brake:
+			j.MotorSpeed, j.MotorTorque = 0, brakeTorque
+		case throttle != 0:
+			j.MotorSpeed, j.MotorTorque = throttle*carSpeed, carTorque
+		default:
+			j.MotorSpeed, j.MotorTorque = 0, 0
+		}
+	}
+}
+
+// reading gives the car's speed and how to drive it.
+func (c *car) reading(w *physics.World) string {
+	v := w.Snapshot()[c.chassis].Velocity
+	return text("car.speed", v.Magnitude()*physics.TicksPerSecond)
+}
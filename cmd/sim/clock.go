@@ -0,0 +1,115 @@
+package main
+
+import (
+	"time"
+
+	"physicsSim/physics"
+)
+
+// tick is how much real time a step of the world stands for, and
+// maxCatchUp the most steps the clock lets pile up after a stall, so a
+// slow frame doesn't leave the game forever running behind.
+const (
+	tick       = time.Second / physics.TicksPerSecond
+	maxCatchUp = physics.TicksPerSecond / 4
+)
+
+// clock steps the world at a fixed rate of real time however often frames
+// come: each update it gathers the time since the last one and hands out
+// the whole ticks it adds up to, keeping the rest for later. The same scene
+// so plays out the same at 30, 60 or 144 frames a second.
+//
+// Between steps it can also blend where bodies are drawn from where they
+// were before the latest step to where they are after it, so they move
+// smoothly when frames come faster than steps.
+type clock struct {
+	last   time.Time
+	behind time.Duration
+	// smooth sets bodies drawn between steps, and curved sets them along a
+	// curve leaving and arriving at each step at the body's velocity there
+	// rather than in a straight line, so slowed right down they don't lurch
+	// from one step's direction to the next
+	smooth bool
+	curved bool
+	// previous holds every body's position before the latest step and
+	// launched its velocity, and blended the bodies as last drawn between
+	// the two
+	previous []physics.Vector
+	launched []physics.Vector
+	blended  []physics.Body
+}
+
+// advance returns how many ticks are due by now, with time running at
+// scale times real time. A stall lets scale times maxCatchUp pile up.
+func (c *clock) advance(now time.Time, scale float64) int {
+	if !c.last.IsZero() {
+		c.behind += time.Duration(float64(now.Sub(c.last)) * scale)
+	}
+	c.last = now
+	ticks := int(c.behind / tick)
+	c.behind -= time.Duration(ticks) * tick
+	if limit := int(maxCatchUp * max(scale, 1)); ticks > limit {
+		ticks = limit
+		c.behind = 0
+	}
+	return ticks
+}
+
+// hold lets the time up to now go by without it falling due, as it
+// shouldn't while paused.
+func (c *clock) hold(now time.Time) {
+	c.last = now
+}
+
+// remember records the bodies' positions and velocities before a step, to
+// blend from.
+func (c *clock) remember(objects []physics.Body) {
+	c.previous = c.previous[:0]
+	c.launched = c.launched[:0]
+	for _, currBall := range objects {
+		c.previous = append(c.previous, currBall.Position)
+		c.launched = append(c.launched, currBall.Velocity)
+	}
+}
+
+// forget drops the positions to blend from, for when the world jumps
+// rather than steps, as it does rewinding.
+func (c *clock) forget() {
+	c.previous = c.previous[:0]
+}
+
+// blend returns the bodies as they are drawn now, part way from before the
+// latest step to after it by how far the clock is into the next tick.
+// Bodies that jumped, as across a torus's seams, are drawn where they are.
+func (c *clock) blend(objects []physics.Body) []physics.Body {
+	if !c.smooth || len(c.previous) != len(objects) {
+		return objects
+	}
+	alpha := float64(c.behind) / float64(tick)
+	c.blended = append(c.blended[:0], objects...)
+	for i := range c.blended {
+		b := &c.blended[i]
+		moved := physics.Subtract(b.Position, c.previous[i])
+		if moved.Magnitude() > physics.ScreenHeight/2 {
+			continue
+		}
+		// Drawing between the last two steps lags up to a tick behind, but
+		// never guesses ahead at where a body will go
+		if c.curved {
+			b.Position = hermite(c.previous[i], c.launched[i], b.Position, b.Velocity, alpha)
+			continue
+		}
+		b.Position = physics.Add(c.previous[i], physics.ScalarMult(moved, alpha))
+	}
+	return c.blended
+}
+
+// hermite returns the point alpha of the way along the cubic from p0 to p1
+// over a tick, leaving at velocity v0 and arriving at v1.
+func hermite(p0, v0, p1, v1 physics.Vector, alpha float64) physics.Vector {
+	a2, a3 := alpha*alpha, alpha*alpha*alpha
+	return physics.Add(
+		physics.Add(physics.ScalarMult(p0, 2*a3-3*a2+1), physics.ScalarMult(v0, a3-2*a2+alpha)),
+		physics.Add(physics.ScalarMult(p1, -2*a3+3*a2), physics.ScalarMult(v1, a3-a2)),
+	)
+}
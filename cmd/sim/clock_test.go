@@ -0,0 +1,100 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"physicsSim/physics"
+)
+
+// TestClockPlaysTheSameAtAnyFrameRate runs a scene for two seconds of
+// frames at several rates and checks each steps it through the same ticks
+// to the same place.
+func TestClockPlaysTheSameAtAnyFrameRate(t *testing.T) {
+	var want []physics.Body
+	for _, fps := range []int{30, 60, 144} {
+		w := defaultScene().build()
+		var c clock
+		start := time.Unix(0, 0)
+		for frame := 0; frame <= 2*fps; frame++ {
+			now := start.Add(time.Duration(frame) * time.Second / time.Duration(fps))
+			for i := c.advance(now, 1); i > 0; i-- {
+				w.Step()
+			}
+		}
+		if w.Steps != 2*physics.TicksPerSecond {
+			t.Errorf("at %d fps: took %d steps, want %d", fps, w.Steps, 2*physics.TicksPerSecond)
+		}
+		got := append([]physics.Body(nil), w.Snapshot()...)
+		w.Close()
+		if want == nil {
+			want = got
+			continue
+		}
+		for i := range want {
+			if got[i].Position != want[i].Position {
+				t.Fatalf("at %d fps: body %d at %v, want %v", fps, i, got[i].Position, want[i].Position)
+			}
+		}
+	}
+}
+
+// TestClockCatchesUpOnlySoFar checks a long stall hands out no more than
+// maxCatchUp ticks and leaves none owing.
+func TestClockCatchesUpOnlySoFar(t *testing.T) {
+	var c clock
+	start := time.Unix(0, 0)
+	c.advance(start, 1)
+	if got := c.advance(start.Add(10*time.Second), 1); got != maxCatchUp {
+		t.Errorf("after a stall: %d ticks, want %d", got, maxCatchUp)
+	}
+	if got := c.advance(start.Add(10*time.Second+tick/2), 1); got != 0 {
+		t.Errorf("half a tick later: %d ticks, want 0", got)
+	}
+}
+
+// TestClockBlendsBetweenSteps checks bodies are drawn part way between
+// where they were before the latest step and after it, but not across a
+// jump.
+func TestClockBlendsBetweenSteps(t *testing.T) {
+	c := clock{smooth: true}
+	start := time.Unix(0, 0)
+	c.advance(start, 1)
+	c.remember([]physics.Body{{Position: physics.Vector{X: 10}}, {Position: physics.Vector{X: 10}}})
+	c.advance(start.Add(tick+tick/4), 1)
+
+	got := c.blend([]physics.Body{{Position: physics.Vector{X: 20}}, {Position: physics.Vector{X: 10 + physics.ScreenWidth}}})
+	if want := 12.5; math.Abs(got[0].Position.X-want) > 1e-3 {
+		t.Errorf("blended to x = %v, want %v", got[0].Position.X, want)
+	}
+	if want := 10.0 + physics.ScreenWidth; got[1].Position.X != want {
+		t.Errorf("jumped to x = %v, want %v", got[1].Position.X, want)
+	}
+}
+
+// TestClockCurvesBetweenSteps checks a curved clock draws a body turning
+// through a step bowed out along the turn, and one moving steadily just as
+// a straight blend would.
+func TestClockCurvesBetweenSteps(t *testing.T) {
+	c := clock{smooth: true, curved: true}
+	start := time.Unix(0, 0)
+	c.advance(start, 1)
+	c.remember([]physics.Body{
+		{Position: physics.Vector{}, Velocity: physics.Vector{X: 10}},
+		{Position: physics.Vector{X: 10}, Velocity: physics.Vector{X: 10}},
+	})
+	c.advance(start.Add(tick+tick/4), 1)
+
+	got := c.blend([]physics.Body{
+		{Position: physics.Vector{X: 10, Y: 10}, Velocity: physics.Vector{Y: 10}},
+		{Position: physics.Vector{X: 20}, Velocity: physics.Vector{X: 10}},
+	})
+	// A quarter of the way round it has gone further on than up
+	if want := (physics.Vector{X: 2.96875, Y: 1.09375}); math.Abs(got[0].Position.X-want.X) > 1e-3 || math.Abs(got[0].Position.Y-want.Y) > 1e-3 {
+		t.Errorf("turning body drawn at %v, want %v", got[0].Position, want)
+	}
+	if want := 12.5; math.Abs(got[1].Position.X-want) > 1e-3 {
+		t.Errorf("steady body drawn at x = %v, want %v", got[1].Position.X, want)
+	}
+}
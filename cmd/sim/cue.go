@@ -0,0 +1,107 @@
+package main
+
+import "physicsSim/physics"
+
+const (
+	// cueReach is how close to the cue ball a press must land to pick up
+	// the cue.
+	cueReach = 3 * physics.BallRadius
+
+	// cuePowerScale turns pixels of pull-back into speed, up to maxCuePower
+	// pixels per tick.
+	cuePowerScale = 0.12
+	maxCuePower   = 18
+
+	// maxTipOffset is how far off centre the tip can strike, as a fraction
+	// of the ball's radius, before it would miscue.
+	maxTipOffset = 0.5
+
+	// englishStep is how far one key press moves the tip across the ball.
+	englishStep = 0.25
+)
+
+// cue is a stick aimed at one ball. Pressing near the ball and dragging
+// away pulls the cue back, aiming it through the ball; releasing strikes.
+// english says where the tip meets the ball: x from full left (-1) to full
+// right (1), y from full draw (-1) to full follow (1).
+type cue struct {
+	ball    int
+	english physics.Vector
+	aiming  bool
+	pull    physics.Vector
+}
+
+func newCue(ball int) *cue {
+	return &cue{ball: ball}
+}
+
+// press picks up the cue if at is close enough to the cue ball.
+func (c *cue) press(objects []physics.Body, at physics.Vector) {
+	if c.ball >= len(objects) {
+		return
+	}
+	offset := physics.Subtract(at, objects[c.ball].Position)
+	c.aiming = offset.Magnitude() <= cueReach
+	c.pull = at
+}
+
+func (c *cue) drag(at physics.Vector) {
+	c.pull = at
+}
+
+// release strikes the cue ball if the cue was being aimed, and reports
+// whether it did.
+func (c *cue) release(w *physics.World) bool {
+	if !c.aiming {
+		return false
+	}
+	c.aiming = false
+
+	velocity, spin := c.shot(w.Snapshot())
+	if velocity == (physics.Vector{}) {
+		return false
+	}
+	w.Strike(c.ball, velocity, spin)
+	return true
+}
+
+// adjustEnglish moves the tip across the ball by the given steps, keeping
+// it on the ball.
+func (c *cue) adjustEnglish(side, follow float64) {
+	c.english.X = max(-1, min(1, c.english.X+side*englishStep))
+	c.english.Y = max(-1, min(1, c.english.Y+follow*englishStep))
+}
+
+// shot returns the velocity and spin the cue gives its ball when released
+// from c.pull. A tip striking a height h off centre spins a solid ball at
+// a surface speed of 5h/2r times the speed it sends it off at.
+func (c *cue) shot(objects []physics.Body) (physics.Vector, physics.Vector) {
+	back := physics.Subtract(objects[c.ball].Position, c.pull)
+	power := min(back.Magnitude()*cuePowerScale, maxCuePower)
+	direction := physics.UnitVector(back)
+	velocity := physics.ScalarMult(direction, power)
+
+	spinRate := 2.5 * maxTipOffset * power
+	right := physics.Vector{X: -direction.Y, Y: direction.X}
+	spin := physics.CrossProduct(physics.ScalarMult(right, c.english.X*spinRate), direction)
+	spin = physics.Add(spin, physics.ScalarMult(direction, c.english.Y*spinRate))
+	return velocity, spin
+}
+
+// predict returns the path the cue ball would take over the next
+// PredictionSteps ticks if the cue were released now, or nil while it
+// isn't being aimed.
+func (c *cue) predict(w *physics.World) []physics.Vector {
+	if c == nil || !c.aiming {
+		return nil
+	}
+	objects := w.Snapshot()
+	if c.ball >= len(objects) {
+		return nil
+	}
+	velocity, spin := c.shot(objects)
+	if velocity == (physics.Vector{}) {
+		return nil
+	}
+	return w.Predict(c.ball, velocity, spin, physics.PredictionSteps)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestDrawShotComesBack checks a cue ball hit low stops dead on a full hit
+// and then spins its way back towards the player.
+func TestDrawShotComesBack(t *testing.T) {
+	objects := []physics.Body{
+		{Position: physics.Vector{X: 200, Y: 240}},
+		{Position: physics.Vector{X: 300, Y: 240}},
+	}
+	w := physics.NewWorld(objects, physics.Vector{}, physics.WithCloth(clothSliding, clothRolling))
+	defer w.Close()
+
+	c := newCue(0)
+	c.adjustEnglish(0, -4)
+	c.press(w.Snapshot(), physics.Vector{X: 200, Y: 240})
+	c.drag(physics.Vector{X: 150, Y: 240})
+	c.release(w)
+
+	slowest := 0.0
+	for i := 0; i < 120; i++ {
+		w.Step()
+		slowest = math.Min(slowest, w.Snapshot()[0].Velocity.X)
+	}
+	if slowest > -0.1 {
+		t.Errorf("cue ball never came back, slowest velocity %v", slowest)
+	}
+	if objects := w.Snapshot(); objects[1].Position.X <= 300 {
+		t.Errorf("object ball at %v, want it pushed away", objects[1].Position)
+	}
+}
+
+// TestEnglishKicksOffCushion checks side spin pushes a ball sideways when
+// it bounces straight off a wall, to the player's right for right english.
+func TestEnglishKicksOffCushion(t *testing.T) {
+	objects := []physics.Body{{Position: physics.Vector{X: physics.ScreenWidth - 100, Y: 240}}}
+	w := physics.NewWorld(objects, physics.Vector{})
+	defer w.Close()
+
+	c := newCue(0)
+	c.adjustEnglish(4, 0)
+	c.press(w.Snapshot(), objects[0].Position)
+	c.drag(physics.Vector{X: physics.ScreenWidth - 150, Y: 240})
+	c.release(w)
+
+	for i := 0; i < 30; i++ {
+		w.Step()
+	}
+	// Shooting towards +x, the player's right is +y
+	if v := w.Snapshot()[0].Velocity; v.X >= 0 || v.Y <= 0 {
+		t.Errorf("after the cushion velocity = %v, want back and to the right", v)
+	}
+}
+
+// TestPredictionMatchesShot aims the cue in the billiards scene and checks
+// the predicted path is the one the cue ball then takes, and that
+// predicting left the real world alone.
+func TestPredictionMatchesShot(t *testing.T) {
+	s := billiardsScene()
+	w := s.build()
+	defer w.Close()
+
+	objects := w.Snapshot()
+	s.cue.press(objects, objects[0].Position)
+	s.cue.drag(physics.Subtract(objects[0].Position, physics.Vector{X: 80, Y: 5}))
+	before := w.Checksum()
+	path := s.cue.predict(w)
+	if len(path) != physics.PredictionSteps {
+		t.Fatalf("predicted %d steps, want %d", len(path), physics.PredictionSteps)
+	}
+	if w.Checksum() != before {
+		t.Fatal("predicting changed the world")
+	}
+
+	s.cue.release(w)
+	for k, want := range path {
+		w.Step()
+		if got := w.Snapshot()[0].Position; got != want {
+			t.Fatalf("step %d: cue ball at %v, predicted %v", k+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+
+	"physicsSim/physics"
+)
+
+// drain takes every ball that comes into its region out of the world,
+// counting how many of each material it has swallowed. A named drain
+// reports its counts in the HUD.
+type drain struct {
+	name   string
+	region zone
+	counts [physics.Materials]int
+	total  int
+}
+
+func newDrain(name string, min, max physics.Vector) *drain {
+	return &drain{name: name, region: zone{min: min, max: max}}
+}
+
+// collect removes every ball inside the drain. It must not be called while
+// a step runs.
+func (d *drain) collect(w *physics.World) {
+	objects := w.Snapshot()
+	for i := len(objects) - 1; i >= 0; i-- {
+		if !d.region.contains(objects[i].Position) {
+			continue
+		}
+		d.counts[objects[i].Material]++
+		d.total++
+		w.Remove(i)
+	}
+}
+
+// reading describes what the drain has swallowed, by material.
+func (d *drain) reading() string {
+	var parts []string
+	for m, count := range d.counts {
+		if count > 0 {
+			parts = append(parts, text("drain.count", text("material."+physics.Material(m).String()), count))
+		}
+	}
+	if len(parts) == 0 {
+		return text("drain.empty", d.name)
+	}
+	return text("drain.total", d.name, d.total, strings.Join(parts, ", "))
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestDrainCountsByMaterial drops balls of different materials into a drain
+// and checks it takes only those inside, counted by what they are made of.
+func TestDrainCountsByMaterial(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{
+		{Position: physics.Vector{X: 50, Y: 450}, Material: physics.Steel},
+		{Position: physics.Vector{X: 320, Y: 100}},
+		{Position: physics.Vector{X: 100, Y: 450}, Material: physics.Wood},
+		{Position: physics.Vector{X: 150, Y: 450}, Material: physics.Steel},
+	}, physics.Vector{})
+	defer w.Close()
+	d := newDrain("sink", physics.Vector{X: 0, Y: 400}, physics.Vector{X: 200, Y: 480})
+
+	d.collect(w)
+	if got := len(w.Snapshot()); got != 1 {
+		t.Fatalf("%d bodies left, want the one outside the drain", got)
+	}
+	if got, want := d.reading(), "sink 3: steel 2, wood 1"; got != want {
+		t.Errorf("reading %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"math/rand"
+
+	"physicsSim/physics"
+)
+
+// emitter drops a new ball into the world every interval ticks from
+// somewhere within spread either side of position, as long as fewer than
+// limit balls are in play. A limit of zero or less never stops it. Balls
+// leave at speed along direction, made of material, and last lifetime
+// ticks; by default they drop straight down from rest, spread across the
+// screen, and last for good.
+type emitter struct {
+	position  physics.Vector
+	direction physics.Vector
+	spread    float64
+	interval  int
+	limit     int
+	speed     float64
+	material  physics.Material
+	lifetime  int
+
+	countdown int
+	rng       *rand.Rand
+}
+
+func newEmitter(position physics.Vector, spread float64, interval, limit int) *emitter {
+	return &emitter{
+		position:  position,
+		direction: physics.Vector{Y: 1},
+		spread:    spread,
+		interval:  interval,
+		limit:     limit,
+		rng:       rand.New(rand.NewSource(1)),
+	}
+}
+
+// aim sends the emitter's balls off along direction at speed, spread
+// across it rather than across the screen.
+func (e *emitter) aim(direction physics.Vector, speed float64) {
+	e.direction = physics.UnitVector(direction)
+	e.speed = speed
+}
+
+// update counts down one tick and emits a ball when the countdown runs
+// out. It must not be called while a step runs.
+func (e *emitter) update(w *physics.World) {
+	if e.countdown > 0 {
+		e.countdown--
+		return
+	}
+	if e.limit > 0 && len(w.Snapshot()) >= e.limit {
+		return
+	}
+	across := physics.Vector{X: e.direction.Y, Y: -e.direction.X}
+	b := physics.Body{
+		Position: physics.Add(e.position, physics.ScalarMult(across, (e.rng.Float64()*2-1)*e.spread)),
+		Velocity: physics.ScalarMult(e.direction, e.speed),
+		Material: e.material,
+	}
+	if e.lifetime > 0 {
+		b.Expires = w.Steps + uint64(e.lifetime)
+	}
+	w.Spawn(b)
+	e.countdown = e.interval - 1
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestEmitterKeepsToItsRate checks an emitter adds a ball on its first
+// tick and every interval after, stopping at its limit.
+func TestEmitterKeepsToItsRate(t *testing.T) {
+	w := physics.NewWorld(nil, physics.Vector{Y: .3})
+	defer w.Close()
+	e := newEmitter(physics.Vector{X: 320, Y: physics.BallRadius}, 100, 10, 3)
+
+	for tick, want := range []int{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2} {
+		e.update(w)
+		w.Step()
+		if got := len(w.Snapshot()); got != want {
+			t.Fatalf("tick %d: %d balls, want %d", tick, got, want)
+		}
+	}
+	for range 100 {
+		e.update(w)
+		w.Step()
+	}
+	if got := len(w.Snapshot()); got != 3 {
+		t.Errorf("%d balls after the emitter ran on, want its limit of 3", got)
+	}
+}
+
+// TestAimedEmitter checks an aimed emitter sends its balls off at its speed
+// and direction, made of its material, spread across the direction.
+func TestAimedEmitter(t *testing.T) {
+	w := physics.NewWorld(nil, physics.Vector{})
+	defer w.Close()
+	position := physics.Vector{X: 100, Y: 300}
+	e := newEmitter(position, 30, 1, 0)
+	e.aim(physics.Vector{X: 3, Y: -4}, 10)
+	e.material = physics.Wood
+
+	for range 20 {
+		e.update(w)
+	}
+	for i, b := range w.Snapshot() {
+		if b.Velocity != (physics.Vector{X: 6, Y: -8}) || b.Material != physics.Wood {
+			t.Fatalf("ball %d leaves at %v made of %v, want (6, -8) in wood", i, b.Velocity, b.Material)
+		}
+		offset := physics.Subtract(b.Position, position)
+		along := physics.DotProduct(offset, e.direction)
+		if math.Abs(along) > epsilon || offset.Magnitude() > 30+epsilon {
+			t.Errorf("ball %d starts %v from the emitter, want within 30 across its direction", i, offset)
+		}
+	}
+}
+
+// TestEmitterLifetimeBoundsBodies runs an unlimited emitter whose balls
+// expire and checks the number in play levels off at what one lifetime
+// fires.
+func TestEmitterLifetimeBoundsBodies(t *testing.T) {
+	w := physics.NewWorld(nil, physics.Vector{}, physics.WithOpenArena(1000))
+	defer w.Close()
+	e := newEmitter(physics.Vector{X: 320, Y: 240}, 0, 5, 0)
+	e.aim(physics.Vector{X: 1}, 4)
+	e.lifetime = 50
+
+	for range 500 {
+		e.update(w)
+		w.Step()
+	}
+	// A lifetime spans ten shots, the oldest of which may just have gone
+	if n := len(w.Snapshot()); n < 9 || n > 10 {
+		t.Errorf("%d balls in play, want the 9 or 10 one lifetime fires", n)
+	}
+}
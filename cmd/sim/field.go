@@ -0,0 +1,138 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"physicsSim/physics"
+)
+
+// fieldMode is how, if at all, the force fields are drawn over the world.
+type fieldMode int
+
+const (
+	noField fieldMode = iota
+	// fieldArrows draws an arrow at each point of a grid
+	fieldArrows
+	// fieldStreamlines traces a line along the field from each point of
+	// the grid, showing the way a ball let go there would first move
+	fieldStreamlines
+	fieldModes
+)
+
+const (
+	// fieldSpacing is how far apart in the world the fields are sampled,
+	// and fieldHead the length of each arrow's barbs
+	fieldSpacing = 40
+	fieldHead    = 5
+	// streamlineSteps is how many short steps a streamline is traced in,
+	// each a streamlineStep of a grid square long
+	streamlineSteps = 12
+	streamlineStep  = 1.0 / 8
+)
+
+// forceField is one of the world's fields the layer draws, sampled at a
+// world point, in the colour it is drawn in.
+type forceField struct {
+	at    func(physics.Vector) physics.Vector
+	color color.RGBA
+}
+
+// addFields draws every force field at work in the world over the part the
+// camera sees on a screen of the given size: the gravity and attraction a
+// ball would fall by, and the magnetic field a magnetised ball would turn
+// to. A field that is nothing everywhere in view is left out.
+func (f *frame) addFields(w *physics.World, mode fieldMode, cam *camera, width, height float64) {
+	if mode == noField {
+		return
+	}
+	for _, field := range []forceField{{w.FieldAt, f.theme.field}, {w.MagneticFieldAt, f.theme.north}} {
+		strongest := f.sampleField(field.at, cam, width, height)
+		if strongest == 0 {
+			continue
+		}
+		if mode == fieldStreamlines {
+			f.addStreamlines(field, cam)
+		} else {
+			f.addArrows(field.color, strongest, cam)
+		}
+	}
+}
+
+// sampleField samples a field at each point of a grid over the camera's
+// view, fixed in the world so it doesn't crawl as the view pans, into
+// f.field, and returns the strongest it found.
+func (f *frame) sampleField(at func(physics.Vector) physics.Vector, cam *camera, width, height float64) float64 {
+	viewMin, viewMax := cam.view(width, height)
+	first := physics.Vector{
+		X: (math.Floor(viewMin.X/fieldSpacing) + 0.5) * fieldSpacing,
+		Y: (math.Floor(viewMin.Y/fieldSpacing) + 0.5) * fieldSpacing,
+	}
+	f.field = f.field[:0]
+	strongest := 0.0
+	for y := first.Y; y < viewMax.Y+fieldSpacing; y += fieldSpacing {
+		for x := first.X; x < viewMax.X+fieldSpacing; x += fieldSpacing {
+			p := physics.Vector{X: x, Y: y}
+			g := at(p)
+			strongest = max(strongest, g.Magnitude())
+			f.field = append(f.field, [2]physics.Vector{p, g})
+		}
+	}
+	return strongest
+}
+
+// addArrows draws an arrow on each sample, the strongest filling most of a
+// grid square and the rest scaled to match, so the field's shape shows
+// however strong it is.
+func (f *frame) addArrows(c color.RGBA, strongest float64, cam *camera) {
+	for _, sample := range f.field {
+		p, g := sample[0], sample[1]
+		arrow := physics.ScalarMult(g, 0.8*fieldSpacing/strongest)
+		if arrow.Magnitude() < 1 {
+			continue
+		}
+		tail := cam.worldToScreen(physics.Subtract(p, physics.ScalarMult(arrow, 0.5)))
+		tip := cam.worldToScreen(physics.Add(p, physics.ScalarMult(arrow, 0.5)))
+		f.lines = append(f.lines, lineCommand{x1: tail.X, y1: tail.Y, x2: tip.X, y2: tip.Y, color: c})
+		f.addBarbs(tip, arrow, c)
+	}
+}
+
+// addStreamlines traces the field along its direction from each sample,
+// the same length however strong it is, with barbs at the end.
+func (f *frame) addStreamlines(field forceField, cam *camera) {
+	for _, sample := range f.field {
+		p, g := sample[0], sample[1]
+		from := cam.worldToScreen(p)
+		for range streamlineSteps {
+			if g == (physics.Vector{}) {
+				break
+			}
+			// Step by the field halfway along, so lines bend round a
+			// source rather than overshooting it
+			step := physics.ScalarMult(physics.UnitVector(g), streamlineStep*fieldSpacing)
+			middle := field.at(physics.Add(p, physics.ScalarMult(step, 0.5)))
+			if middle == (physics.Vector{}) {
+				break
+			}
+			step = physics.ScalarMult(physics.UnitVector(middle), streamlineStep*fieldSpacing)
+			p = physics.Add(p, step)
+			to := cam.worldToScreen(p)
+			f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: field.color})
+			from, g = to, field.at(p)
+		}
+		if g != (physics.Vector{}) {
+			f.addBarbs(from, g, field.color)
+		}
+	}
+}
+
+// addBarbs draws the two barbs of an arrowhead at tip, pointing along
+// direction.
+func (f *frame) addBarbs(tip, direction physics.Vector, c color.RGBA) {
+	back := physics.ScalarMult(physics.UnitVector(direction), -fieldHead)
+	for _, side := range []float64{-1, 1} {
+		barb := physics.Add(tip, physics.Vector{X: back.X - side*back.Y*0.5, Y: back.Y + side*back.X*0.5})
+		f.lines = append(f.lines, lineCommand{x1: tip.X, y1: tip.Y, x2: barb.X, y2: barb.Y, color: c})
+	}
+}
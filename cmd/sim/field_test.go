@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestFieldArrowsFollowGravity draws the field of a point source and
+// checks there is an arrow in every grid square pointing at it, longest
+// where the pull is strongest.
+func TestFieldArrowsFollowGravity(t *testing.T) {
+	source := physics.Vector{X: 300, Y: 220}
+	w := physics.NewWorld(nil, physics.Vector{}, physics.WithGravitySources(
+		physics.PointGravity{Centre: source, Strength: 100, Radius: 10},
+	))
+	defer w.Close()
+
+	var cam camera
+	f := frame{theme: defaultPalette}
+	f.addFields(w, fieldArrows, &cam, physics.ScreenWidth, physics.ScreenHeight)
+	if got, want := len(f.field), (physics.ScreenWidth/fieldSpacing+1)*(physics.ScreenHeight/fieldSpacing+1); got != want {
+		t.Fatalf("sampled the field at %d points, want %d", got, want)
+	}
+	longest, longestAt := 0.0, physics.Vector{}
+	for k := 0; k+2 < len(f.lines); k += 3 {
+		shaft := f.lines[k]
+		tail, tip := physics.Vector{X: shaft.x1, Y: shaft.y1}, physics.Vector{X: shaft.x2, Y: shaft.y2}
+		arrow, towards := physics.Subtract(tip, tail), physics.Subtract(source, tail)
+		if physics.DotProduct(arrow, towards) <= 0 {
+			t.Errorf("arrow from %v to %v points away from the source", tail, tip)
+		}
+		if length := arrow.Magnitude(); length > longest {
+			longest, longestAt = length, physics.ScalarMult(physics.Add(tail, tip), 0.5)
+		}
+	}
+	if offset := physics.Subtract(longestAt, source); offset.Magnitude() > fieldSpacing {
+		t.Errorf("longest arrow at %v, want it by the source at %v", longestAt, source)
+	}
+}
+
+// TestStreamlinesRunDownhill traces streamlines through plain downward
+// gravity and checks each one runs straight down its full length, and that
+// a world with no magnets draws no magnetic field.
+func TestStreamlinesRunDownhill(t *testing.T) {
+	w := physics.NewWorld(nil, physics.Vector{Y: 0.3})
+	defer w.Close()
+
+	var cam camera
+	f := frame{theme: defaultPalette}
+	f.addFields(w, fieldStreamlines, &cam, physics.ScreenWidth, physics.ScreenHeight)
+	samples := (physics.ScreenWidth/fieldSpacing + 1) * (physics.ScreenHeight/fieldSpacing + 1)
+	if got, want := len(f.lines), samples*(streamlineSteps+2); got != want {
+		t.Fatalf("drew %d lines, want %d steps and 2 barbs for each of %d streamlines", got, streamlineSteps+2, samples)
+	}
+	for _, l := range f.lines[:streamlineSteps] {
+		if l.x1 != l.x2 || l.y2 <= l.y1 {
+			t.Errorf("streamline step from (%v, %v) to (%v, %v), want it straight down", l.x1, l.y1, l.x2, l.y2)
+		}
+	}
+
+	f.lines = f.lines[:0]
+	f.addFields(w, noField, &cam, physics.ScreenWidth, physics.ScreenHeight)
+	if len(f.lines) != 0 {
+		t.Errorf("drew %d lines with the fields off", len(f.lines))
+	}
+}
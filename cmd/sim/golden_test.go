@@ -0,0 +1,149 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite golden frames in testdata")
+
+const (
+	// goldenChannelTolerance is how far a colour channel may drift before
+	// a pixel counts as different.
+	goldenChannelTolerance = 8
+
+	// goldenPixelTolerance is the fraction of pixels allowed to differ,
+	// absorbing sub-pixel jitter along circle edges.
+	goldenPixelTolerance = 0.002
+)
+
+func TestGoldenFrames(t *testing.T) {
+	tests := []struct {
+		name   string
+		scene  scene
+		steps  int
+		camera camera
+	}{
+		{"default-start", defaultScene(), 0, camera{}},
+		{"default-120", defaultScene(), 120, camera{}},
+		// The torus scene's balls, left to bounce off the walls of a plain box
+		{"box-300", scene{objects: torusScene().objects}, 300, camera{}},
+		{"box-300-panned", scene{objects: torusScene().objects}, 300, camera{position: physics.Vector{X: 200, Y: 150}}},
+		{"cradle-90", newtonsCradleScene(), 90, camera{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := tt.scene.build(physics.WithDeterminism())
+			defer w.Close()
+			for i := 0; i < tt.steps; i++ {
+				w.Step()
+			}
+
+			var f frame
+			f.build(w, &tt.camera, physics.ScreenWidth, physics.ScreenHeight)
+			got := f.rasterize(physics.ScreenWidth, physics.ScreenHeight)
+			// Checked before updating too, so a blank frame is never saved
+			if blank(got, f.background) {
+				t.Fatal("frame is all background, nothing was drawn")
+			}
+
+			path := filepath.Join("testdata", "golden", tt.name+".png")
+			if *updateGolden {
+				if err := writePNG(path, got); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := readPNG(path)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if err := compareImages(got, want); err != nil {
+				t.Errorf("%s: %v", path, err)
+			}
+		})
+	}
+}
+
+// blank reports whether every pixel of img is the background colour.
+func blank(img *image.RGBA, background color.RGBA) bool {
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		for x := img.Rect.Min.X; x < img.Rect.Max.X; x++ {
+			if img.RGBAAt(x, y) != background {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// compareImages fails if too many pixels differ by more than the channel
+// tolerance.
+func compareImages(got *image.RGBA, want image.Image) error {
+	if got.Bounds() != want.Bounds() {
+		return fmt.Errorf("size %v, want %v", got.Bounds(), want.Bounds())
+	}
+
+	differing := 0
+	bounds := got.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r1, g1, b1, _ := got.At(x, y).RGBA()
+			r2, g2, b2, _ := want.At(x, y).RGBA()
+			if channelDiff(r1, r2) > goldenChannelTolerance ||
+				channelDiff(g1, g2) > goldenChannelTolerance ||
+				channelDiff(b1, b2) > goldenChannelTolerance {
+				differing++
+			}
+		}
+	}
+
+	total := bounds.Dx() * bounds.Dy()
+	if fraction := float64(differing) / float64(total); fraction > goldenPixelTolerance {
+		return fmt.Errorf("%d of %d pixels differ (%.3f%%)", differing, total, fraction*100)
+	}
+	return nil
+}
+
+// channelDiff compares two 16-bit colour channels on an 8-bit scale.
+func channelDiff(a, b uint32) uint32 {
+	a, b = a>>8, b>>8
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func readPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return png.Decode(file)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
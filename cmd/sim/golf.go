@@ -0,0 +1,203 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"physicsSim/physics"
+)
+
+const (
+	// holeRadius is the size of the cup. A ball whose middle comes within
+	// it drops in, unless it is going faster than sinkSpeed and lips out.
+	holeRadius = 1.5 * physics.BallRadius
+	sinkSpeed  = 4
+	// restSpeed is how slowly the ball must be rolling before it can be
+	// hit again
+	restSpeed = 0.05
+
+	// The green is slower than a pool table's cloth, and its walls softer
+	greenSliding     = 0.25
+	greenRolling     = 0.04
+	greenRestitution = 0.6
+)
+
+//go:embed courses/*.json
+var courseFiles embed.FS
+
+// course is one hole of mini-golf, read from a scene file with a hole:
+// where the ball starts, where the hole is and the obstacles between them.
+type course struct {
+	Name string
+	Par  int
+	Tee  [2]float64
+	Hole [2]float64
+	obstacles
+}
+
+// loadCourses reads every course in the directory dir of fsys, in order
+// of file name. Each is a scene file, its tee where its first ball starts.
+func loadCourses(fsys fs.FS, dir string) ([]*course, error) {
+	paths, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no courses in %s", dir)
+	}
+
+	courses := make([]*course, 0, len(paths))
+	for _, p := range paths {
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return nil, err
+		}
+		s, err := parseScene(p, data)
+		if err != nil {
+			return nil, err
+		}
+		if s.golf == nil {
+			return nil, fmt.Errorf("%s: no hole, so not a course", p)
+		}
+		courses = append(courses, s.golf.courses...)
+	}
+	return courses, nil
+}
+
+func (c *course) tee() physics.Vector {
+	return physics.Vector{X: c.Tee[0], Y: c.Tee[1]}
+}
+
+func (c *course) hole() physics.Vector {
+	return physics.Vector{X: c.Hole[0], Y: c.Hole[1]}
+}
+
+// golf is a round of mini-golf played over a list of courses. The ball,
+// body 0, is hit with a cue once it has stopped, and every hit is a
+// stroke. Once it drops into the hole a click moves on to the next course,
+// and after the last one starts the round again.
+type golf struct {
+	courses []*course
+	current int
+	strokes int
+	// card holds the strokes taken on each course finished this round
+	card []int
+	sunk bool
+	cue  *cue
+}
+
+func newGolf(courses []*course) *golf {
+	return &golf{courses: courses, cue: newCue(0)}
+}
+
+func (g *golf) course() *course {
+	return g.courses[g.current]
+}
+
+// options returns the green and the first course's obstacles.
+func (g *golf) options() []physics.WorldOption {
+	return []physics.WorldOption{
+		physics.WithCloth(greenSliding, greenRolling),
+		physics.WithWallRestitution(greenRestitution),
+		func(w *physics.World) { g.course().place(w) },
+	}
+}
+
+// resting reports whether the ball has stopped and can be hit.
+func (g *golf) resting(w *physics.World) bool {
+	objects := w.Snapshot()
+	return !g.sunk && len(objects) > 0 && objects[0].Velocity.Magnitude() < restSpeed
+}
+
+// press picks up the cue at the point at, if the ball can be hit, or moves
+// on to the next course once this one is done.
+func (g *golf) press(w *physics.World, at physics.Vector) {
+	switch {
+	case g.sunk:
+		g.next(w)
+	case g.resting(w):
+		g.cue.press(w.Snapshot(), at)
+	}
+}
+
+// release hits the ball if the cue is being aimed, counting the stroke.
+func (g *golf) release(w *physics.World) {
+	if g.cue.release(w) {
+		g.strokes++
+	}
+}
+
+// next sets up the next course with the ball on its tee, going back to the
+// first and a fresh card after the last.
+func (g *golf) next(w *physics.World) {
+	g.current++
+	if g.current == len(g.courses) {
+		g.current = 0
+		g.card = g.card[:0]
+	}
+	g.strokes, g.sunk = 0, false
+	g.course().place(w)
+	w.Teleport(0, g.course().tee(), physics.Vector{})
+}
+
+// update drops the ball into the hole if it has rolled onto it slowly
+// enough. Call it after every step.
+func (g *golf) update(w *physics.World) {
+	objects := w.Snapshot()
+	if g.sunk || len(objects) == 0 {
+		return
+	}
+	currBall := objects[0]
+	offset := physics.Subtract(currBall.Position, g.course().hole())
+	if offset.Magnitude() > holeRadius || currBall.Velocity.Magnitude() > sinkSpeed {
+		return
+	}
+	w.Teleport(0, g.course().hole(), physics.Vector{})
+	g.sunk = true
+	g.card = append(g.card, g.strokes)
+}
+
+// overPar returns how many strokes over par the finished courses took, or
+// under par if negative.
+func (g *golf) overPar() int {
+	total := 0
+	for k, strokes := range g.card {
+		total += strokes - g.courses[k].Par
+	}
+	return total
+}
+
+// scoreName names a score on one course.
+func scoreName(strokes, par int) string {
+	if strokes == 1 {
+		return text("golf.holeInOne")
+	}
+	switch strokes - par {
+	case -2:
+		return text("golf.eagle")
+	case -1:
+		return text("golf.birdie")
+	case 0:
+		return text("golf.par")
+	case 1:
+		return text("golf.bogey")
+	case 2:
+		return text("golf.doubleBogey")
+	}
+	return fmt.Sprintf("%+d", strokes-par)
+}
+
+// reading describes the course, the strokes taken and the round so far.
+func (g *golf) reading() string {
+	c := g.course()
+	status := text("golf.status", g.current+1, len(g.courses), c.Name, c.Par, g.strokes, g.overPar())
+	switch {
+	case g.sunk && g.current == len(g.courses)-1:
+		return status + "  " + text("golf.again", scoreName(g.strokes, c.Par))
+	case g.sunk:
+		return status + "  " + text("golf.next", scoreName(g.strokes, c.Par))
+	}
+	return status
+}
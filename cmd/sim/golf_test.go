@@ -0,0 +1,109 @@
+package main
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"physicsSim/physics"
+)
+
+// TestCoursesLoad checks every built-in course loads with its tee and hole
+// on the screen and clear of its obstacles, and that a scene file without
+// a hole, or a par to play it in, is turned away.
+func TestCoursesLoad(t *testing.T) {
+	courses, err := loadCourses(courseFiles, "courses")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range courses {
+		w := physics.NewWorld(nil, physics.Vector{})
+		c.place(w)
+		for _, p := range []physics.Vector{c.tee(), c.hole()} {
+			if p.X < physics.BallRadius || p.X > physics.ScreenWidth-physics.BallRadius || p.Y < physics.BallRadius || p.Y > physics.ScreenHeight-physics.BallRadius {
+				t.Errorf("%s: %v is off the screen", c.Name, p)
+			}
+			var near []physics.Vector
+			for _, s := range w.StaticSegments {
+				near = append(near, s.Closest(p))
+			}
+			for _, b := range w.StaticBoxes {
+				near = append(near, b.Closest(p))
+			}
+			for _, b := range w.StaticCircles {
+				offset := physics.Subtract(p, b.Position)
+				near = append(near, physics.Add(b.Position, physics.ScalarMult(physics.UnitVector(offset), b.Radius)))
+			}
+			for _, q := range near {
+				if gap := physics.Subtract(p, q); gap.Magnitude() < physics.BallRadius {
+					t.Errorf("%s: a ball at %v would overlap the obstacle at %v", c.Name, p, q)
+				}
+			}
+		}
+		w.Close()
+	}
+
+	for name, data := range map[string]string{
+		"no par":  `{"name": "Bad", "hole": [560, 240], "balls": [{"position": [80, 240], "velocity": [0, 0]}]}`,
+		"no hole": `{"name": "Bad", "balls": [{"position": [80, 240], "velocity": [0, 0]}]}`,
+	} {
+		broken := fstest.MapFS{"courses/bad.json": {Data: []byte(data)}}
+		if _, err := loadCourses(broken, "courses"); err == nil {
+			t.Errorf("loaded a course with %s", name)
+		}
+	}
+}
+
+// putt strikes the ball towards target with the cue pulled back pull
+// pixels.
+func putt(w *physics.World, g *golf, target physics.Vector, pull float64) {
+	at := w.Snapshot()[0].Position
+	g.press(w, at)
+	g.cue.drag(physics.Subtract(at, physics.ScalarMult(physics.UnitVector(physics.Subtract(target, at)), pull)))
+	g.release(w)
+}
+
+// TestPuttingOut putts a ball along an open green: a hard putt runs over
+// the hole and off the far wall, then gentler ones from wherever it stops
+// drop it in, counting every stroke, and a click moves on to the next
+// course.
+func TestPuttingOut(t *testing.T) {
+	open := &course{Name: "Open", Par: 3, Tee: [2]float64{100, 240}, Hole: [2]float64{400, 240}}
+	next := &course{Name: "Next", Par: 2, Tee: [2]float64{320, 400}, Hole: [2]float64{320, 80}}
+	g := newGolf([]*course{open, next})
+	w := physics.NewWorld([]physics.Body{{Position: open.tee()}}, physics.Vector{}, g.options()...)
+	defer w.Close()
+
+	putt(w, g, open.hole(), 500)
+	for tick := 0; tick < 600 && !g.resting(w); tick++ {
+		w.Step()
+		g.update(w)
+	}
+	if g.sunk {
+		t.Fatal("a full-power putt dropped in")
+	}
+
+	for g.strokes < 10 && !g.sunk {
+		putt(w, g, open.hole(), 60)
+		for tick := 0; tick < 600 && !g.sunk && !g.resting(w); tick++ {
+			w.Step()
+			g.update(w)
+		}
+	}
+	if !g.sunk {
+		t.Fatalf("ball still out after %d strokes", g.strokes)
+	}
+	if len(g.card) != 1 || g.card[0] != g.strokes || g.strokes < 2 {
+		t.Fatalf("card %v after %d strokes, want the strokes recorded", g.card, g.strokes)
+	}
+	if got := w.Snapshot()[0].Position; got != open.hole() {
+		t.Errorf("sunk ball at %v, want it in the hole at %v", got, open.hole())
+	}
+
+	g.press(w, physics.Vector{})
+	if g.current != 1 || g.strokes != 0 || g.sunk {
+		t.Fatalf("on course %d with %d strokes after clicking, want a fresh second course", g.current, g.strokes)
+	}
+	if got := w.Snapshot()[0].Position; got != next.tee() {
+		t.Errorf("ball at %v, want it on the next tee at %v", got, next.tee())
+	}
+}
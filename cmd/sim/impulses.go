@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strconv"
+
+	"physicsSim/physics"
+)
+
+const (
+	// impulseWindow is how many ticks back the impulse plot reaches
+	impulseWindow = 5 * physics.TicksPerSecond
+	// plotWidth and plotHeight size the impulse plot in the top-right
+	// corner of the screen, plotMargin in from its edges
+	plotWidth  = 200
+	plotHeight = 80
+	plotMargin = 8
+)
+
+// impulseSample is one impact on the recorded body: the step it happened
+// in, the body on the other side or NoBody for a wall, and the impulse it
+// exchanged.
+type impulseSample struct {
+	step    uint64
+	other   int
+	impulse float64
+}
+
+// impulseRecorder keeps the impulse of every impact on one body as the
+// world steps, for tuning materials and checking crash scenarios against.
+// Bodies renumber when one is removed, so it can end up on another.
+type impulseRecorder struct {
+	body    int
+	samples []impulseSample
+}
+
+// newImpulseRecorder returns a recorder recording nothing.
+func newImpulseRecorder() impulseRecorder {
+	return impulseRecorder{body: physics.NoBody}
+}
+
+// toggle starts recording body i afresh, or stops if it is the one being
+// recorded already.
+func (r *impulseRecorder) toggle(i int) {
+	if r.body == i {
+		r.body = physics.NoBody
+	} else {
+		r.body = i
+	}
+	r.samples = r.samples[:0]
+}
+
+// record adds the impacts on the body in the world's last step. Stepping
+// on again after a rewind replaces what was recorded from there on.
+func (r *impulseRecorder) record(w *physics.World) {
+	if r.body == physics.NoBody {
+		return
+	}
+	step := w.Steps
+	kept := len(r.samples)
+	for kept > 0 && r.samples[kept-1].step >= step {
+		kept--
+	}
+	r.samples = r.samples[:kept]
+	for _, hit := range w.LastImpacts() {
+		switch r.body {
+		case hit.A:
+			r.samples = append(r.samples, impulseSample{step: step, other: hit.B, impulse: hit.Impulse})
+		case hit.B:
+			r.samples = append(r.samples, impulseSample{step: step, other: hit.A, impulse: hit.Impulse})
+		}
+	}
+}
+
+// recent returns the samples from the impulseWindow ticks up to step now.
+func (r *impulseRecorder) recent(now uint64) []impulseSample {
+	first := len(r.samples)
+	for first > 0 && r.samples[first-1].step+impulseWindow > now {
+		first--
+	}
+	return r.samples[first:]
+}
+
+// reading describes the hardest impact in the plot and how many there are.
+func (r *impulseRecorder) reading(now uint64) string {
+	recent := r.recent(now)
+	peak := 0.0
+	for _, s := range recent {
+		peak = max(peak, s.impulse)
+	}
+	return text("hud.impulses", r.body, peak, len(recent))
+}
+
+// writeCSV writes every sample recorded as CSV with a header row: the
+// step, the time in seconds, the other body, -1 for a wall, and the
+// impulse.
+func (r *impulseRecorder) writeCSV(out io.Writer) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"step", "seconds", "other", "impulse"})
+	for _, s := range r.samples {
+		w.Write([]string{
+			strconv.FormatUint(s.step, 10),
+			strconv.FormatFloat(float64(s.step)/physics.TicksPerSecond, 'f', -1, 64),
+			strconv.Itoa(s.other),
+			strconv.FormatFloat(s.impulse, 'g', -1, 64),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// buildImpulsePlot fills the frame with a plot of the recorded body's
+// impacts over the last impulseWindow ticks up to step now, in the
+// top-right corner of a screen the given width: a bar for each, as tall as
+// its impulse against the hardest shown, with the newest on the right.
+func (f *frame) buildImpulsePlot(r *impulseRecorder, now uint64, width float64) {
+	if f.theme == nil {
+		f.theme = defaultPalette
+	}
+	f.rects = f.rects[:0]
+	f.lines = f.lines[:0]
+	f.circles = f.circles[:0]
+	f.texts = f.texts[:0]
+
+	left, top := width-plotMargin-plotWidth, float64(plotMargin)
+	bottom := top + plotHeight
+	f.rects = append(f.rects, rectCommand{x: left, y: top, width: plotWidth, height: plotHeight, color: f.theme.minimap})
+	f.lines = append(f.lines, lineCommand{x1: left, y1: bottom, x2: left + plotWidth, y2: bottom, color: f.theme.text})
+
+	recent := r.recent(now)
+	peak := 0.0
+	for _, s := range recent {
+		peak = max(peak, s.impulse)
+	}
+	if peak == 0 {
+		return
+	}
+	for _, s := range recent {
+		x := left + plotWidth*(1-float64(now-s.step)/impulseWindow)
+		f.lines = append(f.lines, lineCommand{x1: x, y1: bottom, x2: x, y2: bottom - plotHeight*s.impulse/peak, color: f.theme.histogram})
+	}
+}
+
+// writeImpulseLog writes the recorded impulses to the CSV file at path.
+func writeImpulseLog(path string, r *impulseRecorder) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := r.writeCSV(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestImpulseRecorderRecordsHits rolls one ball into another and checks the
+// recorder on the second hears the one hit, keeps it once through a rewind
+// and a replay, plots it and writes it out.
+func TestImpulseRecorderRecordsHits(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{
+		{Position: physics.Vector{X: 100, Y: 200}, Velocity: physics.Vector{X: 5}},
+		{Position: physics.Vector{X: 300, Y: 200}},
+	}, physics.Vector{})
+	defer w.Close()
+	history := physics.NewHistory(120)
+	history.Record(w)
+
+	r := newImpulseRecorder()
+	r.toggle(1)
+	step := func(n int) {
+		for range n {
+			w.Step()
+			r.record(w)
+			history.Record(w)
+		}
+	}
+	step(60)
+	for range 40 {
+		history.Rewind(w)
+	}
+	step(40)
+
+	if len(r.samples) != 1 {
+		t.Fatalf("recorded %v, want the one hit", r.samples)
+	}
+	hit := r.samples[0]
+	if hit.other != 0 || hit.impulse <= 0 {
+		t.Errorf("recorded %+v, want a hit by body 0 with some impulse", hit)
+	}
+
+	var f frame
+	f.buildImpulsePlot(&r, w.Steps, physics.ScreenWidth)
+	if len(f.lines) != 2 {
+		t.Fatalf("plot has %d lines, want the axis and one bar", len(f.lines))
+	}
+	if bar := f.lines[1]; bar.y1-bar.y2 != plotHeight {
+		t.Errorf("the hardest hit's bar is %v tall, want the plot's full %v", bar.y1-bar.y2, plotHeight)
+	}
+	f.buildImpulsePlot(&r, w.Steps+impulseWindow, physics.ScreenWidth)
+	if len(f.lines) != 1 {
+		t.Errorf("plot has %d lines once the hit is out of the window, want just the axis", len(f.lines))
+	}
+
+	var out strings.Builder
+	if err := r.writeCSV(&out); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || lines[0] != "step,seconds,other,impulse" || !strings.Contains(lines[1], ",0,") {
+		t.Errorf("CSV %q, want a header and the hit", out.String())
+	}
+
+	r.toggle(1)
+	if r.body != physics.NoBody || len(r.samples) != 0 {
+		t.Errorf("toggling the recorded body left it on %d with %d samples", r.body, len(r.samples))
+	}
+}
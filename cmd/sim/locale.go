@@ -0,0 +1,132 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// locale maps the key of every piece of HUD text to the format it is shown
+// with, as for fmt.Sprintf. A translation can take the arguments in another
+// order with explicit indexes such as %[2]d. Anything a locale leaves out
+// is shown in English.
+type locale map[string]string
+
+var english = locale{
+	"hud.fps":         "FPS: %.2f",
+	"hud.speed":       "speed %gx",
+	"hud.paused":      "paused at %gx, . steps",
+	"hud.rewinding":   "rewinding %gx, %.1f s left",
+	"hud.halted":      "halted: body %d went bad in step %d",
+	"hud.limited":     "stopped: body %d went past the limits in step %d",
+	"hud.integrate":   "integrate %s (%s)",
+	"hud.broadphase":  "broadphase %s",
+	"hud.narrowphase": "narrowphase %s",
+	"hud.solver":      "solver %s",
+	"hud.overlap":     "overlap %.2f px",
+	"hud.render":      "render %s",
+	"hud.steps":       "step p50 %s p95 %s p99 %s",
+	"hud.bodies":      "bodies %d contacts %d islands %d",
+	"hud.english":     "english side %+.2f follow %+.2f",
+	"hud.landed":      "landed %d",
+	"hud.impulses":    "body %d: peak %.2f, %d hits",
+
+	"telemetry.energy":     "energy %.1f, %+.2f since shown",
+	"telemetry.parts":      "kinetic %.1f potential %.1f",
+	"telemetry.momentum":   "momentum %.2f, %.2f, off by %.3f since shown",
+	"telemetry.collisions": "collisions %d/s bodies %d",
+
+	"sweep.run": "%s %s, run %d of %d, next in %.1f s",
+
+	"stopwatch.running": "%s %.2f s (%d ticks) running",
+	"stopwatch.stopped": "%s %.2f s (%d ticks) stopped",
+	"drain.empty":       "%s 0",
+	"drain.total":       "%s %d: %s",
+	"drain.count":       "%s %d",
+	"material.rubber":   "rubber",
+	"material.steel":    "steel",
+	"material.wood":     "wood",
+	"material.clay":     "clay",
+	"material.snow":     "snow",
+
+	"measure.ruler":      "ruler: click two points",
+	"measure.protractor": "protractor: click three points, the angle is at the second",
+	"measure.distance":   "%.1f units = %.2f m",
+	"measure.angle":      "%.1f deg",
+
+	"breakout.score":   "score %d  lives %d",
+	"breakout.cleared": "cleared! click to play again",
+	"breakout.over":    "game over, click to play again",
+	"breakout.serve":   "click to serve",
+
+	"golf.status":      "hole %d/%d %s  par %d  strokes %d  round %+d",
+	"golf.holeInOne":   "hole in one",
+	"golf.eagle":       "eagle",
+	"golf.birdie":      "birdie",
+	"golf.par":         "par",
+	"golf.bogey":       "bogey",
+	"golf.doubleBogey": "double bogey",
+	"golf.next":        "%s! click for the next hole",
+	"golf.again":       "%s! click to play again",
+
+	"car.speed": "speed %.0f px/s  right and left to drive, down to brake",
+}
+
+// messages is the locale the HUD is shown in.
+var messages = english
+
+// text formats the HUD text with the given key in the current locale.
+func text(key string, args ...any) string {
+	format, ok := messages[key]
+	if !ok {
+		format = english[key]
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// localeNames lists the built-in locales, English first.
+func localeNames() []string {
+	names := []string{"en"}
+	paths, _ := fs.Glob(localeFiles, "locales/*.json")
+	for _, p := range paths {
+		names = append(names, strings.TrimSuffix(path.Base(p), ".json"))
+	}
+	return names
+}
+
+// loadLocale returns the built-in locale with the given name, or else
+// reads one from the JSON file at that path. Keys English doesn't have are
+// rejected, as is text the HUD's font can't draw, which only covers
+// Latin-1.
+func loadLocale(name string) (locale, error) {
+	if name == "en" {
+		return english, nil
+	}
+	data, err := localeFiles.ReadFile(path.Join("locales", name+".json"))
+	if err != nil {
+		if data, err = os.ReadFile(name); err != nil {
+			return nil, fmt.Errorf("no locale %q built in or at that path, choose one of: %s", name, strings.Join(localeNames(), ", "))
+		}
+	}
+
+	var l locale
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	for key, format := range l {
+		if _, ok := english[key]; !ok {
+			return nil, fmt.Errorf("%s: unknown key %q", name, key)
+		}
+		if k := strings.IndexFunc(format, func(r rune) bool { return r > 0xff }); k >= 0 {
+			return nil, fmt.Errorf("%s: %q has %q, which the HUD font can't draw", name, key, []rune(format[k:])[0])
+		}
+	}
+	return l, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// verbs matches the formatting verbs in a format, other than %%.
+var verbs = regexp.MustCompile(`%[-+# 0]*[0-9.]*[a-z]`)
+
+// sampleArgs returns arguments of the kinds the English format for key
+// takes.
+func sampleArgs(key string) []any {
+	var args []any
+	for _, verb := range verbs.FindAllString(english[key], -1) {
+		switch verb[len(verb)-1] {
+		case 'd':
+			args = append(args, 3)
+		case 'f', 'g':
+			args = append(args, 2.5)
+		default:
+			args = append(args, "x")
+		}
+	}
+	return args
+}
+
+// TestBuiltInLocales checks every built-in locale loads, translates every
+// key, and takes the same arguments as English.
+func TestBuiltInLocales(t *testing.T) {
+	for _, name := range localeNames() {
+		l, err := loadLocale(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key := range english {
+			format, ok := l[key]
+			if !ok {
+				t.Errorf("%s: %s is not translated", name, key)
+				continue
+			}
+			if got := fmt.Sprintf(format, sampleArgs(key)...); strings.Contains(got, "%!") {
+				t.Errorf("%s: %s formats as %q", name, key, got)
+			}
+		}
+	}
+}
+
+// TestTextFallsBackToEnglish checks a key a locale leaves out is shown in
+// English.
+func TestTextFallsBackToEnglish(t *testing.T) {
+	defer func() { messages = english }()
+	messages = locale{"breakout.serve": "sacar"}
+	if got := text("breakout.serve"); got != "sacar" {
+		t.Errorf("translated text = %q", got)
+	}
+	if got := text("breakout.score", 10, 2); got != "score 10  lives 2" {
+		t.Errorf("untranslated text = %q", got)
+	}
+}
+
+// TestLoadLocaleFile checks a locale can be read from a file, and that
+// unknown keys and text the HUD font can't draw are rejected.
+func TestLoadLocaleFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, c := range []struct {
+		name, data string
+		ok         bool
+	}{
+		{"good.json", `{"hud.landed": "gelandet %d"}`, true},
+		{"unknown.json", `{"hud.landing": "gelandet %d"}`, false},
+		{"font.json", `{"hud.landed": "着地 %d"}`, false},
+		{"broken.json", `{"hud.landed": `, false},
+	} {
+		path := filepath.Join(dir, c.name)
+		if err := os.WriteFile(path, []byte(c.data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		l, err := loadLocale(path)
+		if (err == nil) != c.ok {
+			t.Errorf("%s: err = %v", c.name, err)
+		}
+		if c.ok && l["hud.landed"] != "gelandet %d" {
+			t.Errorf("%s: loaded %v", c.name, l)
+		}
+	}
+	if _, err := loadLocale("xx"); err == nil {
+		t.Error("missing locale loaded")
+	}
+}
@@ -0,0 +1,1016 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"physicsSim/physics"
+)
+
+type Game struct {
+	world *physics.World
+	// views are drawn in order, and focus is the one the keyboard moves
+	views []*viewport
+	focus int
+	// screenWidth and screenHeight are the window's size in pixels, which
+	// the views are fitted to
+	screenWidth  float64
+	screenHeight float64
+	canvases     []*ebiten.Image
+	colors       []color.RGBA
+	// theme is the palette everything is drawn in, and hud holds the HUD
+	// text until it is tinted to match
+	theme *palette
+	hud   *ebiten.Image
+	// antialias draws shapes placed to a fraction of a pixel
+	antialias bool
+	// minimap maps the whole world in a corner of the screen when shown
+	minimap     frame
+	showMinimap bool
+
+	trails      *trails
+	captions    []caption
+	cue         *cue
+	breakout    *breakout
+	golf        *golf
+	car         *car
+	bins        *bins
+	emitters    []*emitter
+	drains      []*drain
+	portals     []*portal
+	stopwatches []*stopwatch
+	// vacuumPaths are where the scene's vacuum bodies would have gone
+	// without air, from where they started
+	vacuumPaths [][]physics.Vector
+	sounds      *sounds
+	labels      labelMode
+	measure     measurement
+	spawner     spawner
+
+	// showBounds outlines every body's bounding box, and boxes holds them
+	// between frames
+	showBounds bool
+	boxes      []physics.AABB
+	// fields is how the force fields are drawn over the world, if at all
+	fields fieldMode
+	// impulses records the impacts on one body, plotted in the corner
+	impulses    impulseRecorder
+	impulsePlot frame
+	// telemetry reads out the world's energy and momentum under the HUD
+	telemetry telemetry
+	// sweep, if set, runs the scene again for each value of a parameter
+	sweep *sweep
+	// trajectories records whole paths to export on exit, if asked to
+	trajectories *trajectories
+
+	// timeControl sets how fast the world is stepped, or pauses it
+	timeControl TimeController
+	// clock hands out the ticks due each update, however often that is
+	clock clock
+	// history holds the recent past, which the world is stepped back
+	// through instead of on while rewinding
+	history   *physics.History
+	rewinding bool
+	// autosaver, if set, saves the world to disk every so often
+	autosaver *autosaver
+
+	// interest holds the middle of every view, for level of detail
+	interest []physics.Vector
+
+	// renderTime is how long the last Draw spent drawing bodies, and
+	// stepTimes how long the latest steps took
+	renderTime time.Duration
+	stepTimes  stepTimes
+}
+
+const (
+	panSpeed    = 8
+	trailLength = 400
+	maxSpeed    = 64
+)
+
+func (g *Game) Update() error {
+	g.handleViewInput()
+	g.handleCameraInput()
+	g.handleCueInput()
+	g.handleBreakoutInput()
+	g.handleGolfInput()
+	g.handleCarInput()
+	g.handleTimeInput()
+	g.handleRewindInput()
+	g.handleSoundInput()
+	g.handlePaletteInput()
+	g.handleLabelInput()
+	g.handleMeasureInput()
+	g.handleSpawnInput()
+	g.handleFreezeInput()
+	g.handleImpulseInput()
+	g.handleIntegratorInput()
+	g.handleTelemetryInput()
+	g.handleSweepInput()
+
+	// Bodies near the middle of a view always get a full update
+	g.interest = g.interest[:0]
+	for _, v := range g.views {
+		g.interest = append(g.interest, v.centre())
+	}
+	g.world.SetInterestPoints(g.interest...)
+	steps := g.timeControl.steps(&g.clock, time.Now())
+	if g.rewinding {
+		g.clock.forget()
+	}
+	for i := 0; g.rewinding && i < steps; i++ {
+		if !g.history.Rewind(g.world) {
+			break
+		}
+	}
+	for i := 0; !g.rewinding && i < steps; i++ {
+		if i == steps-1 {
+			g.clock.remember(g.world.Snapshot())
+		}
+		for _, e := range g.emitters {
+			e.update(g.world)
+		}
+		started := time.Now()
+		g.world.Step()
+		g.stepTimes.add(time.Since(started))
+		if g.breakout != nil {
+			g.breakout.update(g.world)
+		}
+		if g.golf != nil {
+			g.golf.update(g.world)
+		}
+		for _, s := range g.stopwatches {
+			s.update(g.world)
+		}
+		for _, d := range g.drains {
+			d.collect(g.world)
+		}
+		for _, p := range g.portals {
+			p.update(g.world)
+		}
+		g.sounds.hear(g.world.LastImpacts())
+		g.impulses.record(g.world)
+		if g.trajectories != nil {
+			g.trajectories.record(g.world)
+		}
+		g.history.Record(g.world)
+		if g.autosaver != nil {
+			// A failed save shouldn't stop the run it is there to protect
+			if err := g.autosaver.update(g.world); err != nil {
+				fmt.Fprintf(os.Stderr, "autosave: %v\n", err)
+			}
+		}
+	}
+	if g.sweep != nil && g.sweep.over(g.world) {
+		g.nextRun()
+	}
+	// A paused world is drawn where it stepped to, not on the way there
+	if g.timeControl.paused {
+		g.clock.forget()
+	}
+	g.sounds.play(g.world.Snapshot(), g.views[0])
+	g.trails.record(g.world.Snapshot())
+	// Views follow bodies where they are drawn, so they don't judder
+	for _, v := range g.views {
+		v.track(g.clock.blend(g.world.Snapshot()))
+	}
+	if g.bins != nil {
+		g.bins.collect(g.world)
+	}
+	return nil
+}
+
+// handleViewInput splits the screen into an overview and a close-up with
+// V, and joins it back into one view. Tab hands the keyboard to the next
+// view, and N shows and hides the minimap.
+func (g *Game) handleViewInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyN) {
+		g.showMinimap = !g.showMinimap
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyV) {
+		if len(g.views) > 1 {
+			// Join back onto whatever the view with the keyboard was showing
+			single := fitView(g.world.Arena.Extent(), g.screenWidth, g.screenHeight)
+			single.lookAt(g.views[g.focus].centre())
+			g.setViews(single)
+		} else {
+			g.fitViews(true)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		g.focus = (g.focus + 1) % len(g.views)
+	}
+}
+
+// handleCameraInput pans the view that has the keyboard with the arrow keys,
+// zooms it with = and -, and sets it following the body nearest its middle
+// with F, or stops it. Only Up pans while there is a car to drive.
+func (g *Game) handleCameraInput() {
+	v := g.views[g.focus]
+	driving := g.car != nil
+	if !driving && ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
+		v.pan(physics.Vector{X: -panSpeed})
+	}
+	if !driving && ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
+		v.pan(physics.Vector{X: panSpeed})
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowUp) {
+		v.pan(physics.Vector{Y: -panSpeed})
+	}
+	if !driving && ebiten.IsKeyPressed(ebiten.KeyArrowDown) {
+		v.pan(physics.Vector{Y: panSpeed})
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) {
+		v.zoomBy(2)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) {
+		v.zoomBy(0.5)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		v.toggleFollow(g.world.Snapshot())
+	}
+}
+
+// fitViews fits one view, or an overview and close-up when split, to the
+// whole world on the current screen.
+func (g *Game) fitViews(split bool) {
+	extent := g.world.Arena.Extent()
+	if split {
+		g.setViews(splitViews(g.world.Snapshot(), extent, g.screenWidth, g.screenHeight)...)
+		return
+	}
+	g.setViews(fitView(extent, g.screenWidth, g.screenHeight))
+}
+
+// setViews shows the world through views, the first with the keyboard.
+func (g *Game) setViews(views ...*viewport) {
+	for _, v := range views {
+		v.frame.colors = g.colors
+		v.frame.clock = &g.clock
+	}
+	g.views = views
+	g.focus = 0
+}
+
+// pointer returns the mouse's point on the screen and the view it is over,
+// or the first view if it is over none.
+func (g *Game) pointer() (float64, float64, *viewport) {
+	x, y := ebiten.CursorPosition()
+	at := physics.Vector{X: float64(x), Y: float64(y)}
+	for _, v := range g.views {
+		if v.contains(at) {
+			return at.X, at.Y, v
+		}
+	}
+	return at.X, at.Y, g.views[0]
+}
+
+// cursor returns the world point under the mouse, through whichever view
+// it is over.
+func (g *Game) cursor() physics.Vector {
+	x, y, v := g.pointer()
+	return v.ScreenToWorld(physics.Vector{X: x, Y: y})
+}
+
+// handleTimeInput speeds the simulation up with ] and slows it down with
+// [, from a tenth of real time up to maxSpeed. Space pauses and carries on,
+// and . takes a single step, pausing first if need be.
+func (g *Game) handleTimeInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyBracketRight) {
+		g.timeControl.faster()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBracketLeft) {
+		g.timeControl.slower()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		g.timeControl.toggle()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyPeriod) {
+		g.timeControl.step()
+	}
+}
+
+// handleRewindInput rewinds the world while R is held, at the current
+// speed, or a step at a time with . while paused. Letting go carries on
+// from wherever it got back to.
+func (g *Game) handleRewindInput() {
+	g.rewinding = ebiten.IsKeyPressed(ebiten.KeyR)
+}
+
+// handlePaletteInput switches to the next palette with P.
+func (g *Game) handlePaletteInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		k := slices.Index(palettes, g.theme)
+		g.theme = palettes[(k+1)%len(palettes)]
+	}
+}
+
+// handleSoundInput mutes and unmutes collision sounds with M.
+func (g *Game) handleSoundInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		g.sounds.muted = !g.sounds.muted
+	}
+}
+
+// handleLabelInput cycles the body labels with L, shows and hides the
+// bodies' bounding boxes with X, and cycles the force fields with G.
+func (g *Game) handleLabelInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		g.labels = (g.labels + 1) % labelModes
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyX) {
+		g.showBounds = !g.showBounds
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		g.fields = (g.fields + 1) % fieldModes
+	}
+}
+
+// handleIntegratorInput moves the world on to the next integrator with K.
+func (g *Game) handleIntegratorInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyK) {
+		k := integratorIndex(g.world.Integrator)
+		g.world.Integrator = integrators[(k+1)%len(integrators)].integrator
+	}
+}
+
+// handleTelemetryInput shows and hides the telemetry panel with E.
+func (g *Game) handleTelemetryInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyE) {
+		g.telemetry.toggle(g.world.Stats())
+	}
+}
+
+// handleSweepInput skips on to the next run of a sweep with Enter.
+func (g *Game) handleSweepInput() {
+	if g.sweep != nil && inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+		g.nextRun()
+	}
+}
+
+// nextRun starts the sweep's next run from the start of its scene.
+func (g *Game) nextRun() {
+	g.sweep.next()
+	g.load(g.sweep.scene(), g.sweep.options...)
+	g.clock.forget()
+	g.history.Record(g.world)
+}
+
+// handleMeasureInput cycles the measuring tools with T and places their
+// points with the left mouse button.
+func (g *Game) handleMeasureInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		g.measure.cycle()
+		g.spawner.active = false
+		g.spawner.release(g.world)
+	}
+	if g.measure.tool != noTool && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		g.measure.click(g.cursor())
+	}
+}
+
+// handleSpawnInput turns the spawner on and off with B. While it is on, a
+// left click drops a ball under the cursor, or presses on a ball to pick
+// it up: it follows the mouse until the button is let go, and is thrown on
+// at the speed the mouse was moving.
+func (g *Game) handleSpawnInput() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyB) {
+		g.spawner.active = !g.spawner.active
+		g.spawner.release(g.world)
+		g.measure.tool = noTool
+		g.measure.points = g.measure.points[:0]
+	}
+	if !g.spawner.active {
+		return
+	}
+	x, y, view := g.pointer()
+	mouse, now := view.ScreenToWorld(physics.Vector{X: x, Y: y}), time.Now()
+	g.spawner.aim(g.world, mouse)
+	switch {
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		g.spawner.press(g.world, x, y, view, now)
+	case inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft):
+		g.spawner.drag(g.world, mouse, now)
+		g.spawner.release(g.world)
+	case g.spawner.holding:
+		g.spawner.drag(g.world, mouse, now)
+	}
+}
+
+// handleFreezeInput freezes the body under the mouse with Z, or thaws it
+// if it is frozen already.
+func (g *Game) handleFreezeInput() {
+	if !inpututil.IsKeyJustPressed(ebiten.KeyZ) {
+		return
+	}
+	i, ok := g.world.BodyAtScreenPoint(g.pointer())
+	if !ok {
+		return
+	}
+	if g.world.Snapshot()[i].Frozen {
+		g.world.Thaw(i)
+	} else {
+		g.world.Freeze(i)
+	}
+}
+
+// handleImpulseInput starts recording the impulses on the body under the
+// mouse with I, or stops if it is the one being recorded.
+func (g *Game) handleImpulseInput() {
+	if !inpututil.IsKeyJustPressed(ebiten.KeyI) {
+		return
+	}
+	if i, ok := g.world.BodyAtScreenPoint(g.pointer()); ok {
+		g.impulses.toggle(i)
+	}
+}
+
+// handleBreakoutInput steers the paddle after the mouse and serves with
+// the left button.
+func (g *Game) handleBreakoutInput() {
+	if g.breakout == nil || g.measure.tool != noTool || g.spawner.active {
+		return
+	}
+	g.breakout.steer(g.world, g.cursor().X)
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		g.breakout.click(g.world)
+	}
+}
+
+// handleGolfInput putts with the mouse like the cue: press near the ball,
+// drag back and release. Once the ball is in the hole a click moves on.
+func (g *Game) handleGolfInput() {
+	if g.golf == nil || g.measure.tool != noTool || g.spawner.active {
+		return
+	}
+
+	mouse := g.cursor()
+	switch {
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		g.golf.press(g.world, mouse)
+	case inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft):
+		g.golf.cue.drag(mouse)
+		g.golf.release(g.world)
+	case g.golf.cue.aiming:
+		g.golf.cue.drag(mouse)
+	}
+}
+
+// handleCarInput drives the car forward with Right, back with Left and
+// brakes with Down.
+func (g *Game) handleCarInput() {
+	if g.car == nil {
+		return
+	}
+	var throttle float64
+	if ebiten.IsKeyPressed(ebiten.KeyArrowRight) {
+		throttle++
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyArrowLeft) {
+		throttle--
+	}
+	g.car.drive(g.world, throttle, ebiten.IsKeyPressed(ebiten.KeyArrowDown))
+}
+
+// handleCueInput aims and shoots the cue with the mouse: press near the cue
+// ball, drag back and release. W and S move the tip up and down the ball
+// for follow and draw, A and D across it for side english.
+func (g *Game) handleCueInput() {
+	// The mouse belongs to a measuring tool or the spawner while one is out
+	if g.cue == nil || g.measure.tool != noTool || g.spawner.active {
+		return
+	}
+
+	mouse := g.cursor()
+	switch {
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft):
+		g.cue.press(g.world.Snapshot(), mouse)
+	case inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft):
+		g.cue.drag(mouse)
+		g.cue.release(g.world)
+	case g.cue.aiming:
+		g.cue.drag(mouse)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyW) {
+		g.cue.adjustEnglish(0, 1)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		g.cue.adjustEnglish(0, -1)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyA) {
+		g.cue.adjustEnglish(-1, 0)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyD) {
+		g.cue.adjustEnglish(1, 0)
+	}
+}
+
+func (g *Game) Draw(screen *ebiten.Image) {
+
+	started := time.Now()
+	prediction := g.cue.predict(g.world)
+	for len(g.canvases) < len(g.views) {
+		g.canvases = append(g.canvases, nil)
+	}
+	for k, v := range g.views {
+		g.canvases[k] = g.drawView(screen, g.canvases[k], v, prediction)
+	}
+	if len(g.views) > 1 {
+		v := g.views[g.focus]
+		ebitenutil.DrawRect(screen, v.at.X, v.at.Y, v.width, 1, g.theme.focus)
+		ebitenutil.DrawRect(screen, v.at.X, v.at.Y+v.height-1, v.width, 1, g.theme.focus)
+		ebitenutil.DrawRect(screen, v.at.X, v.at.Y, 1, v.height, g.theme.focus)
+		ebitenutil.DrawRect(screen, v.at.X+v.width-1, v.at.Y, 1, v.height, g.theme.focus)
+	}
+	if g.showMinimap {
+		g.minimap.colors = g.colors
+		g.minimap.theme = g.theme
+		g.minimap.antialias = g.antialias
+		g.minimap.buildMinimap(g.world, g.views, g.focus, g.screenWidth, g.screenHeight)
+		drawCommands(screen, &g.minimap)
+	}
+	if g.impulses.body != physics.NoBody {
+		g.impulsePlot.theme = g.theme
+		g.impulsePlot.antialias = g.antialias
+		g.impulsePlot.buildImpulsePlot(&g.impulses, g.world.Steps, g.screenWidth)
+		drawCommands(screen, &g.impulsePlot)
+	}
+	g.renderTime = time.Since(started)
+
+	// The HUD is printed white and tinted to the palette's text colour
+	if width, height := int(g.screenWidth), int(g.screenHeight); g.hud == nil || g.hud.Bounds().Dx() != width || g.hud.Bounds().Dy() != height {
+		g.hud = ebiten.NewImage(width, height)
+	}
+	g.hud.Clear()
+	stats := g.world.Stats()
+	timings := stats.Timings
+	speed := text("hud.speed", g.timeControl.Scale())
+	var limited *physics.LimitError
+	switch d := g.world.Halted(); {
+	case d != nil:
+		speed = text("hud.halted", d.Body, d.Step)
+	case errors.As(g.world.Err(), &limited):
+		speed = text("hud.limited", limited.Body, limited.Step)
+	case g.rewinding:
+		speed = text("hud.rewinding", g.timeControl.Scale(), g.history.Seconds())
+	case g.timeControl.paused:
+		speed = text("hud.paused", g.timeControl.Scale())
+	}
+	lines := []string{
+		text("hud.fps", ebiten.ActualFPS()),
+		speed,
+		text("hud.integrate", milliseconds(timings.Integration), integrators[integratorIndex(g.world.Integrator)].name),
+		text("hud.broadphase", milliseconds(timings.Broadphase)),
+		text("hud.narrowphase", milliseconds(timings.Narrowphase)),
+		text("hud.solver", milliseconds(timings.Solver)),
+		text("hud.overlap", stats.Penetration),
+		text("hud.render", milliseconds(g.renderTime)),
+		text("hud.bodies", stats.Bodies, stats.Contacts, stats.Islands),
+		g.stepTimes.reading(),
+	}
+	ebitenutil.DebugPrint(g.hud, strings.Join(lines, "\n"))
+	// Stopwatches, named drains and then telemetry read out under the
+	// timings
+	line := len(lines)
+	for _, s := range g.stopwatches {
+		ebitenutil.DebugPrintAt(g.hud, s.reading(g.world.Steps), 0, line*glyphHeight)
+		line++
+	}
+	for _, d := range g.drains {
+		if d.name == "" {
+			continue
+		}
+		ebitenutil.DebugPrintAt(g.hud, d.reading(), 0, line*glyphHeight)
+		line++
+	}
+	for _, reading := range g.telemetry.lines(stats) {
+		ebitenutil.DebugPrintAt(g.hud, reading, 0, line*glyphHeight)
+		line++
+	}
+	if g.impulses.body != physics.NoBody {
+		ebitenutil.DebugPrintAt(g.hud, g.impulses.reading(g.world.Steps), int(g.screenWidth)-plotMargin-plotWidth, plotMargin+plotHeight)
+	}
+	if g.sweep != nil {
+		ebitenutil.DebugPrintAt(g.hud, g.sweep.reading(g.world), 0, int(g.screenHeight)-3*glyphHeight)
+	}
+	if prompt := g.measure.prompt(); prompt != "" {
+		ebitenutil.DebugPrintAt(g.hud, prompt, 0, int(g.screenHeight)-2*glyphHeight)
+	}
+	if g.cue != nil {
+		ebitenutil.DebugPrintAt(g.hud, text("hud.english", g.cue.english.X, g.cue.english.Y), 0, int(g.screenHeight)-glyphHeight)
+	}
+	if g.bins != nil {
+		ebitenutil.DebugPrintAt(g.hud, text("hud.landed", g.bins.total), 0, int(g.screenHeight)-glyphHeight)
+	}
+	if g.breakout != nil {
+		ebitenutil.DebugPrintAt(g.hud, g.breakout.reading(), 0, int(g.screenHeight)-glyphHeight)
+	}
+	if g.golf != nil {
+		ebitenutil.DebugPrintAt(g.hud, g.golf.reading(), 0, int(g.screenHeight)-glyphHeight)
+	}
+	if g.car != nil {
+		ebitenutil.DebugPrintAt(g.hud, g.car.reading(g.world), 0, int(g.screenHeight)-glyphHeight)
+	}
+
+	var op ebiten.DrawImageOptions
+	op.ColorScale.ScaleWithColor(g.theme.text)
+	screen.DrawImage(g.hud, &op)
+}
+
+// drawView draws the world as v sees it onto canvas, a world-scale image
+// the size of the view's span, then scales that into place on the screen.
+// It returns the canvas, made anew if the old one was missing or the
+// wrong size.
+func (g *Game) drawView(screen, canvas *ebiten.Image, v *viewport, prediction []physics.Vector) *ebiten.Image {
+	width, height := v.span()
+	w, h := int(math.Ceil(width)), int(math.Ceil(height))
+	if canvas == nil || canvas.Bounds().Dx() != w || canvas.Bounds().Dy() != h {
+		if canvas != nil {
+			canvas.Deallocate()
+		}
+		canvas = ebiten.NewImage(w, h)
+	}
+
+	f, cam := &v.frame, &v.camera
+	f.theme = g.theme
+	f.antialias = g.antialias
+	f.build(g.world, cam, width, height)
+	f.addTrails(g.trails, cam)
+	f.addCue(g.cue, g.world.Snapshot(), cam)
+	f.addBreakout(g.breakout, cam)
+	f.addGolf(g.golf, g.world.Snapshot(), cam)
+	f.addPrediction(prediction, cam)
+	f.addVacuumPaths(g.vacuumPaths, cam)
+	f.addHistogram(g.bins, cam)
+	f.addZones(g.stopwatches, cam)
+	f.addDrains(g.drains, cam)
+	f.addPortals(g.portals, cam)
+	f.addLabels(g.world.Snapshot(), g.labels, cam)
+	if g.showBounds {
+		g.boxes = g.world.BoundingBoxes(g.boxes[:0])
+		f.addBoundingBoxes(g.boxes, cam)
+	}
+	f.addFields(g.world, g.fields, cam, width, height)
+	f.addMeasurement(&g.measure, cam)
+	f.addGhost(&g.spawner, cam)
+	canvas.Fill(f.background)
+	drawCommands(canvas, f)
+	for _, c := range g.captions {
+		at := cam.worldToScreen(c.position)
+		ebitenutil.DebugPrintAt(canvas, c.text, int(at.X)-glyphWidth*len(c.text)/2, int(at.Y)-glyphHeight/2)
+	}
+	if g.bins != nil {
+		g.drawBinCounts(canvas, cam)
+	}
+
+	var op ebiten.DrawImageOptions
+	op.GeoM.Scale(v.zoom, v.zoom)
+	op.GeoM.Translate(v.at.X, v.at.Y)
+	screen.DrawImage(canvas, &op)
+	return canvas
+}
+
+// drawCommands draws everything in the frame onto target, over what is
+// there already.
+func drawCommands(target *ebiten.Image, f *frame) {
+	for _, r := range f.rects {
+		vector.DrawFilledRect(target, float32(r.x), float32(r.y), float32(r.width), float32(r.height), r.color, f.antialias)
+	}
+	for _, l := range f.lines {
+		vector.StrokeLine(target, float32(l.x1), float32(l.y1), float32(l.x2), float32(l.y2), 1, l.color, f.antialias)
+	}
+	for _, c := range f.circles {
+		vector.DrawFilledCircle(target, float32(c.x), float32(c.y), float32(c.radius), c.color, f.antialias)
+	}
+	for _, t := range f.texts {
+		ebitenutil.DebugPrintAt(target, t.text, int(t.x), int(t.y))
+	}
+}
+
+// drawBinCounts prints each bin's count centred just above it.
+func (g *Game) drawBinCounts(screen *ebiten.Image, cam *camera) {
+	for i, count := range g.bins.counts {
+		label := strconv.Itoa(count)
+		at := cam.worldToScreen(physics.Vector{X: g.bins.left + (float64(i)+0.5)*g.bins.width, Y: g.bins.top})
+		ebitenutil.DebugPrintAt(screen, label, int(at.X)-glyphWidth*len(label)/2, int(at.Y)-glyphHeight)
+	}
+}
+
+func milliseconds(d time.Duration) string {
+	return fmt.Sprintf("%.2fms", float64(d)/float64(time.Millisecond))
+}
+
+// Layout draws at the window's own size, refitting the views to the whole
+// world whenever it changes.
+func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if width, height := float64(outsideWidth), float64(outsideHeight); width != g.screenWidth || height != g.screenHeight {
+		g.screenWidth, g.screenHeight = width, height
+		g.fitViews(len(g.views) > 1)
+	}
+	return outsideWidth, outsideHeight
+}
+
+// newGame builds the scene's world, with any further options, and the
+// game that runs and draws it.
+func newGame(s scene, options ...physics.WorldOption) *Game {
+	game := &Game{
+		screenWidth:  physics.ScreenWidth,
+		screenHeight: physics.ScreenHeight,
+		theme:        defaultPalette,
+		sounds:       newSounds(),
+		impulses:     newImpulseRecorder(),
+		timeControl:  newTimeController(),
+		clock:        clock{smooth: true},
+	}
+	game.load(s, options...)
+	game.fitViews(false)
+	return game
+}
+
+// load builds the scene's world, with any further options, in place of
+// the game's last one, along with everything else the game keeps from
+// the scene. The history starts afresh, so rewinding stops at the start.
+func (g *Game) load(s scene, options ...physics.WorldOption) {
+	if g.world != nil {
+		g.spawner.release(g.world)
+		g.world.Close()
+	}
+	g.world = s.build(options...)
+	g.colors = s.colors
+	g.trails = newTrails(trailLength, s.trails)
+	g.captions = s.captions
+	g.cue = s.cue
+	g.breakout = s.breakout
+	g.golf = s.golf
+	g.car = s.car
+	g.bins = s.bins
+	g.emitters = s.emitters
+	g.drains = s.drains
+	g.portals = s.portals
+	g.stopwatches = s.stopwatches
+	g.vacuumPaths = g.vacuumPaths[:0]
+	for _, i := range s.vacuum {
+		g.vacuumPaths = append(g.vacuumPaths, g.world.VacuumPath(i, trailLength))
+	}
+	g.history = physics.NewHistory(physics.RewindSeconds * physics.TicksPerSecond)
+}
+
+// guardModes are the NaN guard's modes by the name -nan-guard takes.
+var guardModes = map[string]physics.GuardMode{
+	"halt":  physics.HaltOnNaN,
+	"clamp": physics.ClampNaN,
+}
+
+// limitPolicies are the policies for bodies past the world's limits by the
+// name -limit-policy takes.
+var limitPolicies = map[string]physics.LimitPolicy{
+	"clamp":   physics.ClampToLimits,
+	"destroy": physics.DestroyPastLimits,
+	"wrap":    physics.WrapPastLimits,
+	"error":   physics.ErrorPastLimits,
+}
+
+// namedIntegrator is an integrator by the name -integrator takes.
+type namedIntegrator struct {
+	name       string
+	integrator physics.Integrator
+}
+
+// integrators are the world's integrators in the order K cycles through
+// them, starting from the world's own.
+var integrators = []namedIntegrator{
+	{"euler", physics.SymplecticEuler{}},
+	{"verlet", physics.VelocityVerlet{}},
+	{"rk4", physics.RK4{}},
+}
+
+// integratorIndex returns where integrator is in integrators, taking nil
+// as the symplectic Euler a world moves with unless given another.
+func integratorIndex(integrator physics.Integrator) int {
+	if integrator == nil {
+		integrator = physics.SymplecticEuler{}
+	}
+	return slices.IndexFunc(integrators, func(n namedIntegrator) bool { return n.integrator == integrator })
+}
+
+// integratorNames returns the names of the integrators, in order.
+func integratorNames() []string {
+	names := make([]string, len(integrators))
+	for i, n := range integrators {
+		names[i] = n.name
+	}
+	return names
+}
+
+// limitPolicyNames returns the names of the limit policies, sorted.
+func limitPolicyNames() []string {
+	names := make([]string, 0, len(limitPolicies))
+	for name := range limitPolicies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func main() {
+	preset := flag.String("preset", "default", "built-in scene to run: "+strings.Join(presetNames(), ", "))
+	sceneFile := flag.String("scene", "", "JSON scene file to run instead of a preset")
+	autosave := flag.Duration("autosave", 0, "simulation time between autosaves, or 0 for none")
+	autosaveDir := flag.String("autosave-dir", "autosave", "directory to keep autosaves in")
+	resume := flag.String("resume", "", "autosave to start from, or latest for the newest in -autosave-dir")
+	paletteName := flag.String("palette", defaultPalette.name, "colours to draw in: "+strings.Join(paletteNames(), ", "))
+	localeName := flag.String("locale", "en", "language of the HUD: "+strings.Join(localeNames(), ", ")+", or a JSON file of translations")
+	tps := flag.Int("tps", 0, "updates per second, or 0 for one a frame; the simulation's own rate stays the same")
+	cellSize := flag.Float64("cell-size", 0, "width of the collision grid's cells, or 0 for the width of the largest ball")
+	interpolate := flag.Bool("interpolate", true, "draw bodies part way between steps when frames come faster than steps")
+	curved := flag.Bool("curved", false, "with -interpolate, draw bodies between steps along curves that keep their velocity, for smooth slow motion")
+	antialias := flag.Bool("antialias", true, "draw shapes with soft edges placed to a fraction of a pixel, so slow bodies glide rather than jump pixel to pixel")
+	maxSpeed := flag.Float64("max-speed", 0, "fastest a body may go in world units per tick, or 0 for no limit")
+	maxDistance := flag.Float64("max-distance", 0, "furthest a body may go from the origin in world units along either axis, or 0 for no limit")
+	limitPolicy := flag.String("limit-policy", "clamp", "what to do with a body past -max-speed or -max-distance: "+strings.Join(limitPolicyNames(), ", "))
+	window := flag.String("window", fmt.Sprintf("%dx%d", physics.ScreenWidth, physics.ScreenHeight), "starting window size as WIDTHxHEIGHT; the world is scaled to fit it, and again whenever the window is resized")
+	recordImpulses := flag.Int("record-impulses", physics.NoBody, "body to record and plot the impulses on from the start, as I does for the body under the mouse")
+	impulseLog := flag.String("impulse-log", "", "CSV file to write the recorded impulses to on exit")
+	exportTrails := flag.String("export-trails", "", "GeoJSON file to write the whole paths of the trailed bodies to on exit, or of every body in a scene without trails")
+	exportEvery := flag.Int("export-every", 1, "steps between the points of each path -export-trails writes")
+	integratorName := flag.String("integrator", "euler", "how bodies move through each tick, which K changes as it runs: "+strings.Join(integratorNames(), ", "))
+	sweepSpec := flag.String("sweep", "", "run the scene over and over with one parameter stepped each time, as parameter=from:to:step such as restitution=0:1:0.1; parameters: "+strings.Join(sweepParameterNames(), ", "))
+	sweepTime := flag.Duration("sweep-time", 5*time.Second, "simulation time each run of a -sweep lasts")
+	nanGuard := flag.String("nan-guard", "off", "what to do with a body gone to NaN or infinity: off, halt or clamp")
+	flag.Parse()
+
+	var err error
+	if messages, err = loadLocale(*localeName); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(2)
+	}
+
+	theme, ok := paletteNamed(*paletteName)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown palette %q, choose one of: %s\n", *paletteName, strings.Join(paletteNames(), ", "))
+		os.Exit(2)
+	}
+
+	options := []physics.WorldOption{physics.WithCellSize(*cellSize)}
+	k := slices.IndexFunc(integrators, func(n namedIntegrator) bool { return n.name == *integratorName })
+	if k < 0 {
+		fmt.Fprintf(os.Stderr, "unknown -integrator %q, choose one of: %s\n", *integratorName, strings.Join(integratorNames(), ", "))
+		os.Exit(2)
+	}
+	options = append(options, physics.WithIntegrator(integrators[k].integrator))
+	if *nanGuard != "off" {
+		mode, ok := guardModes[*nanGuard]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown -nan-guard %q, choose one of: off, halt, clamp\n", *nanGuard)
+			os.Exit(2)
+		}
+		options = append(options, physics.WithNaNGuard(mode, func(d *physics.Diagnosis) {
+			fmt.Fprintln(os.Stderr, d.Error())
+		}))
+	}
+	if *maxSpeed > 0 || *maxDistance > 0 {
+		policy, ok := limitPolicies[*limitPolicy]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown -limit-policy %q, choose one of: %s\n", *limitPolicy, strings.Join(limitPolicyNames(), ", "))
+			os.Exit(2)
+		}
+		options = append(options, physics.WithLimits(physics.Limits{Speed: *maxSpeed, Distance: *maxDistance, Policy: policy}))
+	}
+
+	// Resuming runs the preset the autosave was taken from
+	var saved *savedWorld
+	if *resume != "" {
+		path := *resume
+		var err error
+		if path == "latest" {
+			path, err = latestAutosave(*autosaveDir)
+		}
+		if err == nil {
+			saved, err = loadSavedWorld(path)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cannot resume: %v\n", err)
+			os.Exit(2)
+		}
+		*preset = saved.Preset
+	}
+
+	// A scene file isn't a preset an autosave could name to build it again
+	if *sceneFile != "" && (*resume != "" || *autosave > 0) {
+		fmt.Fprintln(os.Stderr, "cannot autosave or resume a scene file, only a preset")
+		os.Exit(2)
+	}
+
+	// A sweep is a run from the start of each value, not one to pick up
+	if *sweepSpec != "" && (*resume != "" || *autosave > 0) {
+		fmt.Fprintln(os.Stderr, "cannot autosave or resume a sweep")
+		os.Exit(2)
+	}
+
+	var game *Game
+	var newScene func() scene
+	switch {
+	case *sceneFile != "" && *sweepSpec == "":
+		var err error
+		if game, err = LoadScene(*sceneFile, options...); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot load scene: %v\n", err)
+			os.Exit(2)
+		}
+	case *sceneFile != "":
+		// A scene file has none of a preset's moving parts to build
+		// afresh, so every run of a sweep can start from the one read
+		s, err := readScene(*sceneFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "cannot load scene: %v\n", err)
+			os.Exit(2)
+		}
+		newScene = func() scene { return s }
+	default:
+		newScene, ok = presets[*preset]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown preset %q, choose one of: %s\n", *preset, strings.Join(presetNames(), ", "))
+			os.Exit(2)
+		}
+	}
+	switch {
+	case *sweepSpec != "":
+		s, err := parseSweep(*sweepSpec, uint64(sweepTime.Seconds()*physics.TicksPerSecond), newScene)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		s.options = options
+		game = newGame(s.scene(), options...)
+		game.sweep = s
+	case game == nil:
+		game = newGame(newScene(), options...)
+	}
+	game.theme = theme
+	game.clock.smooth = *interpolate
+	game.clock.curved = *curved
+	game.antialias = *antialias
+	if *recordImpulses != physics.NoBody {
+		game.impulses.toggle(*recordImpulses)
+	}
+
+	var windowWidth, windowHeight int
+	if _, err := fmt.Sscanf(*window, "%dx%d", &windowWidth, &windowHeight); err != nil || windowWidth <= 0 || windowHeight <= 0 {
+		fmt.Fprintf(os.Stderr, "bad -window %q, want WIDTHxHEIGHT such as 640x480\n", *window)
+		os.Exit(2)
+	}
+	ebiten.SetWindowSize(windowWidth, windowHeight)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	// The clock steps the world at its own rate, so updating once a frame
+	// is enough, or at a set rate to try it at others
+	ebiten.SetTPS(ebiten.SyncWithFPS)
+	if *tps > 0 {
+		ebiten.SetTPS(*tps)
+	}
+	ebiten.SetWindowTitle("Bouncing Balls")
+
+	if saved != nil {
+		saved.apply(game.world)
+	}
+	if *autosave > 0 {
+		game.autosaver = newAutosaver(*autosaveDir, *preset, *autosave)
+	}
+	if *exportTrails != "" {
+		game.trajectories = newTrajectories(game.world, game.trails.bodies, *exportEvery)
+	}
+	game.history.Record(game.world)
+
+	if err := ebiten.RunGame(game); err != nil {
+		panic(err)
+	}
+	if *exportTrails != "" {
+		run := *preset
+		if *sceneFile != "" {
+			run = filepath.Base(*sceneFile)
+		}
+		if err := writeTrajectories(*exportTrails, run, game.trajectories); err != nil {
+			fmt.Fprintf(os.Stderr, "export trails: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *impulseLog != "" {
+		if err := writeImpulseLog(*impulseLog, &game.impulses); err != nil {
+			fmt.Fprintf(os.Stderr, "impulse log: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import "physicsSim/physics"
+
+// unitsPerMeter is how many world units the measuring tools read as a
+// metre.
+const unitsPerMeter = 100
+
+// measureTool is which measuring tool, if any, the mouse is using.
+type measureTool int
+
+const (
+	noTool measureTool = iota
+	// ruler measures the distance between two points
+	ruler
+	// protractor measures the angle at the second of three points
+	protractor
+	measureTools
+)
+
+// measurement is a set of world points clicked with a measuring tool.
+type measurement struct {
+	tool   measureTool
+	points []physics.Vector
+}
+
+// cycle switches to the next tool, dropping any points placed.
+func (m *measurement) cycle() {
+	m.tool = (m.tool + 1) % measureTools
+	m.points = m.points[:0]
+}
+
+// needed returns how many points the tool measures between.
+func (m *measurement) needed() int {
+	switch m.tool {
+	case ruler:
+		return 2
+	case protractor:
+		return 3
+	}
+	return 0
+}
+
+// complete reports whether every point the tool needs has been placed.
+func (m *measurement) complete() bool {
+	return m.tool != noTool && len(m.points) == m.needed()
+}
+
+// click places the next point, starting over once a measurement is
+// complete.
+func (m *measurement) click(p physics.Vector) {
+	if m.tool == noTool {
+		return
+	}
+	if m.complete() {
+		m.points = m.points[:0]
+	}
+	m.points = append(m.points, p)
+}
+
+// prompt tells the player how to use the current tool.
+func (m *measurement) prompt() string {
+	switch m.tool {
+	case ruler:
+		return text("measure.ruler")
+	case protractor:
+		return text("measure.protractor")
+	}
+	return ""
+}
+
+// reading describes a complete measurement, or returns "" if it isn't.
+func (m *measurement) reading() string {
+	if !m.complete() {
+		return ""
+	}
+	switch m.tool {
+	case ruler:
+		length := physics.Subtract(m.points[1], m.points[0])
+		distance := length.Magnitude()
+		return text("measure.distance", distance, distance/unitsPerMeter)
+	case protractor:
+		vertex := m.points[1]
+		angle := physics.AngleBetweenVectors(physics.Subtract(m.points[0], vertex), physics.Subtract(m.points[2], vertex))
+		return text("measure.angle", angle)
+	}
+	return ""
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"physicsSim/physics"
+)
+
+func TestRulerReading(t *testing.T) {
+	var m measurement
+	m.cycle()
+	m.click(physics.Vector{X: 100, Y: 100})
+	if got := m.reading(); got != "" {
+		t.Fatalf("reading with one point = %q, want none", got)
+	}
+	m.click(physics.Vector{X: 400, Y: 500})
+	if got, want := m.reading(), "500.0 units = 5.00 m"; got != want {
+		t.Errorf("reading = %q, want %q", got, want)
+	}
+
+	// A third click starts a new measurement
+	m.click(physics.Vector{X: 10, Y: 10})
+	if len(m.points) != 1 {
+		t.Errorf("%d points after starting over, want 1", len(m.points))
+	}
+}
+
+func TestProtractorReading(t *testing.T) {
+	m := measurement{tool: protractor}
+	for _, p := range []physics.Vector{{X: 200, Y: 100}, {X: 100, Y: 100}, {X: 100, Y: 0}} {
+		m.click(p)
+	}
+	if got, want := m.reading(), "90.0 deg"; got != want {
+		t.Errorf("reading = %q, want %q", got, want)
+	}
+}
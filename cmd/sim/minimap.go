@@ -0,0 +1,82 @@
+package main
+
+import (
+	"image/color"
+
+	"physicsSim/physics"
+)
+
+const (
+	// minimapWidth and minimapHeight bound the minimap in the screen's
+	// corner, and minimapMargin keeps it off the edges
+	minimapWidth  = 160
+	minimapHeight = 120
+	minimapMargin = 8
+	// minimapDot is the radius of a body on the minimap
+	minimapDot = 1.5
+)
+
+// worldExtent returns the corners of the smallest box holding the arena's
+// extent and every body.
+func worldExtent(arena physics.AABB, objects []physics.Body) (physics.Vector, physics.Vector) {
+	lo, hi := arena.Min, arena.Max
+	for _, b := range objects {
+		p, r := b.Position, b.Size()
+		lo = physics.Vector{X: min(lo.X, p.X-r), Y: min(lo.Y, p.Y-r)}
+		hi = physics.Vector{X: max(hi.X, p.X+r), Y: max(hi.Y, p.Y+r)}
+	}
+	return lo, hi
+}
+
+// buildMinimap fills the frame with a map of the whole world in the
+// bottom-right corner of a screen of the given size: a dot for every body
+// and the outline of what each view shows, the one with the keyboard
+// picked out.
+func (f *frame) buildMinimap(w *physics.World, views []*viewport, focus int, width, height float64) {
+	if f.theme == nil {
+		f.theme = defaultPalette
+	}
+	f.rects = f.rects[:0]
+	f.lines = f.lines[:0]
+	f.circles = f.circles[:0]
+	f.texts = f.texts[:0]
+
+	// Take in every view too, so none runs off the map
+	objects := w.Snapshot()
+	lo, hi := worldExtent(w.Arena.Extent(), objects)
+	for _, v := range views {
+		spanX, spanY := v.span()
+		lo = physics.Vector{X: min(lo.X, v.camera.position.X), Y: min(lo.Y, v.camera.position.Y)}
+		hi = physics.Vector{X: max(hi.X, v.camera.position.X+spanX), Y: max(hi.Y, v.camera.position.Y+spanY)}
+	}
+	span := physics.Subtract(hi, lo)
+	scale := min(minimapWidth/span.X, minimapHeight/span.Y)
+	size := physics.ScalarMult(span, scale)
+	corner := physics.Vector{X: width - minimapMargin - size.X, Y: height - minimapMargin - size.Y}
+	toMap := func(p physics.Vector) physics.Vector {
+		return physics.Add(corner, physics.ScalarMult(physics.Subtract(p, lo), scale))
+	}
+
+	f.rects = append(f.rects, rectCommand{x: corner.X, y: corner.Y, width: size.X, height: size.Y, color: f.theme.minimap})
+	for i := range objects {
+		at := toMap(objects[i].Position)
+		f.circles = append(f.circles, circleCommand{x: at.X, y: at.Y, radius: minimapDot, color: f.colorOf(objects, i)})
+	}
+	for k, v := range views {
+		c := f.theme.rod
+		if k == focus {
+			c = f.theme.focus
+		}
+		spanX, spanY := v.span()
+		f.outline(toMap(v.camera.position), toMap(physics.Add(v.camera.position, physics.Vector{X: spanX, Y: spanY})), c)
+	}
+}
+
+// outline appends the four sides of the rectangle from min to max.
+func (f *frame) outline(min, max physics.Vector, c color.RGBA) {
+	corners := [...]physics.Vector{min, {X: max.X, Y: min.Y}, max, {X: min.X, Y: max.Y}}
+	for k, from := range corners {
+		to := corners[(k+1)%len(corners)]
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: c})
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestMinimapShowsWholeWorld puts a body and a view well off the screen
+// and checks the minimap still fits in its corner with every body on it
+// and the view outlined inside it.
+func TestMinimapShowsWholeWorld(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{
+		{Position: physics.Vector{X: 100, Y: 100}},
+		{Position: physics.Vector{X: 2000, Y: 300}},
+	}, physics.Vector{}, physics.WithOpenArena(10000))
+	defer w.Close()
+	v := newViewport(physics.Vector{}, physics.ScreenWidth, physics.ScreenHeight)
+	v.lookAt(physics.Vector{X: 1000, Y: -500})
+
+	var f frame
+	f.buildMinimap(w, []*viewport{v}, 0, physics.ScreenWidth, physics.ScreenHeight)
+	if len(f.rects) != 1 || len(f.circles) != 2 || len(f.lines) != 4 {
+		t.Fatalf("minimap has %d rects, %d dots and %d lines, want 1, 2 and 4", len(f.rects), len(f.circles), len(f.lines))
+	}
+	back := f.rects[0]
+	if back.width > minimapWidth+epsilon || back.height > minimapHeight+epsilon {
+		t.Errorf("minimap is %.1f by %.1f, want it within %d by %d", back.width, back.height, minimapWidth, minimapHeight)
+	}
+	if right, bottom := back.x+back.width, back.y+back.height; right > physics.ScreenWidth-minimapMargin+epsilon || bottom > physics.ScreenHeight-minimapMargin+epsilon {
+		t.Errorf("minimap reaches %.1f, %.1f, want it inside the margin", right, bottom)
+	}
+	inside := func(x, y float64) bool {
+		return x >= back.x-epsilon && x <= back.x+back.width+epsilon && y >= back.y-epsilon && y <= back.y+back.height+epsilon
+	}
+	for _, c := range f.circles {
+		if !inside(c.x, c.y) {
+			t.Errorf("body dot at %.1f, %.1f is off the minimap", c.x, c.y)
+		}
+	}
+	for _, l := range f.lines {
+		if !inside(l.x1, l.y1) || !inside(l.x2, l.y2) || l.color != defaultPalette.focus {
+			t.Errorf("view outline %+v is off the minimap or not picked out", l)
+		}
+	}
+}
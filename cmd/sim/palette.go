@@ -0,0 +1,240 @@
+package main
+
+import "image/color"
+
+// palette is a set of colours to draw everything in: bodies and the world
+// around them, the overlays and the HUD text. series colours things that
+// come in sets, such as trails and portal pairs, in order. Translucent
+// colours are alpha-premultiplied.
+type palette struct {
+	name       string
+	background color.RGBA
+	// cloth is the background on a cloth-covered table
+	cloth color.RGBA
+	body  color.RGBA
+	// rod is for constraints, so they read as part of the rig rather than
+	// as bodies
+	rod    color.RGBA
+	static color.RGBA
+	cue    color.RGBA
+	text   color.RGBA
+	series []color.RGBA
+
+	histogram color.RGBA
+	expected  color.RGBA
+	measure   color.RGBA
+	zone      color.RGBA
+	drain     color.RGBA
+	north     color.RGBA
+	south     color.RGBA
+	water     color.RGBA
+	band      color.RGBA
+	focus     color.RGBA
+	minimap   color.RGBA
+	hole      color.RGBA
+	ghost     color.RGBA
+	blocked   color.RGBA
+	bounds    color.RGBA
+	// field draws the force fields a ball falls by; the magnetic field is
+	// drawn in north
+	field color.RGBA
+	// flipped, weak and strong tint gravity zones that turn gravity
+	// upside down, weaken it and strengthen it
+	flipped color.RGBA
+	weak    color.RGBA
+	strong  color.RGBA
+}
+
+var defaultPalette = &palette{
+	name:       "default",
+	background: color.RGBA{0x00, 0x00, 0x00, 0xff},
+	cloth:      color.RGBA{0x0b, 0x6b, 0x3a, 0xff},
+	body:       color.RGBA{0xff, 0xff, 0xff, 0xff},
+	rod:        color.RGBA{0x90, 0x90, 0x90, 0xff},
+	static:     color.RGBA{0xb0, 0xb0, 0xb0, 0xff},
+	cue:        color.RGBA{0xd9, 0xb3, 0x82, 0xff},
+	text:       color.RGBA{0xff, 0xff, 0xff, 0xff},
+	series: []color.RGBA{
+		{0xff, 0x60, 0x60, 0xff},
+		{0x60, 0xa0, 0xff, 0xff},
+		{0x60, 0xff, 0x90, 0xff},
+		{0xff, 0xd0, 0x40, 0xff},
+	},
+	histogram: color.RGBA{0x2a, 0x3f, 0x8f, 0xff},
+	expected:  color.RGBA{0xff, 0xd0, 0x40, 0xff},
+	measure:   color.RGBA{0x40, 0xe0, 0xff, 0xff},
+	zone:      color.RGBA{0x60, 0xc0, 0x60, 0xff},
+	drain:     color.RGBA{0xc0, 0x60, 0x60, 0xff},
+	north:     color.RGBA{0xe0, 0x30, 0x30, 0xff},
+	south:     color.RGBA{0x30, 0x60, 0xe0, 0xff},
+	water:     color.RGBA{0x10, 0x38, 0x70, 0xa0},
+	band:      color.RGBA{0xe0, 0x90, 0x30, 0xff},
+	focus:     color.RGBA{0xff, 0xff, 0x60, 0xff},
+	minimap:   color.RGBA{0x18, 0x18, 0x18, 0xd0},
+	hole:      color.RGBA{0x06, 0x20, 0x10, 0xff},
+	ghost:     color.RGBA{0x60, 0x60, 0x60, 0x60},
+	blocked:   color.RGBA{0x80, 0x00, 0x00, 0x80},
+	bounds:    color.RGBA{0xff, 0x80, 0xff, 0xff},
+	field:     color.RGBA{0x40, 0xc0, 0xff, 0xff},
+	flipped:   color.RGBA{0x30, 0x10, 0x38, 0x40},
+	weak:      color.RGBA{0x10, 0x28, 0x38, 0x40},
+	strong:    color.RGBA{0x38, 0x18, 0x10, 0x40},
+}
+
+// colorblindPalette keeps to the Okabe-Ito colours, which stay apart for
+// every common kind of colour blindness, and never tells two things apart
+// by red against green alone.
+var colorblindPalette = &palette{
+	name:       "colorblind",
+	background: color.RGBA{0x00, 0x00, 0x00, 0xff},
+	cloth:      color.RGBA{0x00, 0x4f, 0x3a, 0xff},
+	body:       color.RGBA{0xff, 0xff, 0xff, 0xff},
+	rod:        color.RGBA{0x90, 0x90, 0x90, 0xff},
+	static:     color.RGBA{0xb0, 0xb0, 0xb0, 0xff},
+	cue:        color.RGBA{0xe6, 0x9f, 0x00, 0xff},
+	text:       color.RGBA{0xff, 0xff, 0xff, 0xff},
+	series: []color.RGBA{
+		{0xe6, 0x9f, 0x00, 0xff},
+		{0x56, 0xb4, 0xe9, 0xff},
+		{0x00, 0x9e, 0x73, 0xff},
+		{0xf0, 0xe4, 0x42, 0xff},
+		{0x00, 0x72, 0xb2, 0xff},
+		{0xd5, 0x5e, 0x00, 0xff},
+		{0xcc, 0x79, 0xa7, 0xff},
+	},
+	histogram: color.RGBA{0x00, 0x72, 0xb2, 0xff},
+	expected:  color.RGBA{0xf0, 0xe4, 0x42, 0xff},
+	measure:   color.RGBA{0x56, 0xb4, 0xe9, 0xff},
+	zone:      color.RGBA{0x00, 0x9e, 0x73, 0xff},
+	drain:     color.RGBA{0xd5, 0x5e, 0x00, 0xff},
+	north:     color.RGBA{0xd5, 0x5e, 0x00, 0xff},
+	south:     color.RGBA{0x00, 0x72, 0xb2, 0xff},
+	water:     color.RGBA{0x00, 0x39, 0x59, 0xa0},
+	band:      color.RGBA{0xe6, 0x9f, 0x00, 0xff},
+	focus:     color.RGBA{0xf0, 0xe4, 0x42, 0xff},
+	minimap:   color.RGBA{0x18, 0x18, 0x18, 0xd0},
+	hole:      color.RGBA{0x00, 0x1a, 0x12, 0xff},
+	ghost:     color.RGBA{0x60, 0x60, 0x60, 0x60},
+	blocked:   color.RGBA{0x6a, 0x2f, 0x00, 0x80},
+	bounds:    color.RGBA{0xcc, 0x79, 0xa7, 0xff},
+	field:     color.RGBA{0x56, 0xb4, 0xe9, 0xff},
+	flipped:   color.RGBA{0x33, 0x1e, 0x2a, 0x40},
+	weak:      color.RGBA{0x15, 0x2d, 0x3a, 0x40},
+	strong:    color.RGBA{0x39, 0x28, 0x00, 0x40},
+}
+
+// darkPalette is high contrast on black: white bodies and walls, and
+// overlays in fully saturated colours.
+var darkPalette = &palette{
+	name:       "dark",
+	background: color.RGBA{0x00, 0x00, 0x00, 0xff},
+	cloth:      color.RGBA{0x00, 0x30, 0x18, 0xff},
+	body:       color.RGBA{0xff, 0xff, 0xff, 0xff},
+	rod:        color.RGBA{0xc0, 0xc0, 0xc0, 0xff},
+	static:     color.RGBA{0xff, 0xff, 0xff, 0xff},
+	cue:        color.RGBA{0xff, 0xff, 0x00, 0xff},
+	text:       color.RGBA{0xff, 0xff, 0xff, 0xff},
+	series: []color.RGBA{
+		{0xff, 0xff, 0x00, 0xff},
+		{0x00, 0xff, 0xff, 0xff},
+		{0xff, 0x00, 0xff, 0xff},
+		{0x00, 0xff, 0x00, 0xff},
+	},
+	histogram: color.RGBA{0x00, 0x40, 0xff, 0xff},
+	expected:  color.RGBA{0xff, 0xff, 0x00, 0xff},
+	measure:   color.RGBA{0x00, 0xff, 0xff, 0xff},
+	zone:      color.RGBA{0x00, 0xff, 0x00, 0xff},
+	drain:     color.RGBA{0xff, 0x00, 0x00, 0xff},
+	north:     color.RGBA{0xff, 0x00, 0x00, 0xff},
+	south:     color.RGBA{0x00, 0xa0, 0xff, 0xff},
+	water:     color.RGBA{0x00, 0x40, 0x90, 0xa0},
+	band:      color.RGBA{0xff, 0x80, 0x00, 0xff},
+	focus:     color.RGBA{0xff, 0xff, 0x00, 0xff},
+	minimap:   color.RGBA{0x20, 0x20, 0x20, 0xe0},
+	hole:      color.RGBA{0x00, 0x00, 0x00, 0xff},
+	ghost:     color.RGBA{0x80, 0x80, 0x80, 0x80},
+	blocked:   color.RGBA{0xc0, 0x00, 0x00, 0xc0},
+	bounds:    color.RGBA{0xff, 0x00, 0xff, 0xff},
+	field:     color.RGBA{0x00, 0xff, 0xff, 0xff},
+	flipped:   color.RGBA{0x40, 0x00, 0x40, 0x40},
+	weak:      color.RGBA{0x00, 0x30, 0x40, 0x40},
+	strong:    color.RGBA{0x40, 0x20, 0x00, 0x40},
+}
+
+// lightPalette is high contrast on white: black bodies and text, and
+// overlays in dark colours.
+var lightPalette = &palette{
+	name:       "light",
+	background: color.RGBA{0xff, 0xff, 0xff, 0xff},
+	cloth:      color.RGBA{0xd0, 0xf0, 0xd0, 0xff},
+	body:       color.RGBA{0x00, 0x00, 0x00, 0xff},
+	rod:        color.RGBA{0x60, 0x60, 0x60, 0xff},
+	static:     color.RGBA{0x40, 0x40, 0x40, 0xff},
+	cue:        color.RGBA{0x8a, 0x4b, 0x00, 0xff},
+	text:       color.RGBA{0x00, 0x00, 0x00, 0xff},
+	series: []color.RGBA{
+		{0xd0, 0x00, 0x00, 0xff},
+		{0x00, 0x40, 0xd0, 0xff},
+		{0x00, 0x80, 0x00, 0xff},
+		{0xa0, 0x60, 0x00, 0xff},
+	},
+	histogram: color.RGBA{0x90, 0xb0, 0xe0, 0xff},
+	expected:  color.RGBA{0xc0, 0x60, 0x00, 0xff},
+	measure:   color.RGBA{0x00, 0x60, 0xa0, 0xff},
+	zone:      color.RGBA{0x00, 0x80, 0x00, 0xff},
+	drain:     color.RGBA{0xc0, 0x00, 0x00, 0xff},
+	north:     color.RGBA{0xc0, 0x00, 0x00, 0xff},
+	south:     color.RGBA{0x00, 0x40, 0xc0, 0xff},
+	water:     color.RGBA{0x20, 0x40, 0x60, 0x80},
+	band:      color.RGBA{0xc0, 0x60, 0x00, 0xff},
+	focus:     color.RGBA{0xd0, 0x00, 0xd0, 0xff},
+	minimap:   color.RGBA{0xd0, 0xd0, 0xd0, 0xe0},
+	hole:      color.RGBA{0x20, 0x20, 0x20, 0xff},
+	ghost:     color.RGBA{0x40, 0x40, 0x40, 0x60},
+	blocked:   color.RGBA{0x80, 0x00, 0x00, 0x80},
+	bounds:    color.RGBA{0xa0, 0x00, 0xa0, 0xff},
+	field:     color.RGBA{0x00, 0x60, 0xa0, 0xff},
+	flipped:   color.RGBA{0x20, 0x00, 0x20, 0x30},
+	weak:      color.RGBA{0x00, 0x10, 0x20, 0x30},
+	strong:    color.RGBA{0x20, 0x10, 0x00, 0x30},
+}
+
+// palettes are the palettes to pick from, the default first.
+var palettes = []*palette{defaultPalette, colorblindPalette, darkPalette, lightPalette}
+
+// paletteNamed returns the palette with the given name, or false if there
+// is none.
+func paletteNamed(name string) (*palette, bool) {
+	for _, p := range palettes {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// paletteNames lists the palettes in the order they are cycled through.
+func paletteNames() []string {
+	names := make([]string, len(palettes))
+	for k, p := range palettes {
+		names[k] = p.name
+	}
+	return names
+}
+
+// seriesColor returns the k'th colour of the palette's series, going round
+// again after the last.
+func (p *palette) seriesColor(k int) color.RGBA {
+	return p.series[k%len(p.series)]
+}
+
+// zoneTint returns the tint of a gravity zone with the given scale.
+func (p *palette) zoneTint(scale float64) color.RGBA {
+	switch {
+	case scale < 0:
+		return p.flipped
+	case scale > 1:
+		return p.strong
+	}
+	return p.weak
+}
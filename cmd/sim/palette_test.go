@@ -0,0 +1,60 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+// TestPalettesComplete checks every palette sets every colour, keeps its
+// translucent colours premultiplied, and can be found by name.
+func TestPalettesComplete(t *testing.T) {
+	for _, p := range palettes {
+		if got, ok := paletteNamed(p.name); !ok || got != p {
+			t.Errorf("palette %q not found by its name", p.name)
+		}
+		if len(p.series) == 0 {
+			t.Errorf("%s: no series colours", p.name)
+		}
+		named := map[string]color.RGBA{
+			"background": p.background, "cloth": p.cloth, "body": p.body, "rod": p.rod,
+			"static": p.static, "cue": p.cue, "text": p.text, "histogram": p.histogram,
+			"expected": p.expected, "measure": p.measure, "zone": p.zone, "drain": p.drain,
+			"north": p.north, "south": p.south, "water": p.water, "band": p.band,
+			"focus": p.focus, "minimap": p.minimap, "hole": p.hole, "ghost": p.ghost,
+			"blocked": p.blocked, "bounds": p.bounds, "field": p.field, "flipped": p.flipped, "weak": p.weak, "strong": p.strong,
+		}
+		for name, c := range named {
+			if c.A == 0 {
+				t.Errorf("%s: %s is not set", p.name, name)
+			}
+			if c.R > c.A || c.G > c.A || c.B > c.A {
+				t.Errorf("%s: %s = %v is not premultiplied", p.name, name, c)
+			}
+		}
+	}
+}
+
+// TestHighContrastPalettes checks bodies, walls and text stand out from the
+// background in the high-contrast palettes by at least the 7:1 contrast
+// ratio WCAG asks of text.
+func TestHighContrastPalettes(t *testing.T) {
+	luminance := func(c color.RGBA) float64 {
+		channel := func(v uint8) float64 {
+			s := float64(v) / 255
+			if s <= 0.03928 {
+				return s / 12.92
+			}
+			return math.Pow((s+0.055)/1.055, 2.4)
+		}
+		return 0.2126*channel(c.R) + 0.7152*channel(c.G) + 0.0722*channel(c.B)
+	}
+	for _, p := range []*palette{darkPalette, lightPalette} {
+		for name, c := range map[string]color.RGBA{"body": p.body, "static": p.static, "text": p.text} {
+			a, b := luminance(c), luminance(p.background)
+			if ratio := (max(a, b) + 0.05) / (min(a, b) + 0.05); ratio < 7 {
+				t.Errorf("%s: %s contrasts %.1f:1 with the background, want 7:1", p.name, name, ratio)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import "physicsSim/physics"
+
+// portalCooldown is how many ticks a body that has just come through a
+// portal is left alone, so it can get clear of the far end before that
+// end could send it straight back.
+const portalCooldown = 30
+
+// portal links two regions: a ball whose centre comes into either is moved
+// to the same place in the other, keeping its speed. Going from a to b
+// turns the ball and its velocity through turn radians, and going back
+// turns them back.
+type portal struct {
+	a    zone
+	b    zone
+	turn float64
+
+	tick int
+	// quietUntil is the tick each body may next go through, by its number
+	// when there were bodies of them
+	quietUntil []int
+	bodies     int
+}
+
+func newPortal(a, b zone, turn float64) *portal {
+	return &portal{a: a, b: b, turn: turn}
+}
+
+// update sends every ball that is in either end and not cooling down
+// through to the other. Removing a body renumbers those after it, so once
+// there are fewer than last time the cooldowns can't be told apart and
+// are all forgotten; new ones only add to the end. Call it after every
+// step; it must not be called while a step runs.
+func (p *portal) update(w *physics.World) {
+	p.tick++
+	objects := w.Snapshot()
+	if len(objects) < p.bodies {
+		p.quietUntil = p.quietUntil[:0]
+	}
+	p.bodies = len(objects)
+	for i, currBall := range objects {
+		for len(p.quietUntil) <= i {
+			p.quietUntil = append(p.quietUntil, 0)
+		}
+		if p.tick < p.quietUntil[i] {
+			continue
+		}
+		switch {
+		case p.a.contains(currBall.Position):
+			p.send(w, i, currBall, &p.a, &p.b, p.turn)
+		case p.b.contains(currBall.Position):
+			p.send(w, i, currBall, &p.b, &p.a, -p.turn)
+		}
+	}
+}
+
+// send moves ball i from one end of the portal to the other.
+func (p *portal) send(w *physics.World, i int, currBall physics.Body, from, to *zone, turn float64) {
+	offset := physics.RotateBy(physics.Subtract(currBall.Position, from.centre()), turn)
+	w.Teleport(i, physics.Add(to.centre(), offset), physics.RotateBy(currBall.Velocity, turn))
+	p.quietUntil[i] = p.tick + portalCooldown
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestPortalTurnsAndCoolsDown sends a falling ball through a portal that
+// turns it a quarter turn, and checks it comes out moving right at the
+// same speed and isn't sent straight back.
+func TestPortalTurnsAndCoolsDown(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{{Position: physics.Vector{X: 300, Y: 395}, Velocity: physics.Vector{Y: 6}}}, physics.Vector{})
+	defer w.Close()
+	floor := zone{min: physics.Vector{X: 260, Y: 400}, max: physics.Vector{X: 340, Y: 440}}
+	wall := zone{min: physics.Vector{X: 0, Y: 160}, max: physics.Vector{X: 40, Y: 240}}
+	p := newPortal(floor, wall, -math.Pi/2)
+
+	w.Step()
+	p.update(w)
+	b := w.Snapshot()[0]
+	if !wall.contains(b.Position) {
+		t.Fatalf("ball at %v, want it through to the wall end", b.Position)
+	}
+	if math.Abs(b.Velocity.X-6) > epsilon || math.Abs(b.Velocity.Y) > epsilon {
+		t.Fatalf("ball leaves at %v, want (6, 0)", b.Velocity)
+	}
+
+	// Still inside the far end on the next tick, but cooling down
+	w.Step()
+	p.update(w)
+	if b := w.Snapshot()[0]; !wall.contains(b.Position) {
+		t.Errorf("ball at %v, want it left at the wall end while it cools down", b.Position)
+	}
+}
+
+// TestPortalForgetsRenumberedCooldowns sends one ball through, removes a
+// ball before it so it takes that one's number, and checks the ball now
+// resting numbered where the cooling one was goes through at once.
+func TestPortalForgetsRenumberedCooldowns(t *testing.T) {
+	floor := zone{min: physics.Vector{X: 260, Y: 400}, max: physics.Vector{X: 340, Y: 440}}
+	wall := zone{min: physics.Vector{X: 0, Y: 160}, max: physics.Vector{X: 40, Y: 240}}
+	w := physics.NewWorld([]physics.Body{
+		{Position: physics.Vector{X: 500, Y: 100}},
+		{Position: physics.Vector{X: 300, Y: 420}},
+		{Position: physics.Vector{X: 600, Y: 100}},
+	}, physics.Vector{}, physics.WithoutContacts())
+	defer w.Close()
+	p := newPortal(floor, wall, 0)
+
+	w.Step()
+	p.update(w)
+	if b := w.Snapshot()[1]; !wall.contains(b.Position) {
+		t.Fatalf("ball at %v, want it through to the wall end", b.Position)
+	}
+
+	// The third ball drops into the floor end as the first is removed, so
+	// it is body 1, numbered as the ball cooling down was
+	w.Remove(0)
+	w.Teleport(1, physics.Vector{X: 300, Y: 420}, physics.Vector{})
+	w.Step()
+	p.update(w)
+	if b := w.Snapshot()[1]; !wall.contains(b.Position) {
+		t.Errorf("ball newly on the floor end at %v, want it sent through rather than held by another's cooldown", b.Position)
+	}
+}
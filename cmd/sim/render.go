@@ -0,0 +1,724 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"slices"
+	"strconv"
+
+	"physicsSim/physics"
+)
+
+// circleCommand is one filled circle to draw, in screen coordinates.
+type circleCommand struct {
+	x      float64
+	y      float64
+	radius float64
+	color  color.RGBA
+}
+
+// lineCommand is one line segment to draw, in screen coordinates.
+type lineCommand struct {
+	x1    float64
+	y1    float64
+	x2    float64
+	y2    float64
+	color color.RGBA
+}
+
+// rectCommand is one filled rectangle to draw, in screen coordinates, with
+// its top-left corner at x, y.
+type rectCommand struct {
+	x      float64
+	y      float64
+	width  float64
+	height float64
+	color  color.RGBA
+}
+
+// textCommand is one line of debug text to draw, in screen coordinates,
+// with its top-left corner at x, y.
+type textCommand struct {
+	x    float64
+	y    float64
+	text string
+}
+
+// labelMode is what, if anything, is written over each body.
+type labelMode int
+
+const (
+	noLabels labelMode = iota
+	// nameLabels shows the name of every body that has one
+	nameLabels
+	// indexLabels shows every body's index in the world
+	indexLabels
+	labelModes
+)
+
+// glyphWidth and glyphHeight are the size of a character in Ebiten's debug
+// font, used to centre text.
+const (
+	glyphWidth  = 6
+	glyphHeight = 16
+)
+
+// poleMarker is the radius of the dot drawn towards a magnetised ball's
+// north pole, and magnetDot the radius of the dots a bar magnet is drawn
+// with.
+const (
+	poleMarker = 5
+	magnetDot  = 4
+)
+
+// frozenMarker is the radius of the dot drawn in the middle of a frozen
+// ball, in the colour of static geometry.
+const frozenMarker = physics.BallRadius / 3
+
+// cueLength is how long the cue stick is drawn.
+const cueLength = 240
+
+// flagHeight and flagWidth size the flag standing in a golf hole.
+const (
+	flagHeight = 60
+	flagWidth  = 20
+)
+
+// frame collects everything needed to draw one view of the world, kept
+// free of Ebiten so it can also be rendered headlessly. colors, when set,
+// overrides the colour of the first bodies, and theme is the palette to
+// draw everything else in, the default if unset.
+type frame struct {
+	theme      *palette
+	background color.RGBA
+	rects      []rectCommand
+	circles    []circleCommand
+	lines      []lineCommand
+	texts      []textCommand
+	visible    []int
+	images     []physics.Vector
+	curve      []physics.Vector
+	threads    [][2]physics.Vector
+	// field holds the gravity field's samples, each a point and the
+	// gravity there
+	field  [][2]physics.Vector
+	colors []color.RGBA
+	// clock, if set, blends bodies between steps as they are drawn
+	clock *clock
+	// antialias shades the pixels on the edge of every shape by how much
+	// of each it covers, so a body moving less than a pixel a frame glides
+	// rather than jumping a pixel at a time
+	antialias bool
+}
+
+// build fills the frame with the bodies the camera can see on a screen of
+// the given size, reusing the frame's slices.
+func (f *frame) build(w *physics.World, cam *camera, width, height float64) {
+	objects := w.Snapshot()
+	if f.clock != nil {
+		objects = f.clock.blend(objects)
+	}
+
+	if f.theme == nil {
+		f.theme = defaultPalette
+	}
+	f.background = f.theme.background
+	if w.Cloth.Enabled() {
+		f.background = f.theme.cloth
+	}
+
+	// Only draw bodies the broadphase says are on screen
+	viewMin, viewMax := cam.view(width, height)
+	f.visible = w.QueryRect(viewMin, viewMax, f.visible[:0])
+
+	f.lines = f.lines[:0]
+	f.texts = f.texts[:0]
+	for i := range w.Constraints {
+		c := &w.Constraints[i]
+		if c.A >= len(objects) || c.B >= len(objects) {
+			continue
+		}
+		end, _ := c.End(objects)
+		from := cam.worldToScreen(end)
+		to := cam.worldToScreen(objects[c.A].Position)
+		if c.Stiffness != 0 {
+			f.addSpring(from, to)
+			continue
+		}
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.rod})
+	}
+	for _, wd := range w.Welds {
+		if wd.A >= len(objects) || wd.B >= len(objects) {
+			continue
+		}
+		from := cam.worldToScreen(objects[wd.A].Position)
+		to := cam.worldToScreen(objects[wd.B].Position)
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.rod})
+	}
+	for _, j := range w.Wheels {
+		if j.A >= len(objects) || j.B >= len(objects) {
+			continue
+		}
+		from := cam.worldToScreen(objects[j.A].Position)
+		to := cam.worldToScreen(objects[j.B].Position)
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.rod})
+	}
+
+	outline := w.Arena.Outline()
+	for k := 1; k < len(outline); k++ {
+		from := cam.worldToScreen(outline[k-1])
+		to := cam.worldToScreen(outline[k])
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.static})
+	}
+	for _, s := range w.StaticSegments {
+		from := cam.worldToScreen(s.A)
+		to := cam.worldToScreen(s.B)
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.static})
+	}
+	for _, c := range w.StaticChains {
+		for k := 1; k < len(c.Points); k++ {
+			from := cam.worldToScreen(c.Points[k-1])
+			to := cam.worldToScreen(c.Points[k])
+			f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.static})
+		}
+		if c.Loop && len(c.Points) > 2 {
+			from := cam.worldToScreen(c.Points[len(c.Points)-1])
+			to := cam.worldToScreen(c.Points[0])
+			f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.static})
+		}
+	}
+	for _, polygon := range w.StaticPolygons {
+		for k := range polygon.Points {
+			from := cam.worldToScreen(polygon.Points[k])
+			to := cam.worldToScreen(polygon.Points[(k+1)%len(polygon.Points)])
+			f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.static})
+		}
+	}
+	for _, h := range w.Heightfields {
+		for k := 1; k < len(h.Heights); k++ {
+			from := cam.worldToScreen(physics.Vector{X: h.X + float64(k-1)*h.Spacing, Y: h.Heights[k-1]})
+			to := cam.worldToScreen(physics.Vector{X: h.X + float64(k)*h.Spacing, Y: h.Heights[k]})
+			f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.static})
+		}
+	}
+	for _, b := range w.Bands {
+		f.addBand(b, cam)
+	}
+	for _, r := range w.Ropes {
+		f.addRope(r, cam)
+	}
+	for _, c := range w.Cloths {
+		f.addCloth(c, cam)
+	}
+
+	f.rects = f.rects[:0]
+	f.circles = f.circles[:0]
+	if p := w.Water; p != nil {
+		f.addWater(p, w.Arena.Extent().Max.Y, cam)
+	}
+	for _, z := range w.GravityZones {
+		f.addSolidBox(z.Box, f.theme.zoneTint(z.Scale), cam)
+	}
+	for _, b := range w.StaticBoxes {
+		f.addSolidBox(b, f.theme.static, cam)
+	}
+	for _, c := range w.StaticCircles {
+		position := cam.worldToScreen(c.Position)
+		f.circles = append(f.circles, circleCommand{x: position.X, y: position.Y, radius: c.Radius, color: f.theme.static})
+	}
+	for _, m := range w.Magnets {
+		f.addMagnet(m, cam)
+	}
+	for _, i := range f.visible {
+		if i >= len(objects) {
+			continue
+		}
+		position := cam.worldToScreen(objects[i].Position)
+		radius := objects[i].Size()
+		c := fade(f.colorOf(objects, i), objects[i].Expires, w.Steps)
+		f.circles = append(f.circles, circleCommand{
+			x:      position.X,
+			y:      position.Y,
+			radius: radius,
+			color:  c,
+		})
+		if objects[i].Frozen {
+			f.circles = append(f.circles, circleCommand{x: position.X, y: position.Y, radius: frozenMarker, color: f.theme.static})
+		}
+		if moment := objects[i].Moment; moment != (physics.Vector{}) {
+			north := physics.Add(position, physics.ScalarMult(physics.UnitVector(moment), radius-poleMarker))
+			f.circles = append(f.circles, circleCommand{x: north.X, y: north.Y, radius: poleMarker, color: f.theme.north})
+		}
+		// A gripping or turned ball shows a dot on its rim, so it can be
+		// seen rolling and spinning
+		if objects[i].Grip != 0 || objects[i].Angle != 0 {
+			rim := physics.Add(position, physics.RotateBy(physics.Vector{X: radius - poleMarker}, objects[i].Angle))
+			f.circles = append(f.circles, circleCommand{x: rim.X, y: rim.Y, radius: poleMarker, color: f.theme.static})
+		}
+
+		// On a torus a ball over a seam shows on both sides of it
+		if w.Arena.Shape != physics.TorusArena {
+			continue
+		}
+		f.images = w.Arena.SeamImages(objects[i].Position, radius, f.images[:0])
+		for _, seam := range f.images {
+			position := cam.worldToScreen(seam)
+			f.circles = append(f.circles, circleCommand{x: position.X, y: position.Y, radius: radius, color: c})
+		}
+	}
+}
+
+// colorOf returns the colour body i is drawn in: its own if it has one,
+// else the one the frame gives it, else the body colour.
+func (f *frame) colorOf(objects []physics.Body, i int) color.RGBA {
+	switch {
+	case objects[i].Color.A != 0:
+		return objects[i].Color
+	case i < len(f.colors):
+		return f.colors[i]
+	}
+	return f.theme.body
+}
+
+// addLabels writes a label centred just above every visible body, as
+// chosen by mode.
+func (f *frame) addLabels(objects []physics.Body, mode labelMode, cam *camera) {
+	if mode == noLabels {
+		return
+	}
+	for _, i := range f.visible {
+		if i >= len(objects) {
+			continue
+		}
+		label := objects[i].Name
+		if mode == indexLabels {
+			label = strconv.Itoa(i)
+		}
+		if label == "" {
+			continue
+		}
+		position := cam.worldToScreen(objects[i].Position)
+		f.texts = append(f.texts, textCommand{
+			x:    position.X - float64(glyphWidth*len(label))/2,
+			y:    position.Y - objects[i].Size() - glyphHeight,
+			text: label,
+		})
+	}
+}
+
+// addWater appends a column of water from each point of the surface down
+// to the floor, the bottom of the arena.
+func (f *frame) addWater(p *physics.Water, bottom float64, cam *camera) {
+	floor := cam.worldToScreen(physics.Vector{Y: bottom}).Y
+	for k, displacement := range p.Displacements {
+		x := p.Left + (float64(k)-0.5)*physics.WaterSpacing
+		top := cam.worldToScreen(physics.Vector{X: max(x, p.Left), Y: p.Level + displacement})
+		right := min(x+physics.WaterSpacing, p.Right)
+		width := right - max(x, p.Left)
+		if width <= 0 {
+			continue
+		}
+		f.rects = append(f.rects, rectCommand{x: top.X, y: top.Y, width: width, height: floor - top.Y, color: f.theme.water})
+	}
+}
+
+// addBand draws a rubber band as a smooth closed curve.
+func (f *frame) addBand(b *physics.Band, cam *camera) {
+	f.curve = b.Curve(f.curve[:0])
+	for k := 1; k < len(f.curve); k++ {
+		from := cam.worldToScreen(f.curve[k-1])
+		to := cam.worldToScreen(f.curve[k])
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.band})
+	}
+}
+
+// addRope draws a rope as a line through its points.
+func (f *frame) addRope(r *physics.Rope, cam *camera) {
+	f.curve = r.Points(f.curve[:0])
+	for k := 1; k < len(f.curve); k++ {
+		from := cam.worldToScreen(f.curve[k-1])
+		to := cam.worldToScreen(f.curve[k])
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.rod})
+	}
+}
+
+// addCloth draws a cloth as a mesh of the threads still whole.
+func (f *frame) addCloth(c *physics.Cloth, cam *camera) {
+	f.threads = c.Threads(f.threads[:0])
+	for _, thread := range f.threads {
+		from := cam.worldToScreen(thread[0])
+		to := cam.worldToScreen(thread[1])
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.band})
+	}
+}
+
+// addBreakout colours the bricks by row and the paddle, over the plain
+// boxes the world draws them as.
+func (f *frame) addBreakout(b *breakout, cam *camera) {
+	if b == nil {
+		return
+	}
+	for _, br := range b.bricks {
+		f.addSolidBox(br.box, f.theme.seriesColor(br.row), cam)
+	}
+	f.addSolidBox(paddleAt(b.paddle), f.theme.cue, cam)
+}
+
+// addGolf appends the hole on the current course, the flag in it and the
+// cue while a putt is being aimed.
+func (f *frame) addGolf(g *golf, objects []physics.Body, cam *camera) {
+	if g == nil {
+		return
+	}
+	hole := cam.worldToScreen(g.course().hole())
+	top := physics.Add(hole, physics.Vector{Y: -flagHeight})
+	tip := physics.Add(top, physics.Vector{X: flagWidth, Y: flagWidth / 2})
+	f.lines = append(f.lines,
+		lineCommand{x1: hole.X, y1: hole.Y, x2: top.X, y2: top.Y, color: f.theme.body},
+		lineCommand{x1: top.X, y1: top.Y, x2: tip.X, y2: tip.Y, color: f.theme.north},
+		lineCommand{x1: tip.X, y1: tip.Y, x2: top.X, y2: top.Y + flagWidth, color: f.theme.north},
+	)
+	// The cup goes under everything else, so the ball rolls over it
+	f.circles = slices.Insert(f.circles, 0, circleCommand{x: hole.X, y: hole.Y, radius: holeRadius, color: f.theme.hole})
+	f.addCue(g.cue, objects, cam)
+}
+
+// addSolidBox appends a filled box, or the outline of a turned one, which
+// a rectangle can't fill.
+func (f *frame) addSolidBox(box physics.StaticBox, c color.RGBA, cam *camera) {
+	if box.Angle != 0 {
+		corners := box.Corners()
+		for k := range corners {
+			from := cam.worldToScreen(corners[k])
+			to := cam.worldToScreen(corners[(k+1)%len(corners)])
+			f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: c})
+		}
+		return
+	}
+	from := cam.worldToScreen(box.Min)
+	f.rects = append(f.rects, rectCommand{x: from.X, y: from.Y, width: box.Max.X - box.Min.X, height: box.Max.Y - box.Min.Y, color: c})
+}
+
+// addMagnet appends a bar magnet as a row of dots along its moment, red
+// over its north half and blue over its south.
+func (f *frame) addMagnet(m physics.StaticMagnet, cam *camera) {
+	direction := physics.UnitVector(m.Moment)
+	for along := -m.Length / 2; along <= m.Length/2; along += magnetDot {
+		at := cam.worldToScreen(physics.Add(m.Position, physics.ScalarMult(direction, along)))
+		c := f.theme.south
+		if along > 0 {
+			c = f.theme.north
+		}
+		f.circles = append(f.circles, circleCommand{x: at.X, y: at.Y, radius: magnetDot, color: c})
+	}
+}
+
+// addZones outlines the start and stop zones of every stopwatch.
+func (f *frame) addZones(stopwatches []*stopwatch, cam *camera) {
+	for _, s := range stopwatches {
+		f.addBox(&s.start, f.theme.zone, cam)
+		f.addBox(&s.stop, f.theme.zone, cam)
+	}
+}
+
+// addDrains outlines every drain.
+func (f *frame) addDrains(drains []*drain, cam *camera) {
+	for _, d := range drains {
+		f.addBox(&d.region, f.theme.drain, cam)
+	}
+}
+
+// addPortals outlines both ends of every portal, each pair in its own
+// colour.
+func (f *frame) addPortals(portals []*portal, cam *camera) {
+	for k, p := range portals {
+		c := f.theme.seriesColor(k)
+		f.addBox(&p.a, c, cam)
+		f.addBox(&p.b, c, cam)
+	}
+}
+
+// addBox outlines a zone.
+func (f *frame) addBox(z *zone, c color.RGBA, cam *camera) {
+	f.addOutline(z.min, z.max, c, cam)
+}
+
+// addBoundingBoxes outlines the bounding box of every body on screen.
+func (f *frame) addBoundingBoxes(boxes []physics.AABB, cam *camera) {
+	for _, i := range f.visible {
+		if i < len(boxes) {
+			f.addOutline(boxes[i].Min, boxes[i].Max, f.theme.bounds, cam)
+		}
+	}
+}
+
+// addOutline appends the four sides of the rectangle from min to max.
+func (f *frame) addOutline(min, max physics.Vector, c color.RGBA, cam *camera) {
+	lo := cam.worldToScreen(min)
+	hi := cam.worldToScreen(max)
+	corners := []physics.Vector{lo, {X: hi.X, Y: lo.Y}, hi, {X: lo.X, Y: hi.Y}}
+	for i, from := range corners {
+		to := corners[(i+1)%len(corners)]
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: c})
+	}
+}
+
+// measureMarker is the radius of the dot drawn on each measured point.
+const measureMarker = 3
+
+// addMeasurement draws the points placed with a measuring tool joined up
+// in order, with the reading beside the last one once it is complete.
+func (f *frame) addMeasurement(m *measurement, cam *camera) {
+	for i, p := range m.points {
+		at := cam.worldToScreen(p)
+		f.circles = append(f.circles, circleCommand{x: at.X, y: at.Y, radius: measureMarker, color: f.theme.measure})
+		if i > 0 {
+			from := cam.worldToScreen(m.points[i-1])
+			f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: at.X, y2: at.Y, color: f.theme.measure})
+		}
+	}
+	if reading := m.reading(); reading != "" {
+		at := cam.worldToScreen(m.points[len(m.points)-1])
+		f.texts = append(f.texts, textCommand{x: at.X + 2*measureMarker, y: at.Y, text: reading})
+	}
+}
+
+// addTrails appends every recorded trail as a polyline, drawn underneath
+// the rods and bodies. A trail takes its body's colour if it has one.
+func (f *frame) addTrails(t *trails, cam *camera) {
+	var segments []lineCommand
+	for i, body := range t.bodies {
+		c := f.theme.seriesColor(i)
+		if body < len(f.colors) {
+			c = f.colors[body]
+		}
+		path := t.path(i)
+		for k := 1; k < len(path); k++ {
+			from := cam.worldToScreen(path[k-1])
+			to := cam.worldToScreen(path[k])
+			segments = append(segments, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: c})
+		}
+	}
+	f.lines = append(segments, f.lines...)
+}
+
+// addCue appends the cue stick while it is being aimed. It sits behind the
+// cue ball, drawn further back the harder the shot.
+func (f *frame) addCue(c *cue, objects []physics.Body, cam *camera) {
+	if c == nil || !c.aiming || c.ball >= len(objects) {
+		return
+	}
+	velocity, _ := c.shot(objects)
+	direction := physics.UnitVector(velocity)
+	if direction == (physics.Vector{}) {
+		return
+	}
+
+	ball := objects[c.ball].Position
+	tip := physics.Subtract(ball, physics.ScalarMult(direction, physics.BallRadius+velocity.Magnitude()/cuePowerScale/4))
+	butt := physics.Subtract(tip, physics.ScalarMult(direction, cueLength))
+	from := cam.worldToScreen(tip)
+	to := cam.worldToScreen(butt)
+	f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.cue})
+}
+
+// addGhost appends the ball the spawner would drop, red if it is blocked,
+// unless it is holding one.
+func (f *frame) addGhost(s *spawner, cam *camera) {
+	if !s.active || s.holding {
+		return
+	}
+	at := cam.worldToScreen(s.ghost)
+	c := f.theme.ghost
+	if s.blocked {
+		c = f.theme.blocked
+	}
+	f.circles = append(f.circles, circleCommand{x: at.X, y: at.Y, radius: physics.BallRadius, color: c})
+}
+
+// predictionDot is the radius of the dots along a predicted path, and
+// predictionSpacing how many ticks apart they are.
+const (
+	predictionDot     = 1.5
+	predictionSpacing = 3
+)
+
+// addPrediction appends a dotted line along a predicted path, one dot
+// every predictionSpacing ticks.
+func (f *frame) addPrediction(path []physics.Vector, cam *camera) {
+	for k := predictionSpacing - 1; k < len(path); k += predictionSpacing {
+		at := cam.worldToScreen(path[k])
+		f.circles = append(f.circles, circleCommand{x: at.X, y: at.Y, radius: predictionDot, color: f.theme.cue})
+	}
+}
+
+// addVacuumPaths appends a dotted line along each path a body would take
+// through a vacuum, coloured in order from the palette's series.
+func (f *frame) addVacuumPaths(paths [][]physics.Vector, cam *camera) {
+	for i, path := range paths {
+		for k := predictionSpacing - 1; k < len(path); k += predictionSpacing {
+			at := cam.worldToScreen(path[k])
+			f.circles = append(f.circles, circleCommand{x: at.X, y: at.Y, radius: predictionDot, color: f.theme.seriesColor(i)})
+		}
+	}
+}
+
+const (
+	springCoils = 8
+	springWidth = 4
+)
+
+// addSpring appends a zigzag of springCoils coils from one screen point to
+// another, springWidth either side of the line between them, so it
+// squeezes up and stretches out as the spring does.
+func (f *frame) addSpring(from, to physics.Vector) {
+	along := physics.Subtract(to, from)
+	length := along.Magnitude()
+	if length == 0 {
+		return
+	}
+	across := physics.ScalarMult(physics.Vector{X: -along.Y, Y: along.X}, springWidth/length)
+	last := from
+	for k := 1; k <= 2*springCoils; k++ {
+		next := physics.Add(from, physics.ScalarMult(along, (float64(k)-0.5)/(2*springCoils)))
+		if k%2 == 0 {
+			next = physics.Subtract(next, across)
+		} else {
+			next = physics.Add(next, across)
+		}
+		f.lines = append(f.lines, lineCommand{x1: last.X, y1: last.Y, x2: next.X, y2: next.Y, color: f.theme.rod})
+		last = next
+	}
+	f.lines = append(f.lines, lineCommand{x1: last.X, y1: last.Y, x2: to.X, y2: to.Y, color: f.theme.rod})
+}
+
+// histogramHeight is how tall the fullest slot of a histogram is drawn.
+const histogramHeight = 90
+
+// addHistogram appends a bar per slot rising from the bins' floor, scaled
+// so the fullest slot is histogramHeight tall, with the expected shares as
+// a line over the top.
+func (f *frame) addHistogram(b *bins, cam *camera) {
+	if b == nil || b.total == 0 {
+		return
+	}
+	fullest := float64(slices.Max(b.counts))
+	scale := histogramHeight / fullest
+
+	for i, count := range b.counts {
+		height := float64(count) * scale
+		corner := cam.worldToScreen(physics.Vector{X: b.left + float64(i)*b.width + 2, Y: b.floor - height})
+		f.rects = append(f.rects, rectCommand{x: corner.X, y: corner.Y, width: b.width - 4, height: height, color: f.theme.histogram})
+	}
+
+	for i := 1; i < len(b.expected); i++ {
+		from := cam.worldToScreen(physics.Vector{
+			X: b.left + (float64(i)-0.5)*b.width,
+			Y: b.floor - b.expected[i-1]*float64(b.total)*scale,
+		})
+		to := cam.worldToScreen(physics.Vector{
+			X: b.left + (float64(i)+0.5)*b.width,
+			Y: b.floor - b.expected[i]*float64(b.total)*scale,
+		})
+		f.lines = append(f.lines, lineCommand{x1: from.X, y1: from.Y, x2: to.X, y2: to.Y, color: f.theme.expected})
+	}
+}
+
+// rasterize draws the frame in software onto an image filled with its
+// background. A pixel is filled when its centre lies inside a circle or
+// rectangle; lines are one pixel wide. Rectangles go underneath lines,
+// and lines underneath circles.
+func (f *frame) rasterize(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(f.background), image.Point{}, draw.Src)
+
+	for _, r := range f.rects {
+		bounds := image.Rect(
+			int(math.Round(r.x)), int(math.Round(r.y)),
+			int(math.Round(r.x+r.width)), int(math.Round(r.y+r.height)),
+		)
+		draw.Draw(img, bounds, image.NewUniform(r.color), image.Point{}, draw.Src)
+	}
+
+	for _, l := range f.lines {
+		// Step along the segment at half-pixel intervals
+		length := math.Hypot(l.x2-l.x1, l.y2-l.y1)
+		samples := int(length*2) + 1
+		for i := 0; i <= samples; i++ {
+			t := float64(i) / float64(samples)
+			px := int(math.Floor(l.x1 + t*(l.x2-l.x1)))
+			py := int(math.Floor(l.y1 + t*(l.y2-l.y1)))
+			if image.Pt(px, py).In(img.Bounds()) {
+				img.SetRGBA(px, py, l.color)
+			}
+		}
+	}
+
+	for _, c := range f.circles {
+		bounds := image.Rect(int(c.x-c.radius), int(c.y-c.radius), int(c.x+c.radius)+1, int(c.y+c.radius)+1).Intersect(img.Bounds())
+		for py := bounds.Min.Y; py < bounds.Max.Y; py++ {
+			for px := bounds.Min.X; px < bounds.Max.X; px++ {
+				if !f.antialias {
+					dx := float64(px) + 0.5 - c.x
+					dy := float64(py) + 0.5 - c.y
+					if dx*dx+dy*dy <= c.radius*c.radius {
+						img.SetRGBA(px, py, c.color)
+					}
+					continue
+				}
+				if covered := coverage(c, px, py); covered > 0 {
+					img.SetRGBA(px, py, mix(img.RGBAAt(px, py), c.color, covered))
+				}
+			}
+		}
+	}
+	return img
+}
+
+// subpixels is how many samples across and down each pixel is tested at
+// to find how much of it a circle covers.
+const subpixels = 4
+
+// coverage returns the share of pixel px, py inside circle c, from 0 to 1.
+func coverage(c circleCommand, px, py int) float64 {
+	inside := 0
+	for sy := range subpixels {
+		for sx := range subpixels {
+			dx := float64(px) + (float64(sx)+0.5)/subpixels - c.x
+			dy := float64(py) + (float64(sy)+0.5)/subpixels - c.y
+			if dx*dx+dy*dy <= c.radius*c.radius {
+				inside++
+			}
+		}
+	}
+	return float64(inside) / (subpixels * subpixels)
+}
+
+// mix returns the colour share of the way from under to over.
+func mix(under, over color.RGBA, share float64) color.RGBA {
+	channel := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*share))
+	}
+	return color.RGBA{R: channel(under.R, over.R), G: channel(under.G, over.G), B: channel(under.B, over.B), A: channel(under.A, over.A)}
+}
+
+// fadeTicks is how long before it expires a ball starts fading out.
+const fadeTicks = 30
+
+// fade returns c dimmed for a ball that expires at step expires, from
+// full strength fadeTicks before then down to nothing. Colours are
+// alpha-premultiplied, so every channel is scaled.
+func fade(c color.RGBA, expires, now uint64) color.RGBA {
+	if expires == 0 || expires >= now+fadeTicks {
+		return c
+	}
+	share := float64(expires-min(now, expires)) / fadeTicks
+	return color.RGBA{
+		R: uint8(float64(c.R) * share),
+		G: uint8(float64(c.G) * share),
+		B: uint8(float64(c.B) * share),
+		A: uint8(float64(c.A) * share),
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"image/color"
+	"math"
+	"slices"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestLabelModes checks names are shown only for named bodies, and that
+// index labels cover every body, centred above each one.
+func TestLabelModes(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{
+		{Position: physics.Vector{X: 100, Y: 200}, Name: "Earth"},
+		{Position: physics.Vector{X: 300, Y: 200}},
+	}, physics.Vector{})
+	defer w.Close()
+	w.Step()
+
+	var cam camera
+	var f frame
+	var got []string
+	for _, mode := range []labelMode{noLabels, nameLabels, indexLabels} {
+		f.build(w, &cam, physics.ScreenWidth, physics.ScreenHeight)
+		f.addLabels(w.Snapshot(), mode, &cam)
+		for _, text := range f.texts {
+			got = append(got, text.text)
+		}
+	}
+	if want := []string{"Earth", "0", "1"}; !slices.Equal(got, want) {
+		t.Fatalf("labels = %q, want %q", got, want)
+	}
+	if text := f.texts[0]; text.x != 100-glyphWidth/2 || text.y != 200-physics.BallRadius-glyphHeight {
+		t.Errorf("label %q drawn at %v, %v, want it centred over its body", text.text, text.x, text.y)
+	}
+}
+
+// TestBoundingBoxOutlines checks each body on screen gets its bounding box
+// outlined, and bodies off screen don't.
+func TestBoundingBoxOutlines(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{
+		{Position: physics.Vector{X: 100, Y: 200}},
+		{Position: physics.Vector{X: 300, Y: 200}},
+		{Position: physics.Vector{X: 3000, Y: 200}},
+	}, physics.Vector{}, physics.WithOpenArena(5000))
+	defer w.Close()
+	w.Step()
+
+	var cam camera
+	var f frame
+	f.build(w, &cam, physics.ScreenWidth, physics.ScreenHeight)
+	before := len(f.lines)
+	f.addBoundingBoxes(w.BoundingBoxes(nil), &cam)
+	if got := len(f.lines) - before; got != 8 {
+		t.Errorf("outlined with %d lines, want 4 for each of the 2 bodies on screen", got)
+	}
+}
+
+func TestFade(t *testing.T) {
+	c := color.RGBA{0xff, 0x80, 0x40, 0xff}
+	if got := fade(c, 0, 500); got != c {
+		t.Errorf("ball that never expires faded to %v", got)
+	}
+	if got := fade(c, 100+fadeTicks, 100); got != c {
+		t.Errorf("ball not yet fading drawn as %v", got)
+	}
+	if got, want := fade(c, 100+fadeTicks/2, 100), (color.RGBA{0x7f, 0x40, 0x20, 0x7f}); got != want {
+		t.Errorf("ball half faded drawn as %v, want %v", got, want)
+	}
+}
+
+// TestAntialiasedCirclesSitBetweenPixels draws a circle a quarter pixel
+// further over each time and checks, anti-aliased, it is shaded so its
+// weight sits where its centre is, rather than on the nearest pixel.
+func TestAntialiasedCirclesSitBetweenPixels(t *testing.T) {
+	for _, x := range []float64{10, 10.25, 10.5, 10.75} {
+		f := frame{
+			background: color.RGBA{A: 255},
+			circles:    []circleCommand{{x: x, y: 10, radius: 5, color: color.RGBA{R: 255, A: 255}}},
+			antialias:  true,
+		}
+		img := f.rasterize(20, 20)
+		total, moment := 0.0, 0.0
+		for py := 0; py < 20; py++ {
+			for px := 0; px < 20; px++ {
+				shade := float64(img.RGBAAt(px, py).R)
+				total += shade
+				moment += shade * (float64(px) + 0.5)
+			}
+		}
+		if centre := moment / total; math.Abs(centre-x) > 0.05 {
+			t.Errorf("circle at x %v shaded around %.3f", x, centre)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"physicsSim/physics"
+)
+
+// sceneFile is a scene as it is written in a JSON file, for trying out
+// scenes without building them into the sim. Balls are written as they are
+// in an autosave, with any field left out taking the default a preset's
+// would. A size, if given, walls the world in a box arena of that width
+// and height from the origin instead of the screen's, and the views fit
+// it to the window as they do any arena. A hole makes the scene a hole of
+// mini-golf, played with its first ball from where it starts, and gives
+// it a par and, optionally, a name. Scenes are JSON only: YAML would take
+// a parser from outside the standard library.
+type sceneFile struct {
+	Gravity [2]float64  `json:"gravity"`
+	Size    [2]float64  `json:"size,omitempty"`
+	Balls   []savedBody `json:"balls"`
+	obstacles
+	Name string      `json:"name,omitempty"`
+	Hole *[2]float64 `json:"hole,omitempty"`
+	Par  int         `json:"par,omitempty"`
+}
+
+// obstacles is the static geometry of a scene file. Walls run from x1,y1
+// to x2,y2, boxes from their top-left to their bottom-right corner, and
+// bumpers are circles given as x,y and radius.
+type obstacles struct {
+	Walls   [][4]float64 `json:"walls,omitempty"`
+	Boxes   [][4]float64 `json:"boxes,omitempty"`
+	Bumpers [][3]float64 `json:"bumpers,omitempty"`
+}
+
+// place swaps the world's static geometry for the obstacles. It must not
+// be called while a step runs.
+func (o *obstacles) place(w *physics.World) {
+	w.StaticSegments = w.StaticSegments[:0]
+	for _, s := range o.Walls {
+		w.StaticSegments = append(w.StaticSegments, physics.StaticSegment{A: physics.Vector{X: s[0], Y: s[1]}, B: physics.Vector{X: s[2], Y: s[3]}})
+	}
+	w.StaticBoxes = w.StaticBoxes[:0]
+	for _, b := range o.Boxes {
+		w.StaticBoxes = append(w.StaticBoxes, physics.StaticBox{Min: physics.Vector{X: b[0], Y: b[1]}, Max: physics.Vector{X: b[2], Y: b[3]}})
+	}
+	w.StaticCircles = w.StaticCircles[:0]
+	for _, b := range o.Bumpers {
+		w.StaticCircles = append(w.StaticCircles, physics.StaticCircle{Position: physics.Vector{X: b[0], Y: b[1]}, Radius: b[2]})
+	}
+}
+
+// readScene reads the scene in the JSON file at path, and checks it over
+// with Validate.
+func readScene(path string) (scene, error) {
+	if ext := filepath.Ext(path); ext == ".yaml" || ext == ".yml" {
+		return scene{}, fmt.Errorf("%s: scenes are read from JSON, not YAML; write it as JSON instead", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return scene{}, err
+	}
+	return parseScene(path, data)
+}
+
+// parseScene makes a scene of data, the JSON of the scene file at path,
+// and checks it over with Validate.
+func parseScene(path string, data []byte) (scene, error) {
+	var f sceneFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return scene{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(f.Balls) == 0 {
+		return scene{}, fmt.Errorf("%s: no balls", path)
+	}
+	if width, height := f.Size[0], f.Size[1]; width < 0 || height < 0 || (width == 0) != (height == 0) {
+		return scene{}, fmt.Errorf("%s: size must be a width and a height above zero, not %v", path, f.Size)
+	}
+	if f.Hole == nil && f.Par != 0 {
+		return scene{}, fmt.Errorf("%s: par %d but no hole to play to", path, f.Par)
+	}
+	if f.Hole != nil && f.Par <= 0 {
+		return scene{}, fmt.Errorf("%s: par must be at least one, not %d", path, f.Par)
+	}
+
+	s := scene{gravity: physics.Vector{X: f.Gravity[0], Y: f.Gravity[1]}}
+	for _, b := range f.Balls {
+		s.objects = append(s.objects, b.body())
+	}
+	if width, height := f.Size[0], f.Size[1]; width > 0 {
+		s.options = append(s.options, physics.WithBoxArena(width, height))
+	}
+	if f.Hole != nil {
+		s.golf = newGolf([]*course{{
+			Name:      f.Name,
+			Par:       f.Par,
+			Tee:       f.Balls[0].Position,
+			Hole:      *f.Hole,
+			obstacles: f.obstacles,
+		}})
+		s.options = append(s.options, s.golf.options()...)
+	} else {
+		s.options = append(s.options, func(w *physics.World) { f.obstacles.place(w) })
+	}
+
+	// Catch what would go wrong before it runs, rather than leave it to be
+	// puzzled over once it has
+	w := s.build()
+	defer w.Close()
+	if problems := w.Validate(); len(problems) > 0 {
+		for k, problem := range problems {
+			problems[k] = fmt.Errorf("%s: %w", path, problem)
+		}
+		return scene{}, errors.Join(problems...)
+	}
+	return s, nil
+}
+
+// LoadScene reads the scene in the JSON file at path and returns a game
+// running it, its world built with any further options.
+func LoadScene(path string, options ...physics.WorldOption) (*Game, error) {
+	s, err := readScene(path)
+	if err != nil {
+		return nil, err
+	}
+	return newGame(s, options...), nil
+}
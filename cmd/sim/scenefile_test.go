@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestReadScene reads the example scene and checks its balls come through
+// with their own masses and sizes, and that its walls are as wide as it
+// says rather than the screen.
+func TestReadScene(t *testing.T) {
+	s, err := readScene(filepath.Join("scenes", "orbit-pair.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.objects) != 4 {
+		t.Fatalf("read %d balls, want 4", len(s.objects))
+	}
+	if b := s.objects[0]; b.Mass != 4 || b.Radius != 30 || b.Color.R != 230 {
+		t.Errorf("first ball = %+v, want mass 4, radius 30 and its own colour", b)
+	}
+	if s.gravity != (physics.Vector{Y: 0.2}) {
+		t.Errorf("gravity = %v, want 0.2 down", s.gravity)
+	}
+
+	w := s.build()
+	defer w.Close()
+	if w.Arena.Shape != physics.BoxArena || w.Arena.Extent().Max.X <= physics.ScreenWidth {
+		t.Errorf("arena is shape %v reaching %v, want a box wider than the screen", w.Arena.Shape, w.Arena.Extent().Max)
+	}
+	for range 20 {
+		w.Step()
+	}
+	if p := w.Snapshot()[3].Position; p.X < physics.ScreenWidth {
+		t.Errorf("ball past the screen's right edge was pushed back to %v, want it left inside the wider walls", p)
+	}
+}
+
+// TestReadSceneRejectsBadFiles checks broken files, empty scenes, lopsided
+// sizes, a par with no hole, scenes that fail validation and YAML are
+// turned away rather than run, and a golf hole is read.
+func TestReadSceneRejectsBadFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, c := range []struct {
+		name, data string
+		ok         bool
+	}{
+		{"good.json", `{"gravity": [0, 0.3], "balls": [{"position": [100, 100], "velocity": [1, 0]}]}`, true},
+		{"broken.json", `{"balls": [`, false},
+		{"empty.json", `{"gravity": [0, 0.3]}`, false},
+		{"size.json", `{"size": [800, 0], "balls": [{"position": [100, 100], "velocity": [0, 0]}]}`, false},
+		{"mass.json", `{"balls": [{"position": [100, 100], "velocity": [0, 0], "mass": -1}]}`, false},
+		{"outside.json", `{"balls": [{"position": [700, 100], "velocity": [0, 0]}]}`, false},
+		{"hole.json", `{"hole": [500, 100], "par": 2, "balls": [{"position": [100, 100], "velocity": [0, 0]}], "boxes": [[300, 50, 340, 150]]}`, true},
+		{"par.json", `{"par": 2, "balls": [{"position": [100, 100], "velocity": [0, 0]}]}`, false},
+		{"bumper.json", `{"balls": [{"position": [100, 100], "velocity": [0, 0]}], "bumpers": [[300, 100, 0]]}`, false},
+		{"scene.yaml", `balls: []`, false},
+	} {
+		path := filepath.Join(dir, c.name)
+		if err := os.WriteFile(path, []byte(c.data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readScene(path); (err == nil) != c.ok {
+			t.Errorf("%s: err = %v", c.name, err)
+		}
+	}
+	if _, err := readScene(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("missing scene file read")
+	}
+}
@@ -0,0 +1,1699 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"slices"
+	"strconv"
+
+	"physicsSim/physics"
+)
+
+// scene describes a world to build: its bodies, gravity, constraints and
+// any other world options it needs, plus how to present it: which bodies
+// leave a trail, body colours, captions, the cue or game the player gets,
+// bins counting where balls land, emitters feeding in new ones, drains
+// taking them out again, portals moving them about and stopwatches timing
+// them.
+type scene struct {
+	objects     []physics.Body
+	gravity     physics.Vector
+	constraints []physics.DistanceConstraint
+	options     []physics.WorldOption
+
+	trails      []int
+	colors      []color.RGBA
+	captions    []caption
+	cue         *cue
+	breakout    *breakout
+	golf        *golf
+	car         *car
+	bins        *bins
+	emitters    []*emitter
+	drains      []*drain
+	portals     []*portal
+	stopwatches []*stopwatch
+	// vacuum lists bodies whose path through a vacuum is drawn, to set
+	// against the path they take through the world's air
+	vacuum []int
+}
+
+// caption is a line of text drawn centred on a point in the world.
+type caption struct {
+	position physics.Vector
+	text     string
+}
+
+// presets are the built-in scenes, selectable with the -preset flag.
+var presets = map[string]func() scene{
+	"default":       defaultScene,
+	"cradle":        newtonsCradleScene,
+	"magnets":       magnetsScene,
+	"pendulum":      doublePendulumScene,
+	"pendulum-pair": doublePendulumPairScene,
+	"restitution":   restitutionScene,
+	"wrecking-ball": wreckingBallScene,
+	"water":         waterScene,
+	"accretion":     accretionScene,
+	"mud":           mudScene,
+	"bands":         bandsScene,
+	"sparks":        sparksScene,
+	"mixing":        mixingScene,
+	"breakout":      breakoutScene,
+	"billiards":     billiardsScene,
+	"bowl":          bowlScene,
+	"car":           carScene,
+	"contraption":   contraptionScene,
+	"curtain":       curtainScene,
+	"dominoes":      dominoesScene,
+	"drag":          dragScene,
+	"fields":        fieldsScene,
+	"flock":         flockScene,
+	"fountain":      fountainScene,
+	"galton":        galtonBoardScene,
+	"golf":          golfScene,
+	"hills":         hillsScene,
+	"plinko":        plinkoScene,
+	"polygons":      polygonsScene,
+	"portals":       portalsScene,
+	"projectile":    projectileScene,
+	"rain":          rainScene,
+	"rope":          ropeScene,
+	"ramps":         rampsScene,
+	"sizes":         sizesScene,
+	"spin":          spinScene,
+	"springs":       springsScene,
+	"solar":         solarSystemScene,
+	"star":          starScene,
+	"torus":         torusScene,
+	"welds":         weldsScene,
+	"zones":         zonesScene,
+}
+
+// presetNames lists the presets in alphabetical order.
+func presetNames() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// build creates a world running the scene.
+func (s scene) build(options ...physics.WorldOption) *physics.World {
+	options = append(append([]physics.WorldOption{physics.WithConstraints(s.constraints...)}, s.options...), options...)
+	return physics.NewWorld(s.objects, s.gravity, options...)
+}
+
+// defaultScene is the handful of bouncing balls shown at startup.
+func defaultScene() scene {
+	objects := []physics.Body{
+		{
+			Position: physics.Vector{X: 100, Y: 100},
+			Velocity: physics.Vector{X: 2, Y: 3},
+		},
+		{
+			Position: physics.Vector{X: 300, Y: 200},
+			Velocity: physics.Vector{X: -1, Y: -2},
+		},
+		{
+			Position: physics.Vector{X: 10, Y: 150},
+			Velocity: physics.Vector{X: 2, Y: 3},
+		},
+		{
+			Position: physics.Vector{X: 20, Y: 20},
+			Velocity: physics.Vector{X: -1, Y: -2},
+		},
+		{
+			Position: physics.Vector{X: 200, Y: 100},
+			Velocity: physics.Vector{X: 2, Y: 3},
+		},
+		{
+			Position: physics.Vector{X: 30, Y: 200},
+			Velocity: physics.Vector{X: -1, Y: -2},
+		},
+		{
+			Position: physics.Vector{X: 100, Y: 100},
+			Velocity: physics.Vector{X: 2, Y: 3},
+		},
+		{
+			Position: physics.Vector{X: 300, Y: 200},
+			Velocity: physics.Vector{X: -1, Y: -2},
+		},
+	}
+
+	return scene{objects: objects, gravity: physics.Vector{X: 0, Y: .3}}
+}
+
+// newtonsCradleScene hangs a row of touching balls from rigid rods and
+// lifts the first one, so its momentum passes through the row and
+// launches the ball at the far end.
+func newtonsCradleScene() scene {
+	const (
+		count   = 5
+		length  = 220
+		anchorY = 60
+		// gap keeps neighbours a hair apart so they only collide when struck
+		gap = 0.01
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	spacing := 2*physics.BallRadius + gap
+	left := physics.ScreenWidth/2 - spacing*(count-1)/2
+	for i := 0; i < count; i++ {
+		anchor := physics.Vector{X: left + float64(i)*spacing, Y: anchorY}
+		position := physics.Vector{X: anchor.X, Y: anchorY + length}
+
+		// Pull the first ball back to 45 degrees
+		if i == 0 {
+			angle := -math.Pi / 4
+			position = physics.Vector{X: anchor.X + length*math.Sin(angle), Y: anchorY + length*math.Cos(angle)}
+		}
+
+		s.objects = append(s.objects, physics.Body{Position: position, Material: physics.Steel})
+		s.constraints = append(s.constraints, physics.NewAnchoredRod(s.objects, i, anchor))
+	}
+	return s
+}
+
+// wreckingBallScene swings a heavy ball on a rod into a stack of light
+// balls packed between a kerb and the right-hand wall.
+func wreckingBallScene() scene {
+	const (
+		length = 360
+		// mass is the wrecking ball's weight in stacked balls
+		mass = 20
+		rows = 5
+		// restitution is low so the stack settles rather than rattles
+		restitution = 0.5
+		// A resting stack only holds still when each contact is solved in
+		// small steps; with one per tick the gravity every ball picks up
+		// bounces it off its neighbours
+		substeps = 8
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	// Hang the wrecking ball out level with its pivot
+	pivot := physics.Vector{X: 380, Y: 60}
+	s.objects = append(s.objects, physics.Body{Position: physics.Vector{X: pivot.X - length, Y: pivot.Y}, Mass: mass, Material: physics.Steel, Name: "wrecking ball"})
+	s.constraints = append(s.constraints, physics.NewAnchoredRod(s.objects, 0, pivot))
+	s.colors = []color.RGBA{defaultPalette.rod}
+
+	// The bottom row spans from the kerb to the wall so the rows above sit
+	// in its grooves
+	rowHeight := math.Sqrt(3) * physics.BallRadius
+	right := float64(physics.ScreenWidth - physics.BallRadius)
+	floor := float64(physics.ScreenHeight - physics.BallRadius)
+	for row := 0; row < rows; row++ {
+		for k := 0; k < rows-row; k++ {
+			x := right - float64(row)*physics.BallRadius - float64(k)*2*physics.BallRadius
+			s.objects = append(s.objects, physics.Body{Position: physics.Vector{X: x, Y: floor - float64(row)*rowHeight}, Material: physics.Wood})
+		}
+	}
+	kerb := right - float64(rows)*2*physics.BallRadius + physics.BallRadius
+	s.options = append(s.options,
+		physics.WithStaticSegments(physics.StaticSegment{A: physics.Vector{X: kerb, Y: physics.ScreenHeight - 2*physics.BallRadius}, B: physics.Vector{X: kerb, Y: physics.ScreenHeight}}),
+		physics.WithWallRestitution(restitution),
+		physics.WithSubsteps(substeps),
+	)
+	return s
+}
+
+// pendulumSubsteps keeps the double pendulums swinging. Each rod step
+// loses a sliver of energy, and the chaotic motion only shows while they
+// still have plenty.
+const pendulumSubsteps = 16
+
+// doublePendulumScene hangs one double pendulum from the middle of the
+// screen with a trail on its lower bob.
+func doublePendulumScene() scene {
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	s.options = append(s.options, physics.WithSubsteps(pendulumSubsteps))
+	s.addDoublePendulum(physics.Vector{X: physics.ScreenWidth / 2, Y: 200}, 100, 2*math.Pi/3, 2*math.Pi/3)
+	return s
+}
+
+// doublePendulumPairScene runs two double pendulums side by side whose
+// starting angles differ by a thousandth of a radian. They track each
+// other at first, then their trails fly apart.
+func doublePendulumPairScene() scene {
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	s.options = append(s.options, physics.WithSubsteps(pendulumSubsteps))
+	s.addDoublePendulum(physics.Vector{X: physics.ScreenWidth / 4, Y: 200}, 65, 2*math.Pi/3, 2*math.Pi/3)
+	s.addDoublePendulum(physics.Vector{X: 3 * physics.ScreenWidth / 4, Y: 200}, 65, 2*math.Pi/3, 2*math.Pi/3+0.001)
+	return s
+}
+
+// addDoublePendulum hangs two bobs in series from anchor, with both arms
+// of the given length and angles measured from straight down.
+func (s *scene) addDoublePendulum(anchor physics.Vector, arm, angle1, angle2 float64) {
+	upper := physics.Add(anchor, physics.Vector{X: arm * math.Sin(angle1), Y: arm * math.Cos(angle1)})
+	lower := physics.Add(upper, physics.Vector{X: arm * math.Sin(angle2), Y: arm * math.Cos(angle2)})
+
+	first := len(s.objects)
+	s.objects = append(s.objects, physics.Body{Position: upper}, physics.Body{Position: lower})
+	s.constraints = append(s.constraints,
+		physics.NewAnchoredRod(s.objects, first, anchor),
+		physics.NewRod(s.objects, first+1, first),
+	)
+	s.trails = append(s.trails, first+1)
+}
+
+// Pool table settings, in pixels and ticks. Cushions return far less speed
+// than the balls do off each other.
+const (
+	clothSliding       = 0.2
+	clothRolling       = 0.02
+	cushionRestitution = 0.75
+)
+
+// poolColors are the solid colours of balls one to eight; nine to fifteen
+// reuse one to seven.
+var poolColors = []color.RGBA{
+	{0xf5, 0xc5, 0x18, 0xff},
+	{0x1f, 0x4f, 0xd8, 0xff},
+	{0xd8, 0x22, 0x22, 0xff},
+	{0x6a, 0x2c, 0x9e, 0xff},
+	{0xf0, 0x7d, 0x1a, 0xff},
+	{0x1a, 0x8a, 0x3c, 0xff},
+	{0x8a, 0x1c, 0x1c, 0xff},
+	{0x10, 0x10, 0x10, 0xff},
+}
+
+// billiardsScene racks fifteen balls on a pool table viewed from above,
+// with the cue ball at body 0 for the player to strike.
+func billiardsScene() scene {
+	const (
+		rows = 5
+		// gap keeps the rack from starting in contact
+		gap = 0.5
+	)
+
+	var s scene
+	s.options = append(s.options,
+		physics.WithCloth(clothSliding, clothRolling),
+		physics.WithWallRestitution(cushionRestitution),
+	)
+	s.objects = append(s.objects, physics.Body{Position: physics.Vector{X: physics.ScreenWidth / 4, Y: physics.ScreenHeight / 2}, Material: physics.Steel, Name: "cue"})
+	s.colors = append(s.colors, color.RGBA{0xff, 0xff, 0xff, 0xff})
+	s.cue = newCue(0)
+
+	// The eight goes in the middle of the third row
+	numbers := []int{1, 9, 2, 10, 8, 3, 11, 4, 12, 5, 13, 6, 14, 7, 15}
+	spacing := 2*physics.BallRadius + gap
+	apex := physics.Vector{X: physics.ScreenWidth * 0.65, Y: physics.ScreenHeight / 2}
+	for row := 0; row < rows; row++ {
+		for k := 0; k <= row; k++ {
+			position := physics.Add(apex, physics.Vector{
+				X: float64(row) * spacing * math.Sqrt(3) / 2,
+				Y: (float64(k) - float64(row)/2) * spacing,
+			})
+			number := numbers[len(s.objects)-1]
+			s.objects = append(s.objects, physics.Body{Position: position, Material: physics.Steel, Name: strconv.Itoa(number)})
+			s.colors = append(s.colors, poolColors[(number-1)%len(poolColors)])
+		}
+	}
+	return s
+}
+
+// galtonBoardScene funnels a stream of balls through staggered rows of
+// pegs into a row of bins. Each peg sends a ball left or right about evenly,
+// so the counts pile up into a binomial distribution; landed balls are
+// sent back to the top to keep the histogram growing.
+func galtonBoardScene() scene {
+	const (
+		rows      = 5
+		pegRadius = 4
+		// Pegs sit far enough apart, across and diagonally, for a ball to
+		// pass between any two of them
+		pegSpacing = 60
+		rowSpacing = 52
+		firstRow   = 130
+		binTop     = 385
+		balls      = 10
+		// restitution is low so balls trickle down rather than bounce
+		restitution = 0.3
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	centre := physics.ScreenWidth / 2.0
+	left := centre - (rows+1)*pegSpacing/2.0
+	right := centre + (rows+1)*pegSpacing/2.0
+	var pegs []physics.StaticCircle
+	for row := 0; row < rows; row++ {
+		// Rows run the full width of the bins, so a ball knocked wide still
+		// meets a peg on every row
+		for k := -rows; k <= 2*rows; k++ {
+			x := centre + (float64(k)-float64(row)/2)*pegSpacing
+			if x < left+pegSpacing/4 || x > right-pegSpacing/4 {
+				continue
+			}
+			pegs = append(pegs, physics.StaticCircle{
+				Position: physics.Vector{X: x, Y: firstRow + float64(row)*rowSpacing},
+				Radius:   pegRadius,
+			})
+		}
+	}
+
+	// A funnel and a short chute drop balls straight onto the top peg, and
+	// dividers split the floor into one bin per possible number of
+	// right-hand bounces
+	funnelGap := 2*physics.BallRadius + 8.0
+	funnelExit := firstRow - 80.0
+	chuteEnd := firstRow - 2.0*physics.BallRadius
+	segments := []physics.StaticSegment{
+		{A: physics.Vector{X: 40, Y: 20}, B: physics.Vector{X: centre - funnelGap/2, Y: funnelExit}},
+		{A: physics.Vector{X: physics.ScreenWidth - 40, Y: 20}, B: physics.Vector{X: centre + funnelGap/2, Y: funnelExit}},
+		{A: physics.Vector{X: centre - funnelGap/2, Y: funnelExit}, B: physics.Vector{X: centre - funnelGap/2, Y: chuteEnd}},
+		{A: physics.Vector{X: centre + funnelGap/2, Y: funnelExit}, B: physics.Vector{X: centre + funnelGap/2, Y: chuteEnd}},
+	}
+	for i := 0; i <= rows+1; i++ {
+		x := left + float64(i)*pegSpacing
+		segments = append(segments, physics.StaticSegment{A: physics.Vector{X: x, Y: binTop - 20}, B: physics.Vector{X: x, Y: physics.ScreenHeight}})
+	}
+
+	s.options = append(s.options,
+		physics.WithStaticCircles(pegs...),
+		physics.WithStaticSegments(segments...),
+		physics.WithWallRestitution(restitution),
+	)
+
+	spawn := physics.Vector{X: centre, Y: physics.BallRadius}
+	for i := 0; i < balls; i++ {
+		x := centre + (float64(i)-(balls-1)/2.0)*(2*physics.BallRadius+4)
+		s.objects = append(s.objects, physics.Body{Position: physics.Vector{X: x, Y: physics.BallRadius}})
+	}
+
+	s.bins = newBins(left, pegSpacing, rows+1, binTop, physics.ScreenHeight)
+	s.bins.respawnAt(spawn, 3*physics.BallRadius)
+	s.bins.expected = binomialShares(rows)
+	return s
+}
+
+// plinkoScene drops a steady stream of balls from the top through a field
+// of pegs into eight bins, which take each landed ball out of play.
+func plinkoScene() scene {
+	const (
+		binCount = 8
+		binWidth = physics.ScreenWidth / binCount
+		rows     = 5
+		// Pegs are spaced so a ball fits between any two of them, across
+		// or diagonally
+		rowSpacing  = 55
+		firstRow    = 110
+		pegRadius   = 4
+		binTop      = 400
+		restitution = 0.5
+		interval    = 20
+		limit       = 30
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	var pegs []physics.StaticCircle
+	for row := 0; row < rows; row++ {
+		// Every other row shifts half a bin across
+		offset := float64(row%2) * binWidth / 2
+		for x := binWidth/2 + offset; x < physics.ScreenWidth; x += binWidth {
+			pegs = append(pegs, physics.StaticCircle{
+				Position: physics.Vector{X: x, Y: firstRow + float64(row)*rowSpacing},
+				Radius:   pegRadius,
+			})
+		}
+	}
+
+	var dividers []physics.StaticSegment
+	for i := 1; i < binCount; i++ {
+		x := float64(i * binWidth)
+		dividers = append(dividers, physics.StaticSegment{A: physics.Vector{X: x, Y: binTop - 40}, B: physics.Vector{X: x, Y: physics.ScreenHeight}})
+	}
+
+	s.options = append(s.options,
+		physics.WithStaticCircles(pegs...),
+		physics.WithStaticSegments(dividers...),
+		physics.WithWallRestitution(restitution),
+	)
+	s.bins = newBins(0, binWidth, binCount, binTop, physics.ScreenHeight)
+	s.emitters = append(s.emitters, newEmitter(physics.Vector{X: physics.ScreenWidth / 2, Y: physics.BallRadius}, physics.ScreenWidth/3, interval, limit))
+	return s
+}
+
+// restitutionScene drops a row of balls from the same height, each keeping
+// a tenth more of its speed off the floor than the one to its left, from
+// 0.1 up to a perfect bounce at 1.
+func restitutionScene() scene {
+	const (
+		balls  = 10
+		height = 120
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	spacing := float64(physics.ScreenWidth) / balls
+	for i := 0; i < balls; i++ {
+		e := float64(i+1) / balls
+		x := (float64(i) + 0.5) * spacing
+		s.objects = append(s.objects, physics.Body{Position: physics.Vector{X: x, Y: height}, Restitution: e, HasRestitution: true})
+		s.captions = append(s.captions, caption{position: physics.Vector{X: x, Y: height - 2*physics.BallRadius}, text: fmt.Sprintf("e=%.1f", e)})
+	}
+	return s
+}
+
+// contraptionScene chains together every kind of body, obstacle and
+// constraint the world has. An emitter feeds balls onto a tilted box that
+// drops them on a seesaw, which tips them onto a chute; they roll down it
+// into a bob hung from a pulley and knock it into a row of dominoes, which
+// topple onto a cart parked against a sprung buffer. The balls drop on
+// into counting bins with pointed polygon dividers and rolling ground.
+func contraptionScene() scene {
+	const (
+		// seesawArm is how far each end of the seesaw sits from its middle,
+		// and seesawDrop how far the beam hangs below its pivot, which
+		// rights it after every hit
+		seesawArm  = 60
+		seesawDrop = 20
+		// The ramp is a plank, tilted down towards the seesaw
+		plank = 290
+		thick = 8
+		tilt  = 0.245
+		// The pulley's line is beads round the top of the wheel, and line
+		// is how far below the wheel the bob hangs. The counterweight
+		// outweighs the bob, so it rests on its shelf and the bob swings
+		// as a pendulum; a hard knock can still lift it
+		pulleyRadius  = 14
+		beads         = 7
+		bead          = 4
+		line          = 110
+		bobWeight     = 0.5
+		counterweight = 1
+		// The dominoes stand on a shelf over the chute's end, where the bob
+		// swings into the first of them
+		shelfTop     = 285
+		dominoRadius = 4
+		dominoRows   = 8
+		dominoGap    = 35
+		firstDomino  = 395
+		binTop       = 430
+		interval     = 120
+		limit        = 8
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	seesawPivot := physics.Vector{X: 240, Y: 180}
+	left := physics.Body{Position: physics.Vector{X: seesawPivot.X - seesawArm, Y: seesawPivot.Y + seesawDrop}, Material: physics.Wood}
+	right := physics.Body{Position: physics.Vector{X: seesawPivot.X + seesawArm, Y: seesawPivot.Y + seesawDrop}, Material: physics.Wood}
+	s.objects = append(s.objects, left, right)
+	s.constraints = append(s.constraints,
+		physics.NewRod(s.objects, 0, 1),
+		physics.NewAnchoredRod(s.objects, 0, seesawPivot),
+		physics.NewAnchoredRod(s.objects, 1, seesawPivot),
+	)
+
+	// The beads are rodded into a line laid over the wheel, which has no
+	// grip, so the line slides round it as a rope would
+	pulley := physics.StaticCircle{Position: physics.Vector{X: 358, Y: 165}, Radius: pulleyRadius}
+	around := pulley.Radius + bead
+	for k := 0; k < beads; k++ {
+		a := math.Pi * float64(k) / (beads - 1)
+		s.objects = append(s.objects, physics.Body{
+			Position: physics.Add(pulley.Position, physics.Vector{X: -around * math.Cos(a), Y: -around * math.Sin(a)}),
+			Radius:   bead,
+			Mass:     0.1,
+		})
+		if k > 0 {
+			s.constraints = append(s.constraints, physics.NewRod(s.objects, len(s.objects)-2, len(s.objects)-1))
+		}
+	}
+	firstBead, lastBead := len(s.objects)-beads, len(s.objects)-1
+	weight := physics.Body{Position: physics.Vector{X: pulley.Position.X + around, Y: pulley.Position.Y + 30}, Radius: 12, Mass: counterweight, Material: physics.Steel}
+	bob := physics.Body{Position: physics.Vector{X: pulley.Position.X - around, Y: pulley.Position.Y + line}, Mass: bobWeight, Material: physics.Steel}
+	s.objects = append(s.objects, weight, bob)
+	s.constraints = append(s.constraints,
+		physics.NewRod(s.objects, lastBead, len(s.objects)-2),
+		physics.NewRod(s.objects, firstBead, len(s.objects)-1),
+	)
+	for range s.objects {
+		s.colors = append(s.colors, defaultPalette.rod)
+	}
+	weightShelf := physics.StaticBox{
+		Min: physics.Subtract(weight.Position, physics.Vector{X: 14, Y: -weight.Radius}),
+		Max: physics.Add(weight.Position, physics.Vector{X: 14, Y: weight.Radius + thick}),
+	}
+
+	var welds []physics.Weld
+	for k := 0; k < 3; k++ {
+		s.objects, welds = appendDomino(s.objects, welds, firstDomino+dominoRadius+float64(k)*dominoGap, shelfTop, dominoRadius, dominoRows)
+	}
+	for len(s.colors) < len(s.objects) {
+		s.colors = append(s.colors, dominoColor)
+	}
+	// The cart stands with its rear wheel where the last domino falls, and
+	// its front one against the buffer, a ball held off the wall by a
+	// spring
+	cart, objects, cartOptions := newCar(s.objects, physics.Vector{X: 532, Y: shelfTop - wheelRadius})
+	s.objects = objects
+	s.colors = append(s.colors, cart.colors()...)
+	buffer := physics.Body{Position: physics.Vector{X: 608, Y: shelfTop - 8}, Radius: 8, Material: physics.Rubber}
+	s.objects = append(s.objects, buffer)
+	s.colors = append(s.colors, defaultPalette.rod)
+	s.constraints = append(s.constraints, physics.NewAnchoredSpring(s.objects, len(s.objects)-1, physics.Vector{X: physics.ScreenWidth, Y: buffer.Position.Y}, 0.05, 0.02))
+	shelf := physics.StaticBox{Min: physics.Vector{X: firstDomino - 2, Y: shelfTop}, Max: physics.Vector{X: physics.ScreenWidth, Y: shelfTop + thick}}
+
+	centre := physics.Vector{X: 480, Y: 99}
+	ramp := physics.StaticBox{
+		Min:   physics.Subtract(centre, physics.Vector{X: plank / 2, Y: thick / 2}),
+		Max:   physics.Add(centre, physics.Vector{X: plank / 2, Y: thick / 2}),
+		Angle: -tilt,
+	}
+	// The chute bends down past the bob so the balls pass under the shelf
+	chute := physics.StaticChain{Points: []physics.Vector{{X: 0, Y: 280}, {X: 220, Y: 305}, {X: 340, Y: 322}, {X: 440, Y: 360}}}
+	var dividers []physics.StaticPolygon
+	for x := 80.0; x < physics.ScreenWidth; x += 80 {
+		dividers = append(dividers, physics.NewStaticPolygon(
+			physics.Vector{X: x - 3, Y: physics.ScreenHeight},
+			physics.Vector{X: x - 3, Y: binTop + 6},
+			physics.Vector{X: x, Y: binTop},
+			physics.Vector{X: x + 3, Y: binTop + 6},
+			physics.Vector{X: x + 3, Y: physics.ScreenHeight},
+		))
+	}
+	// Each bin's floor dips to its middle, so a landed ball settles there
+	floor := physics.NewHeightfield(0, physics.ScreenWidth, 65, func(x float64) float64 {
+		return physics.ScreenHeight - 8 + 6*math.Cos(2*math.Pi*(x-40)/80)
+	})
+
+	s.options = append(s.options, cartOptions...)
+	s.options = append(s.options,
+		physics.WithWelds(welds...),
+		physics.WithStaticBoxes(ramp, weightShelf, shelf),
+		physics.WithStaticCircles(pulley),
+		physics.WithStaticChains(chute),
+		physics.WithStaticPolygons(dividers...),
+		physics.WithHeightfields(floor),
+		physics.WithWallRestitution(0.5),
+		// The dominoes' welds need stepping in eighths to stand
+		physics.WithSubsteps(8),
+	)
+	s.bins = newBins(0, 80, physics.ScreenWidth/80, binTop, physics.ScreenHeight)
+	s.stopwatches = append(s.stopwatches, newStopwatch("ramp",
+		zone{min: physics.Vector{X: 540, Y: 30}, max: physics.Vector{X: 580, Y: 90}},
+		zone{min: physics.Vector{X: 350, Y: 70}, max: physics.Vector{X: 390, Y: 130}},
+	))
+	// The emitter counts the rig's own bodies towards its limit
+	s.emitters = append(s.emitters, newEmitter(physics.Vector{X: physics.ScreenWidth - 40, Y: physics.BallRadius}, 0, interval, limit+len(s.objects)))
+	return s
+}
+
+// Solar system scale: the inner planets' orbits fill the screen and Earth
+// takes ten seconds at normal speed to go round.
+const (
+	auPixels  = 130
+	yearTicks = 600
+)
+
+// planet is a real planet's orbit, with its semi-major axis in astronomical
+// units, longitude of perihelion in degrees and mass in solar masses.
+type planet struct {
+	name          string
+	semiMajorAxis float64
+	eccentricity  float64
+	perihelion    float64
+	mass          float64
+	color         color.RGBA
+}
+
+var innerPlanets = []planet{
+	{name: "Mercury", semiMajorAxis: 0.387, eccentricity: 0.2056, perihelion: 77.46, mass: 1.660e-7, color: color.RGBA{0xa0, 0x9a, 0x90, 0xff}},
+	{name: "Venus", semiMajorAxis: 0.723, eccentricity: 0.0068, perihelion: 131.53, mass: 2.448e-6, color: color.RGBA{0xe6, 0xc2, 0x7a, 0xff}},
+	{name: "Earth", semiMajorAxis: 1.000, eccentricity: 0.0167, perihelion: 102.95, mass: 3.003e-6, color: color.RGBA{0x3c, 0x8c, 0xe0, 0xff}},
+	{name: "Mars", semiMajorAxis: 1.524, eccentricity: 0.0934, perihelion: 336.04, mass: 3.227e-7, color: color.RGBA{0xd0, 0x5a, 0x32, 0xff}},
+}
+
+// solarSystemScene puts the Sun and the four inner planets on their real
+// orbits, scaled down, each starting at perihelion. Everything pulls on
+// everything else, and the Sun is given the recoil that keeps the centre
+// of mass still. Bodies are drawn far larger than their orbits allow, so
+// they pass over each other rather than colliding.
+func solarSystemScene() scene {
+	// Kepler's third law sets the Sun's mass from Earth's year
+	sunMass := 4 * math.Pi * math.Pi * math.Pow(auPixels, 3) / (yearTicks * yearTicks)
+	centre := physics.Vector{X: physics.ScreenWidth / 2, Y: physics.ScreenHeight / 2}
+
+	var s scene
+	s.options = append(s.options, physics.WithAttraction(1), physics.WithoutContacts(), physics.WithSubsteps(4))
+	s.objects = append(s.objects, physics.Body{Position: centre, Mass: sunMass, Name: "Sun"})
+	s.colors = append(s.colors, color.RGBA{0xff, 0xd8, 0x40, 0xff})
+
+	var momentum physics.Vector
+	for _, p := range innerPlanets {
+		a := p.semiMajorAxis * auPixels
+		distance := a * (1 - p.eccentricity)
+		speed := math.Sqrt(sunMass * (1 + p.eccentricity) / distance)
+
+		// Screen y points down, so flip it to keep north up and orbits
+		// running anticlockwise
+		angle := p.perihelion * math.Pi / 180
+		out := physics.Vector{X: math.Cos(angle), Y: -math.Sin(angle)}
+		along := physics.Vector{X: -math.Sin(angle), Y: -math.Cos(angle)}
+
+		planet := physics.Body{
+			Position: physics.Add(centre, physics.ScalarMult(out, distance)),
+			Velocity: physics.ScalarMult(along, speed),
+			Mass:     p.mass * sunMass,
+			Name:     p.name,
+		}
+		momentum = physics.Add(momentum, physics.ScalarMult(planet.Velocity, planet.Mass))
+		s.trails = append(s.trails, len(s.objects))
+		s.objects = append(s.objects, planet)
+		s.colors = append(s.colors, p.color)
+	}
+	s.objects[0].Velocity = physics.ScalarMult(momentum, -1/sunMass)
+	return s
+}
+
+// bowlScene drops a grid of balls into a round bowl, which rolls them
+// together at the bottom.
+func bowlScene() scene {
+	const (
+		radius  = 220
+		columns = 6
+		rows    = 4
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	centre := physics.Vector{X: physics.ScreenWidth / 2, Y: physics.ScreenHeight / 2}
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			offset := physics.Vector{X: (float64(column) - (columns-1)/2.0) * 2.5 * physics.BallRadius, Y: (float64(row) - rows + 1) * 2.5 * physics.BallRadius}
+			s.objects = append(s.objects, physics.Body{Position: physics.Add(centre, offset)})
+		}
+	}
+	s.options = append(s.options, physics.WithCircleArena(centre, radius), physics.WithWallRestitution(0.8))
+	return s
+}
+
+// starScene bounces balls around inside a five-pointed star, whose inner
+// corners turn them back in every direction.
+func starScene() scene {
+	const (
+		points = 5
+		outer  = 230
+		inner  = 120
+		balls  = 10
+		ring   = 85
+	)
+
+	var s scene
+	centre := physics.Vector{X: physics.ScreenWidth / 2, Y: physics.ScreenHeight / 2}
+	var vertices []physics.Vector
+	for k := 0; k < 2*points; k++ {
+		radius := float64(outer)
+		if k%2 == 1 {
+			radius = inner
+		}
+		angle := math.Pi*float64(k)/points - math.Pi/2
+		vertices = append(vertices, physics.Add(centre, physics.Vector{X: radius * math.Cos(angle), Y: radius * math.Sin(angle)}))
+	}
+
+	// Start the balls on a ring around the middle, heading out at angles
+	for i := 0; i < balls; i++ {
+		angle := 2 * math.Pi * float64(i) / balls
+		heading := angle + 0.5
+		s.objects = append(s.objects, physics.Body{
+			Position: physics.Add(centre, physics.Vector{X: ring * math.Cos(angle), Y: ring * math.Sin(angle)}),
+			Velocity: physics.Vector{X: 3 * math.Cos(heading), Y: 3 * math.Sin(heading)},
+		})
+	}
+	s.options = append(s.options, physics.WithPolygonArena(vertices...))
+	return s
+}
+
+// torusScene is a gas of balls on a world with no walls, where everything
+// leaving one edge comes back in at the other.
+func torusScene() scene {
+	const (
+		columns = 8
+		rows    = 6
+		speed   = 3
+		// goldenAngle spreads the headings evenly without any two lining up
+		goldenAngle = 2.39996
+	)
+
+	var s scene
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			heading := goldenAngle * float64(row*columns+column)
+			s.objects = append(s.objects, physics.Body{
+				Position: physics.Vector{X: (float64(column) + 0.5) * physics.ScreenWidth / columns, Y: (float64(row) + 0.5) * physics.ScreenHeight / rows},
+				Velocity: physics.Vector{X: speed * math.Cos(heading), Y: speed * math.Sin(heading)},
+			})
+		}
+	}
+	s.options = append(s.options, physics.WithTorusArena())
+	return s
+}
+
+// rainScene pours balls without end through a field of pegs and out of an
+// open bottom. The body limit keeps the world from filling up if they
+// arrive faster than they leave.
+func rainScene() scene {
+	const (
+		columns    = 8
+		pegSpacing = physics.ScreenWidth / columns
+		rows       = 5
+		rowSpacing = 60
+		firstRow   = 120
+		pegRadius  = 6
+		margin     = 2 * physics.BallRadius
+		interval   = 2
+		bodyLimit  = 40
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	var pegs []physics.StaticCircle
+	for row := 0; row < rows; row++ {
+		offset := float64(row%2) * pegSpacing / 2
+		for x := pegSpacing/2 + offset; x < physics.ScreenWidth; x += pegSpacing {
+			pegs = append(pegs, physics.StaticCircle{
+				Position: physics.Vector{X: x, Y: firstRow + float64(row)*rowSpacing},
+				Radius:   pegRadius,
+			})
+		}
+	}
+
+	s.options = append(s.options,
+		physics.WithStaticCircles(pegs...),
+		physics.WithOpenArena(margin),
+		physics.WithBodyLimit(bodyLimit),
+	)
+	s.emitters = append(s.emitters, newEmitter(physics.Vector{X: physics.ScreenWidth / 2, Y: -physics.BallRadius}, physics.ScreenWidth/2, interval, 0))
+	return s
+}
+
+// fountainScene sprays steel balls up and in from the left wall and wooden
+// ones from the right, so the two streams cross and scatter each other. A
+// sink in the middle of the floor drains them away, and the oldest balls
+// make way for new ones if the world fills up regardless.
+func fountainScene() scene {
+	const (
+		height    = 200
+		speed     = 9
+		interval  = 8
+		bodyLimit = 50
+		sinkWidth = 160
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	left := newEmitter(physics.Vector{X: 2 * physics.BallRadius, Y: height}, physics.BallRadius/2, interval, 0)
+	left.aim(physics.Vector{X: 1, Y: -0.6}, speed)
+	left.material = physics.Steel
+	right := newEmitter(physics.Vector{X: physics.ScreenWidth - 2*physics.BallRadius, Y: height}, physics.BallRadius/2, interval, 0)
+	right.aim(physics.Vector{X: -1, Y: -0.6}, speed)
+	right.material = physics.Wood
+	// Stagger the streams so they don't fire in step
+	right.countdown = interval / 2
+	s.emitters = append(s.emitters, left, right)
+	s.drains = append(s.drains, newDrain("sink",
+		physics.Vector{X: (physics.ScreenWidth - sinkWidth) / 2, Y: physics.ScreenHeight - 2*physics.BallRadius},
+		physics.Vector{X: (physics.ScreenWidth + sinkWidth) / 2, Y: physics.ScreenHeight}))
+
+	s.options = append(s.options, physics.WithBodyLimit(bodyLimit), physics.WithWallRestitution(0.6))
+	return s
+}
+
+// portalsScene drops balls onto a portal in the floor, which throws them
+// back out of the left wall turned a quarter turn, so their fall becomes a
+// flight across the room.
+func portalsScene() scene {
+	const (
+		balls = 5
+		// restitution bleeds off the speed the portal's drop adds each
+		// time round
+		restitution = 0.8
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	for i := 0; i < balls; i++ {
+		s.objects = append(s.objects, physics.Body{Position: physics.Vector{X: 200 + 60*float64(i), Y: 60 + 30*float64(i)}})
+	}
+
+	floor := zone{min: physics.Vector{X: 260, Y: physics.ScreenHeight - 40}, max: physics.Vector{X: 380, Y: physics.ScreenHeight}}
+	wall := zone{min: physics.Vector{X: 0, Y: 140}, max: physics.Vector{X: 40, Y: 260}}
+	s.portals = append(s.portals, newPortal(floor, wall, -math.Pi/2))
+	s.options = append(s.options, physics.WithWallRestitution(restitution))
+	return s
+}
+
+// magnetsScene scatters magnetised balls, pointing every which way, around
+// a bar magnet. They swing round to line up with its field, are drawn in
+// to its poles and string together into chains.
+func magnetsScene() scene {
+	const (
+		balls         = 10
+		ring          = 180
+		barLength     = 120
+		barMoment     = 3000
+		ballMoment    = 300
+		goldenAngle   = 2.39996
+		restitution   = 0.5
+		magnetism     = 1.0
+		barHalfHeight = 6
+	)
+
+	var s scene
+	centre := physics.Vector{X: physics.ScreenWidth / 2, Y: physics.ScreenHeight / 2}
+	for i := 0; i < balls; i++ {
+		angle := 2 * math.Pi * float64(i) / balls
+		facing := goldenAngle * float64(i)
+		s.objects = append(s.objects, physics.Body{
+			Position: physics.Add(centre, physics.Vector{X: ring * math.Cos(angle), Y: ring * 0.8 * math.Sin(angle)}),
+			Moment:   physics.Vector{X: ballMoment * math.Cos(facing), Y: ballMoment * math.Sin(facing)},
+			Material: physics.Steel,
+		})
+	}
+
+	bar := physics.StaticMagnet{Position: centre, Moment: physics.Vector{X: barMoment}, Length: barLength}
+	// The bar is solid: balls stop against its sides and ends
+	left := physics.Vector{X: centre.X - barLength/2, Y: centre.Y}
+	right := physics.Vector{X: centre.X + barLength/2, Y: centre.Y}
+	s.options = append(s.options,
+		physics.WithMagnetism(magnetism, bar),
+		physics.WithStaticSegments(
+			physics.StaticSegment{A: physics.Add(left, physics.Vector{Y: -barHalfHeight}), B: physics.Add(right, physics.Vector{Y: -barHalfHeight})},
+			physics.StaticSegment{A: physics.Add(left, physics.Vector{Y: barHalfHeight}), B: physics.Add(right, physics.Vector{Y: barHalfHeight})},
+		),
+		physics.WithWallRestitution(restitution),
+	)
+	return s
+}
+
+// flockScene sets two flocks of boids flying round a world that wraps at
+// the edges, steering around each other and bouncing off a few pillars.
+func flockScene() scene {
+	const (
+		perFlock    = 16
+		goldenAngle = 2.39996
+		pillars     = 3
+	)
+
+	var s scene
+	for flock := 1; flock <= 2; flock++ {
+		for k := 0; k < perFlock; k++ {
+			heading := goldenAngle * float64(k)
+			s.objects = append(s.objects, physics.Body{
+				Position: physics.Vector{
+					X: float64(flock*physics.ScreenWidth/3) + 50*float64(k%4-2),
+					Y: physics.ScreenHeight/2 + 50*float64(k/4-2),
+				},
+				Velocity: physics.Vector{X: 2 * math.Cos(heading), Y: 2 * math.Sin(heading)},
+				Flock:    flock,
+			})
+			s.colors = append(s.colors, defaultPalette.series[flock-1])
+		}
+	}
+
+	var obstacles []physics.StaticCircle
+	for k := 0; k < pillars; k++ {
+		obstacles = append(obstacles, physics.StaticCircle{
+			Position: physics.Vector{X: float64(k+1) * physics.ScreenWidth / (pillars + 1), Y: physics.ScreenHeight / 4 * float64(1+k%2*2)},
+			Radius:   30,
+		})
+	}
+	s.options = append(s.options,
+		physics.WithTorusArena(),
+		physics.WithStaticCircles(obstacles...),
+		physics.WithFlocking(physics.FlockSettings{
+			Reach:      90,
+			Separation: 1.5,
+			Alignment:  1,
+			Cohesion:   1,
+			Cruise:     3,
+			MaxForce:   0.05,
+		}),
+	)
+	return s
+}
+
+// waterScene drops balls of different masses into a pool. The light ones
+// bob up and float high, a unit-mass one floats half under, and the heavy
+// ones sink to the bottom, all of them sending waves across the surface.
+func waterScene() scene {
+	const level = 300
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	masses := []float64{0.6, 1, 1.5, 3, 6}
+	for i, mass := range masses {
+		x := (float64(i) + 0.5) * physics.ScreenWidth / float64(len(masses))
+		s.objects = append(s.objects, physics.Body{
+			Position: physics.Vector{X: x, Y: 60 + 40*float64(i)},
+			Mass:     mass,
+		})
+		s.captions = append(s.captions, caption{position: physics.Vector{X: x, Y: 20}, text: fmt.Sprintf("m=%.1f", mass)})
+	}
+	s.options = append(s.options, physics.WithWater(0, physics.ScreenWidth, level), physics.WithWallRestitution(0.5))
+	return s
+}
+
+// accretionScene sets a slowly turning cloud of balls pulling on each
+// other. Balls that drift together gently merge, so over time the cloud
+// gathers into a few heavy bodies, while hard hits just bounce.
+func accretionScene() scene {
+	const (
+		rings   = 4
+		spacing = 2.6 * physics.BallRadius
+		spin    = 0.008
+	)
+
+	var s scene
+	centre := physics.Vector{X: physics.ScreenWidth / 2, Y: physics.ScreenHeight / 2}
+	for ring := 1; ring <= rings; ring++ {
+		radius := float64(ring) * spacing
+		count := 6 * ring
+		for k := 0; k < count; k++ {
+			angle := 2 * math.Pi * (float64(k) + 0.5*float64(ring)) / float64(count)
+			out := physics.Vector{X: math.Cos(angle), Y: math.Sin(angle)}
+			s.objects = append(s.objects, physics.Body{
+				Position: physics.Add(centre, physics.ScalarMult(out, radius)),
+				Velocity: physics.ScalarMult(physics.Vector{X: -out.Y, Y: out.X}, spin*radius),
+				Mass:     1,
+			})
+		}
+	}
+	s.options = append(s.options, physics.WithAttraction(3), physics.WithMerging(1.5), physics.WithWallRestitution(0.5))
+	return s
+}
+
+// mudScene drops a shower of clay and snow balls that stick where they land
+// and build up a heap, then sends a steel ball in to knock lumps off it.
+// The rubber balls among them bounce off without sticking to each other.
+func mudScene() scene {
+	const (
+		columns = 8
+		rows    = 5
+		spacing = 2.4 * physics.BallRadius
+	)
+	palette := [physics.Materials]color.RGBA{
+		physics.Rubber: {0xe0, 0x50, 0x50, 0xff},
+		physics.Steel:  {0xa0, 0xa8, 0xb0, 0xff},
+		physics.Clay:   {0x8a, 0x5a, 0x34, 0xff},
+		physics.Snow:   {0xf0, 0xf4, 0xff, 0xff},
+	}
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	left := physics.ScreenWidth/2 - (columns-1)*spacing/2
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			m := physics.Clay
+			switch (row*columns + column) % 5 {
+			case 1, 3:
+				m = physics.Snow
+			case 4:
+				m = physics.Rubber
+			}
+			s.objects = append(s.objects, physics.Body{
+				Position: physics.Vector{X: left + float64(column)*spacing, Y: 40 + float64(row)*spacing},
+				Material: m,
+			})
+			s.colors = append(s.colors, palette[m])
+		}
+	}
+	s.objects = append(s.objects, physics.Body{
+		Position: physics.Vector{X: physics.BallRadius, Y: physics.ScreenHeight - 3*physics.BallRadius},
+		Velocity: physics.Vector{X: 0.6, Y: -9},
+		Mass:     4,
+		Material: physics.Steel,
+	})
+	s.colors = append(s.colors, palette[physics.Steel])
+	s.options = append(s.options, physics.WithWallRestitution(0.6))
+	return s
+}
+
+// bandsScene scatters balls in empty space inside two stretched rubber
+// bands, which close in and squeeze each group into a tight bundle.
+func bandsScene() scene {
+	const (
+		radius = 110
+		slack  = 0.4
+	)
+
+	var s scene
+	for _, centre := range []physics.Vector{{X: physics.ScreenWidth / 4, Y: physics.ScreenHeight / 2}, {X: 3 * physics.ScreenWidth / 4, Y: physics.ScreenHeight / 2}} {
+		for k := 0; k < 7; k++ {
+			offset := physics.Vector{}
+			if k > 0 {
+				angle := 2 * math.Pi * float64(k) / 6
+				offset = physics.Vector{X: 60 * math.Cos(angle), Y: 60 * math.Sin(angle)}
+			}
+			s.objects = append(s.objects, physics.Body{Position: physics.Add(centre, offset)})
+		}
+		s.options = append(s.options, physics.WithBand(centre, radius, slack))
+	}
+	return s
+}
+
+// sparksScene sprays short-lived balls up from the floor without a body
+// limit. Each fades out and is gone two seconds after it is fired, which
+// keeps the number in play steady.
+func sparksScene() scene {
+	const (
+		speed    = 11
+		interval = 6
+		lifetime = 2 * physics.TicksPerSecond
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	for _, heading := range []physics.Vector{{X: -0.3, Y: -1}, {X: 0.3, Y: -1}} {
+		e := newEmitter(physics.Vector{X: physics.ScreenWidth / 2, Y: physics.ScreenHeight - physics.BallRadius}, physics.BallRadius, interval, 0)
+		e.aim(heading, speed)
+		e.material = physics.Steel
+		e.lifetime = lifetime
+		s.emitters = append(s.emitters, e)
+	}
+	s.emitters[1].countdown = interval / 2
+	return s
+}
+
+// mixingScene fills a box with a gas of red balls on the left and blue on
+// the right. Each collision blends the colours of the two balls, so purple
+// spreads out from the middle until the whole box is one shade.
+func mixingScene() scene {
+	const (
+		columns     = 10
+		rows        = 6
+		speed       = 3
+		goldenAngle = 2.39996
+	)
+	red := color.RGBA{0xe0, 0x30, 0x30, 0xff}
+	blue := color.RGBA{0x30, 0x50, 0xe0, 0xff}
+
+	var s scene
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			heading := goldenAngle * float64(row*columns+column)
+			c := red
+			if column >= columns/2 {
+				c = blue
+			}
+			s.objects = append(s.objects, physics.Body{
+				Position: physics.Vector{X: (float64(column) + 0.5) * physics.ScreenWidth / columns, Y: (float64(row) + 0.5) * physics.ScreenHeight / rows},
+				Velocity: physics.Vector{X: speed * math.Cos(heading), Y: speed * math.Sin(heading)},
+				Color:    c,
+			})
+		}
+	}
+	s.options = append(s.options, physics.WithColorMixing())
+	return s
+}
+
+// breakoutScene is a game of Breakout: steer the paddle with the mouse and
+// click to serve.
+func breakoutScene() scene {
+	var s scene
+	s.breakout = newBreakout()
+	s.objects = []physics.Body{{Position: s.breakout.serveFrom(), Material: physics.Steel}}
+	s.options = s.breakout.options()
+	return s
+}
+
+// carScene is a car to drive over hilly ground with the arrow keys: Right
+// and Left drive it, Down brakes.
+func carScene() scene {
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	ground := []physics.Vector{
+		{X: 0, Y: 400}, {X: 140, Y: 400}, {X: 240, Y: 370}, {X: 320, Y: 380}, {X: 440, Y: 350},
+		{X: 500, Y: 355}, {X: 590, Y: 385}, {X: physics.ScreenWidth, Y: 380},
+	}
+
+	var options []physics.WorldOption
+	s.car, s.objects, options = newCar(s.objects, physics.Vector{X: 40, Y: 400 - wheelRadius})
+	s.colors = s.car.colors()
+	// One chain, so the wheels roll over the joins without catching
+	s.options = append(options, physics.WithStaticChains(physics.StaticChain{Points: ground}), physics.WithWallRestitution(0.2))
+	return s
+}
+
+// hillsScene drops balls onto rolling hills, finely sampled ground they
+// roll down into the valleys of.
+func hillsScene() scene {
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	ground := physics.NewHeightfield(0, physics.ScreenWidth, 161, func(x float64) float64 {
+		return 340 + 50*math.Sin(x/70) + 20*math.Sin(x/23+1)
+	})
+	for k := 0; k < 24; k++ {
+		s.objects = append(s.objects, physics.Body{
+			Position: physics.Vector{X: 20 + float64(k)*25, Y: 60 + float64(k%3)*30},
+			Material: physics.Wood,
+		})
+		s.colors = append(s.colors, color.RGBA{0x60, 0xa0, 0x50, 0xff})
+	}
+	s.options = append(s.options, physics.WithHeightfields(ground), physics.WithWallRestitution(0.4))
+	return s
+}
+
+// polygonsScene rains balls onto concave solids drawn straight from their
+// outlines: a chevron of a track and a round-bottomed bowl.
+func polygonsScene() scene {
+	const (
+		rim   = 250
+		depth = 180
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	// The bowl's inside is half an ellipse, from one rim round to the other
+	bowl := []physics.Vector{{X: physics.ScreenWidth, Y: rim}, {X: physics.ScreenWidth, Y: physics.ScreenHeight}, {X: 0, Y: physics.ScreenHeight}, {X: 0, Y: rim}}
+	for k := 0; k <= 24; k++ {
+		a := math.Pi * float64(k) / 24
+		bowl = append(bowl, physics.Vector{X: physics.ScreenWidth/2 - 260*math.Cos(a), Y: rim + depth*math.Sin(a)})
+	}
+	chevron := []physics.Vector{{X: 360, Y: 90}, {X: 480, Y: 150}, {X: 600, Y: 110}, {X: 600, Y: 126}, {X: 480, Y: 170}, {X: 360, Y: 106}}
+
+	for k := 0; k < 30; k++ {
+		s.objects = append(s.objects, physics.Body{
+			Position: physics.Vector{X: 60 + float64(k%15)*38, Y: 30 + float64(k/15)*40},
+			Material: physics.Wood,
+		})
+		s.colors = append(s.colors, color.RGBA{0xe0, 0x90, 0x30, 0xff})
+	}
+	s.options = append(s.options,
+		physics.WithStaticPolygons(physics.NewStaticPolygon(bowl...), physics.NewStaticPolygon(chevron...)),
+		physics.WithWallRestitution(0.5),
+	)
+	return s
+}
+
+// rampsScene rolls balls down a zigzag of tilted planks and through a row
+// of pegs onto a floor.
+func rampsScene() scene {
+	const (
+		plank = 360
+		thick = 12
+		tilt  = 0.2
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	// Each plank tilts down towards the end the next one catches from
+	var ramps []physics.StaticBox
+	for k := 0; k < 3; k++ {
+		centre := physics.Vector{X: 230, Y: 100 + float64(k)*95}
+		angle := tilt
+		if k%2 == 1 {
+			centre.X, angle = physics.ScreenWidth-230, -tilt
+		}
+		ramps = append(ramps, physics.StaticBox{
+			Min:   physics.Subtract(centre, physics.Vector{X: plank / 2, Y: thick / 2}),
+			Max:   physics.Add(centre, physics.Vector{X: plank / 2, Y: thick / 2}),
+			Angle: angle,
+		})
+	}
+	var pegs []physics.StaticCircle
+	for x := 60.0; x < physics.ScreenWidth; x += 80 {
+		pegs = append(pegs, physics.StaticCircle{Position: physics.Vector{X: x, Y: 410}, Radius: 6})
+	}
+
+	for k := 0; k < 8; k++ {
+		s.objects = append(s.objects, physics.Body{
+			Position: physics.Vector{X: 80 + float64(k)*42, Y: 30},
+			Material: physics.Wood,
+		})
+		s.colors = append(s.colors, color.RGBA{0xc0, 0x80, 0x40, 0xff})
+	}
+	s.options = append(s.options, physics.WithStaticBoxes(ramps...), physics.WithStaticCircles(pegs...), physics.WithWallRestitution(0.5))
+	return s
+}
+
+// spinScene fires a ball off-centre into a rack of gripping balls with no
+// gravity, so every glancing blow and every scrape along a wall sets them
+// spinning, as the dot on each one's rim shows.
+func spinScene() scene {
+	const (
+		rows = 4
+		grip = 0.6
+	)
+
+	var s scene
+	for row := 0; row < rows; row++ {
+		for k := 0; k <= row; k++ {
+			s.objects = append(s.objects, physics.Body{
+				Position: physics.Vector{X: 380 + float64(row)*36, Y: 240 + float64(2*k-row)*21},
+				Grip:     grip,
+			})
+			s.colors = append(s.colors, color.RGBA{0x40, 0xa0, 0xd0, 0xff})
+		}
+	}
+	// The striker comes in below the rack's middle, to hit it a glancing
+	// blow
+	s.objects = append(s.objects, physics.Body{
+		Position: physics.Vector{X: 80, Y: 252},
+		Velocity: physics.Vector{X: 7},
+		Grip:     grip,
+	})
+	s.colors = append(s.colors, color.RGBA{0xe0, 0x50, 0x40, 0xff})
+	return s
+}
+
+// dragScene drops balls of different weights and drags through thick air
+// on a world that wraps round from the floor to the ceiling, so they fall
+// for good. Each soon stops speeding up at its own terminal speed, the
+// heavy ones falling fastest and the draggy ones slowest.
+func dragScene() scene {
+	const density = 0.01
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	s.options = append(s.options, physics.WithDrag(physics.QuadraticDrag, density), physics.WithTorusArena())
+	balls := []struct {
+		mass, drag float64
+		label      string
+	}{
+		{4, 0, "m=4"},
+		{1, 0, "m=1"},
+		{0.25, 0, "m=0.25"},
+		{1, 4, "drag=4"},
+		{1, 0.25, "drag=0.25"},
+	}
+	spacing := float64(physics.ScreenWidth) / float64(len(balls))
+	for k, b := range balls {
+		x := (float64(k) + 0.5) * spacing
+		s.objects = append(s.objects, physics.Body{Position: physics.Vector{X: x, Y: 60}, Mass: b.mass, Drag: b.drag})
+		s.captions = append(s.captions, caption{position: physics.Vector{X: x, Y: 20}, text: b.label})
+		s.trails = append(s.trails, k)
+	}
+	return s
+}
+
+// springsScene hangs balls off springs: a weight bobbing up and down on
+// one, a chain of them swung out to one side, and a double pendulum whose
+// lower arm is a spring, so it swings and stretches at once.
+func springsScene() scene {
+	const (
+		// stiffness is the chain's, stiff enough to hold its shape
+		stiffness = 0.3
+		damping   = 0.05
+		links     = 4
+		link      = 50
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	// The weight is hung at rest and then pulled down, to bob about where
+	// its weight stretches the spring to
+	top := physics.Vector{X: 100, Y: 40}
+	s.objects = append(s.objects, physics.Body{Position: physics.Vector{X: top.X, Y: 160}, Mass: 2})
+	s.constraints = append(s.constraints, physics.NewAnchoredSpring(s.objects, 0, top, 0.05, 0))
+	s.objects[0].Position.Y += 80
+	s.captions = append(s.captions, caption{position: physics.Vector{X: top.X, Y: 20}, text: "spring"})
+
+	// The chain starts out straight at 40 degrees to the left
+	pivot := physics.Vector{X: 330, Y: 40}
+	angle := -40 * math.Pi / 180
+	for k := 1; k <= links; k++ {
+		i := len(s.objects)
+		offset := physics.Vector{X: math.Sin(angle), Y: math.Cos(angle)}
+		s.objects = append(s.objects, physics.Body{Position: physics.Add(pivot, physics.ScalarMult(offset, float64(k*link)))})
+		if k == 1 {
+			s.constraints = append(s.constraints, physics.NewAnchoredSpring(s.objects, i, pivot, stiffness, damping))
+		} else {
+			s.constraints = append(s.constraints, physics.NewSpring(s.objects, i, i-1, stiffness, damping))
+		}
+	}
+	s.captions = append(s.captions, caption{position: physics.Vector{X: pivot.X, Y: 20}, text: "chain"})
+
+	// The double pendulum's upper arm is a rod, held out level
+	hinge := physics.Vector{X: 520, Y: 60}
+	upper := len(s.objects)
+	s.objects = append(s.objects,
+		physics.Body{Position: physics.Vector{X: hinge.X + 70, Y: hinge.Y}},
+		physics.Body{Position: physics.Vector{X: hinge.X + 70, Y: hinge.Y + 70}},
+	)
+	s.constraints = append(s.constraints,
+		physics.NewAnchoredRod(s.objects, upper, hinge),
+		physics.NewSpring(s.objects, upper+1, upper, 0.1, 0),
+	)
+	s.captions = append(s.captions, caption{position: physics.Vector{X: hinge.X, Y: 20}, text: "spring pendulum"})
+	return s
+}
+
+// ropeScene lays two ropes out level from their anchors, a stiff one and
+// a stretchy one, to swing down into a pile of balls on the floor and
+// drag them about.
+func ropeScene() scene {
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	s.options = append(s.options,
+		physics.WithRope(physics.Vector{X: 40, Y: 40}, physics.Vector{X: 440, Y: 40}, 40, 1),
+		physics.WithRope(physics.Vector{X: 600, Y: 40}, physics.Vector{X: 300, Y: 40}, 30, 0.05),
+	)
+	floor := float64(physics.ScreenHeight - physics.BallRadius)
+	rowHeight := math.Sqrt(3) * physics.BallRadius
+	for row := 0; row < 3; row++ {
+		for k := 0; k < 3-row; k++ {
+			x := 280 + float64(row+2*k)*physics.BallRadius
+			s.objects = append(s.objects, physics.Body{Position: physics.Vector{X: x, Y: floor - float64(row)*rowHeight}})
+		}
+	}
+	return s
+}
+
+// curtainScene hangs a cloth from pins along its top and throws heavy balls
+// into it from the sides and below, hard enough to tear holes in it.
+func curtainScene() scene {
+	const (
+		columns = 26
+		rows    = 18
+		spacing = 12
+		// pinEvery is how many points along the top edge each pin is
+		pinEvery = 5
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	curtain := physics.NewCloth(columns, rows, spacing).Place(physics.Vector{X: 170, Y: 40})
+	for column := 0; column < columns; column += pinEvery {
+		curtain.Pin(column, 0)
+	}
+	curtain.Pin(columns-1, 0)
+	s.options = append(s.options, physics.WithCloths(curtain))
+	s.objects = append(s.objects,
+		physics.Body{Position: physics.Vector{X: 40, Y: 150}, Velocity: physics.Vector{X: 14, Y: -2}, Mass: 30},
+		physics.Body{Position: physics.Vector{X: 600, Y: 200}, Velocity: physics.Vector{X: -18, Y: -3}, Mass: 30},
+		physics.Body{Position: physics.Vector{X: 320, Y: 440}, Velocity: physics.Vector{Y: -22}, Mass: 40},
+	)
+	return s
+}
+
+// fieldsScene sets balls orbiting a pair of stars, each a point source of
+// gravity, in a faint breeze blowing them to the right. A band along the
+// floor overrides the stars with ordinary downward gravity, and any ball
+// that wanders into it falls to the ground and stays there.
+func fieldsScene() scene {
+	const (
+		pull   = 600
+		core   = 30
+		ground = physics.ScreenHeight - 80
+	)
+
+	var s scene
+	stars := []physics.Vector{{X: 200, Y: 200}, {X: 440, Y: 200}}
+	s.options = append(s.options,
+		physics.WithWallRestitution(0.5),
+		physics.WithGravitySources(
+			physics.UniformGravity{Acceleration: physics.Vector{X: 0.002}},
+			physics.PointGravity{Centre: stars[0], Strength: pull, Radius: core},
+			physics.PointGravity{Centre: stars[1], Strength: pull, Radius: core},
+			physics.RegionGravity{Box: physics.StaticBox{Min: physics.Vector{Y: ground}, Max: physics.Vector{X: physics.ScreenWidth, Y: physics.ScreenHeight}}, Acceleration: physics.Vector{Y: .3}},
+		),
+	)
+	for k, star := range stars {
+		s.captions = append(s.captions, caption{position: star, text: "*"})
+		for n, radius := range []float64{70, 110} {
+			// Orbit the nearer star the opposite way round to the other
+			speed := math.Sqrt(pull / radius)
+			angle := float64(n)*math.Pi + float64(k)*math.Pi/2
+			direction := physics.Vector{X: math.Cos(angle), Y: math.Sin(angle)}
+			s.objects = append(s.objects, physics.Body{
+				Position: physics.Add(star, physics.ScalarMult(direction, radius)),
+				Velocity: physics.ScalarMult(physics.Vector{X: -direction.Y, Y: direction.X}, speed*float64(1-2*k)),
+				Radius:   10,
+			})
+		}
+	}
+	s.captions = append(s.captions, caption{position: physics.Vector{X: physics.ScreenWidth / 2, Y: ground + 10}, text: "ordinary gravity"})
+	return s
+}
+
+// golfScene is a round of mini-golf over the built-in courses: drag back
+// from the ball and release to putt it towards the hole.
+func golfScene() scene {
+	courses, err := loadCourses(courseFiles, "courses")
+	if err != nil {
+		panic(err)
+	}
+
+	var s scene
+	s.golf = newGolf(courses)
+	s.objects = []physics.Body{{Position: s.golf.course().tee(), Material: physics.Steel, Name: "ball"}}
+	s.colors = []color.RGBA{{0xff, 0xff, 0xff, 0xff}}
+	s.options = s.golf.options()
+	return s
+}
+
+// zonesScene splits the screen into three bays with their own gravity:
+// normal on the left, a quarter of it in the middle, and upside down on
+// the right under a weightless strip, where balls rise, bounce off the
+// ceiling and are sent back up each time they sink out of the strip, until
+// they hover in it.
+func zonesScene() scene {
+	const (
+		bay     = physics.ScreenWidth / 3
+		divider = 160
+		perBay  = 3
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	s.options = append(s.options,
+		physics.WithWallRestitution(0.6),
+		physics.WithStaticSegments(
+			physics.StaticSegment{A: physics.Vector{X: bay, Y: divider}, B: physics.Vector{X: bay, Y: physics.ScreenHeight}},
+			physics.StaticSegment{A: physics.Vector{X: 2 * bay, Y: divider}, B: physics.Vector{X: 2 * bay, Y: physics.ScreenHeight}},
+		),
+		physics.WithGravityZones(
+			physics.GravityZone{Box: physics.StaticBox{Min: physics.Vector{X: bay}, Max: physics.Vector{X: 2 * bay, Y: physics.ScreenHeight}}, Scale: 0.25},
+			physics.GravityZone{Box: physics.StaticBox{Min: physics.Vector{X: 2 * bay, Y: divider}, Max: physics.Vector{X: physics.ScreenWidth, Y: physics.ScreenHeight}}, Scale: -1},
+			physics.GravityZone{Box: physics.StaticBox{Min: physics.Vector{X: 2 * bay}, Max: physics.Vector{X: physics.ScreenWidth, Y: divider}}, Scale: 0},
+		),
+	)
+
+	for k := 0; k < perBay; k++ {
+		x := (float64(k) + 0.5) * (bay / perBay)
+		s.objects = append(s.objects,
+			physics.Body{Position: physics.Vector{X: x, Y: 2 * physics.BallRadius}},
+			physics.Body{Position: physics.Vector{X: bay + x, Y: 2 * physics.BallRadius}},
+			physics.Body{Position: physics.Vector{X: 2*bay + x, Y: physics.ScreenHeight - 2*physics.BallRadius}},
+		)
+	}
+	s.captions = append(s.captions,
+		caption{position: physics.Vector{X: bay / 2, Y: physics.ScreenHeight - 10}, text: "normal"},
+		caption{position: physics.Vector{X: 1.5 * bay, Y: physics.ScreenHeight - 10}, text: "low gravity"},
+		caption{position: physics.Vector{X: 2.5 * bay, Y: physics.ScreenHeight - 10}, text: "upside down"},
+		caption{position: physics.Vector{X: 2.5 * bay, Y: 10}, text: "weightless"},
+	)
+	return s
+}
+
+// projectileScene fires three cannonballs at the same speed and different
+// elevations through air that thins with height. Each one's trail shows
+// the path drag gives it, and a dotted line the path it would follow in a
+// vacuum.
+func projectileScene() scene {
+	const (
+		speed       = 12
+		density     = 0.0008
+		scaleHeight = 250
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	s.options = append(s.options, physics.WithAtmosphere(density, scaleHeight), physics.WithWallRestitution(0.3), physics.WithoutContacts())
+	muzzle := physics.Vector{X: 2 * physics.BallRadius, Y: physics.ScreenHeight - 2*physics.BallRadius}
+	for k, degrees := range []float64{30, 45, 60} {
+		elevation := degrees * math.Pi / 180
+		s.objects = append(s.objects, physics.Body{
+			Position: muzzle,
+			Velocity: physics.Vector{X: speed * math.Cos(elevation), Y: -speed * math.Sin(elevation)},
+			Material: physics.Steel,
+			Name:     fmt.Sprintf("%.0f deg", degrees),
+		})
+		s.trails = append(s.trails, k)
+		s.vacuum = append(s.vacuum, k)
+	}
+	return s
+}
+
+// sizesScene drops two rows of balls from pebbles to boulders, each as
+// heavy as its area, the rows thrown towards each other so the big ones
+// shoulder the small ones aside as they pile up on the floor.
+func sizesScene() scene {
+	const gap = 8
+	radii := []float64{8, 12, 16, 20, 28, 36, 48}
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+	for row := 0; row < 2; row++ {
+		x := float64(gap)
+		for _, r := range radii {
+			scale := r / physics.BallRadius
+			position := physics.Vector{X: x + r, Y: 60 + 140*float64(row)}
+			velocity := physics.Vector{X: 1.5}
+			if row == 1 {
+				position.X = physics.ScreenWidth - position.X
+				velocity.X = -velocity.X
+			}
+			s.objects = append(s.objects, physics.NewBall(position, velocity, scale*scale, r))
+			x += 2*r + gap
+		}
+	}
+	s.options = append(s.options, physics.WithWallRestitution(0.6))
+	return s
+}
+
+// weldsScene drops a girder of balls welded rigidly together, which lands
+// and rocks as one, and fires a heavy ball along the floor into a wall
+// welded weakly enough to shatter.
+func weldsScene() scene {
+	const (
+		spacing  = 2 * physics.BallRadius
+		tilt     = 0.3
+		strength = 3.5
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	// grid lays out a block of balls and welds each to the ones beside and
+	// below it
+	var welds []physics.Weld
+	grid := func(columns, rows int, at func(column, row int) physics.Vector, strength float64) {
+		first := len(s.objects)
+		for row := 0; row < rows; row++ {
+			for column := 0; column < columns; column++ {
+				s.objects = append(s.objects, physics.Body{Position: at(column, row)})
+				i := len(s.objects) - 1
+				if column > 0 {
+					welds = append(welds, physics.NewWeld(s.objects, i-1, i, strength))
+				}
+				if row > 0 {
+					welds = append(welds, physics.NewWeld(s.objects, i-columns, i, strength))
+				}
+			}
+		}
+		for i := first; i < len(s.objects); i++ {
+			s.colors = append(s.colors, color.RGBA{0x70, 0x90, 0xc0, 0xff})
+		}
+	}
+
+	centre := physics.Vector{X: 200, Y: 100}
+	grid(6, 2, func(column, row int) physics.Vector {
+		offset := physics.Vector{X: (float64(column) - 2.5) * spacing, Y: (float64(row) - 0.5) * spacing}
+		return physics.Add(centre, physics.RotateBy(offset, tilt))
+	}, 0)
+	grid(3, 5, func(column, row int) physics.Vector {
+		return physics.Vector{
+			X: physics.ScreenWidth - 120 + float64(column)*spacing,
+			Y: physics.ScreenHeight - physics.BallRadius - float64(row)*spacing,
+		}
+	}, strength)
+
+	s.objects = append(s.objects, physics.Body{
+		Position: physics.Vector{X: physics.BallRadius, Y: physics.ScreenHeight - physics.BallRadius},
+		Velocity: physics.Vector{X: 12},
+		Mass:     12,
+		Material: physics.Steel,
+	})
+	s.colors = append(s.colors, color.RGBA{0xa0, 0xa8, 0xb0, 0xff})
+	s.options = append(s.options, physics.WithWelds(welds...), physics.WithWallRestitution(0.5))
+	return s
+}
+
+// dominoesScene stands a row of dominoes, each a block of small balls
+// welded rigidly together so it rests on a flat base, and lobs a ball at
+// the first. Each topples into the next, tipping on the corner of its base
+// as friction keeps it from sliding. Seen side on, the row runs straight
+// rather than curving.
+func dominoesScene() scene {
+	const (
+		radius = 8
+		rows   = 6
+		count  = 8
+		gap    = 55
+		first  = 150
+	)
+
+	var s scene
+	s.gravity = physics.Vector{X: 0, Y: .3}
+
+	var welds []physics.Weld
+	for domino := range count {
+		s.objects, welds = appendDomino(s.objects, welds, first+float64(domino)*gap, physics.ScreenHeight, radius, rows)
+	}
+	for range s.objects {
+		s.colors = append(s.colors, dominoColor)
+	}
+
+	s.objects = append(s.objects, physics.Body{
+		Position: physics.Vector{X: 60, Y: physics.ScreenHeight - 2*rows*radius},
+		Velocity: physics.Vector{X: 4, Y: -1.5},
+		Mass:     2,
+	})
+	s.colors = append(s.colors, color.RGBA{0xc0, 0x40, 0x40, 0xff})
+	s.trails = []int{len(s.objects) - 1}
+	// A tall, narrow stack of welds sags on whole ticks until it creeps
+	// along the floor or tips over, so the dominoes are stepped in eighths
+	// to stand up straight
+	s.options = append(s.options, physics.WithWelds(welds...), physics.WithSubsteps(8))
+	return s
+}
+
+// dominoColumns is how many balls wide a domino is, and dominoColor the
+// colour its balls are drawn in.
+const dominoColumns = 2
+
+var dominoColor = color.RGBA{0xe8, 0xe0, 0xd0, 0xff}
+
+// appendDomino stands a domino rows balls tall on floor, its left column's
+// middles at x, by welding balls of the given radius into a block. It
+// returns objects and welds with the domino's added.
+func appendDomino(objects []physics.Body, welds []physics.Weld, x, floor, radius float64, rows int) ([]physics.Body, []physics.Weld) {
+	for row := range rows {
+		for column := range dominoColumns {
+			objects = append(objects, physics.Body{
+				Position:       physics.Vector{X: x + float64(column)*2*radius, Y: floor - radius - float64(row)*2*radius},
+				Radius:         radius,
+				Mass:           0.2,
+				Restitution:    0.1,
+				HasRestitution: true,
+				Grip:           0.8,
+				Material:       physics.Wood,
+			})
+			i := len(objects) - 1
+			if column > 0 {
+				welds = append(welds, physics.NewWeld(objects, i-1, i, 0))
+			}
+			if row > 0 {
+				welds = append(welds, physics.NewWeld(objects, i-dominoColumns, i, 0))
+			}
+		}
+	}
+	return objects, welds
+}
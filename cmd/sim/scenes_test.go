@@ -0,0 +1,474 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+const epsilon = 1e-9
+
+func TestPresetsBuild(t *testing.T) {
+	for _, name := range presetNames() {
+		t.Run(name, func(t *testing.T) {
+			w := presets[name]().build()
+			defer w.Close()
+			for i := 0; i < 10; i++ {
+				w.Step()
+			}
+		})
+	}
+}
+
+// TestPresetsValidate checks no built-in scene has a problem Validate
+// would report.
+func TestPresetsValidate(t *testing.T) {
+	for _, name := range presetNames() {
+		w := presets[name]().build()
+		for _, problem := range w.Validate() {
+			t.Errorf("%s: %v", name, problem)
+		}
+		w.Close()
+	}
+}
+
+// TestNewtonsCradleTransfersMomentum checks the struck ball hands its
+// swing over to the far ball instead of pushing the whole row.
+func TestNewtonsCradleTransfersMomentum(t *testing.T) {
+	s := newtonsCradleScene()
+	w := s.build()
+	defer w.Close()
+
+	first, last := 0, len(s.objects)-1
+	restFirst := s.constraints[first].Anchor.X
+	restLast := s.constraints[last].Anchor.X
+
+	impact := -1
+	farthestLast, farthestFirst := 0.0, 0.0
+	for step := 0; step < 200; step++ {
+		w.Step()
+		objects := w.Snapshot()
+
+		swingLast := objects[last].Position.X - restLast
+		if impact < 0 && swingLast > 1 {
+			impact = step
+		}
+		if impact >= 0 && step < impact+80 {
+			farthestLast = math.Max(farthestLast, swingLast)
+			farthestFirst = math.Max(farthestFirst, math.Abs(objects[first].Position.X-restFirst))
+		}
+	}
+
+	if impact < 0 {
+		t.Fatal("the far ball never moved")
+	}
+	if farthestLast < 100 {
+		t.Errorf("far ball swung out %.1f, want at least 100", farthestLast)
+	}
+	if farthestFirst > 10 {
+		t.Errorf("struck ball kept moving up to %.1f from rest, want under 10", farthestFirst)
+	}
+}
+
+// TestDoublePendulumPairDiverges checks the two pendulums, started a
+// thousandth of a radian apart, end up on visibly different paths.
+func TestDoublePendulumPairDiverges(t *testing.T) {
+	s := doublePendulumPairScene()
+	w := s.build()
+	defer w.Close()
+
+	// Compare each lower bob relative to its own anchor
+	offset := s.constraints[2].Anchor.X - s.constraints[0].Anchor.X
+	separation := func() float64 {
+		objects := w.Snapshot()
+		left, right := objects[s.trails[0]].Position, objects[s.trails[1]].Position
+		left.X += offset
+		gap := physics.Subtract(left, right)
+		return gap.Magnitude()
+	}
+
+	if start := separation(); start > 1 {
+		t.Fatalf("pendulums start %.2f apart, want under 1", start)
+	}
+	for step := 0; step < 1500; step++ {
+		w.Step()
+	}
+	if end := separation(); end < 20 {
+		t.Errorf("pendulums ended %.2f apart, want at least 20", end)
+	}
+}
+
+// TestRestitutionSceneBounces checks each ball in the restitution row
+// climbs back to about e² of its drop height after the first bounce.
+func TestRestitutionSceneBounces(t *testing.T) {
+	s := restitutionScene()
+	w := s.build()
+	defer w.Close()
+
+	floor := float64(physics.ScreenHeight - physics.BallRadius)
+	drop := floor - s.objects[0].Position.Y
+	bounced := make([]bool, len(s.objects))
+	apex := make([]float64, len(s.objects))
+	for i := range apex {
+		apex[i] = floor
+	}
+	for steps := 0; steps < 200; steps++ {
+		w.Step()
+		for i, ball := range w.Snapshot() {
+			if ball.Velocity.Y < 0 {
+				bounced[i] = true
+			}
+			if bounced[i] {
+				apex[i] = math.Min(apex[i], ball.Position.Y)
+			}
+		}
+	}
+
+	for i, ball := range s.objects {
+		e := ball.Restitution
+		want := drop * e * e
+		// The discrete bounce loses up to a step's fall either side
+		if got := floor - apex[i]; math.Abs(got-want) > drop*0.05 {
+			t.Errorf("e=%.1f climbed back %.1f, want %.1f", e, got, want)
+		}
+	}
+}
+
+// TestWreckingBallTopplesStack checks the stack stands still until the
+// wrecking ball reaches it and is knocked down afterwards.
+func TestWreckingBallTopplesStack(t *testing.T) {
+	s := wreckingBallScene()
+	w := s.build()
+	defer w.Close()
+
+	top := len(s.objects) - 1
+	drift := func() float64 {
+		offset := physics.Subtract(w.Snapshot()[top].Position, s.objects[top].Position)
+		return offset.Magnitude()
+	}
+
+	for steps := 0; steps < 60; steps++ {
+		w.Step()
+	}
+	if d := drift(); d > 1 {
+		t.Fatalf("top of the stack moved %.2f before the wrecking ball arrived", d)
+	}
+	for steps := 0; steps < 240; steps++ {
+		w.Step()
+	}
+	if d := drift(); d < 2*physics.BallRadius {
+		t.Errorf("top of the stack moved only %.2f after the hit", d)
+	}
+}
+
+// TestDominoesToppleInTurn checks the welded dominoes stand on their own
+// and, once the ball is lobbed, fall one after another down the row.
+func TestDominoesToppleInTurn(t *testing.T) {
+	// Each domino is two columns of six balls, and the ball comes last
+	const perDomino = 12
+	s := dominoesScene()
+	ball := len(s.objects) - 1
+	dominoes := ball / perDomino
+
+	still := s
+	still.objects = slices.Clone(s.objects)
+	still.objects[ball].Velocity = physics.Vector{}
+	w := still.build()
+	for steps := 0; steps < 300; steps++ {
+		w.Step()
+	}
+	for i, body := range w.Snapshot()[:ball] {
+		if math.Abs(body.Angle) > 0.05 || math.Abs(body.Position.X-s.objects[i].Position.X) > 1 {
+			t.Fatalf("unpushed, ball %d of the dominoes turned %.3f and moved to %v", i, body.Angle, body.Position)
+		}
+	}
+	w.Close()
+
+	w = s.build()
+	defer w.Close()
+	fell := make([]int, dominoes)
+	for steps := 1; steps <= 600; steps++ {
+		w.Step()
+		objects := w.Snapshot()
+		for d := range fell {
+			if fell[d] == 0 && objects[d*perDomino].Angle > 0.4 {
+				fell[d] = steps
+			}
+		}
+	}
+	for d := range fell {
+		if fell[d] == 0 {
+			t.Fatalf("domino %d of %d never fell", d+1, dominoes)
+		}
+		if d > 0 && fell[d] < fell[d-1] {
+			t.Errorf("domino %d fell at step %d, before domino %d at %d", d+1, fell[d], d, fell[d-1])
+		}
+	}
+}
+
+// TestContraptionDeliversBalls runs the contraption as a smoke test: the
+// emitted balls must find their way through the rig into the bins.
+func TestContraptionDeliversBalls(t *testing.T) {
+	s := contraptionScene()
+	w := s.build()
+	defer w.Close()
+
+	for steps := 0; steps < 1500; steps++ {
+		s.emitters[0].update(w)
+		w.Step()
+		s.bins.collect(w)
+	}
+	for i, ball := range w.Snapshot() {
+		if !isFinite(ball.Position) || !isFinite(ball.Velocity) {
+			t.Fatalf("body %d became NaN or infinite: %+v", i, ball)
+		}
+	}
+	if s.bins.total < 5 {
+		t.Errorf("%d balls reached the bins, want at least 5", s.bins.total)
+	}
+}
+
+func isFinite(v physics.Vector) bool {
+	return !math.IsNaN(v.X) && !math.IsNaN(v.Y) && !math.IsInf(v.X, 0) && !math.IsInf(v.Y, 0)
+}
+
+// TestContraptionUsesEveryPart checks the contraption is built from every
+// kind of obstacle and joint, so a change that breaks one of them shows up
+// in the one scene that runs them all together.
+func TestContraptionUsesEveryPart(t *testing.T) {
+	s := contraptionScene()
+	w := s.build()
+	defer w.Close()
+
+	var rods, anchoredRods, springs int
+	for _, c := range w.Constraints {
+		switch {
+		case c.Stiffness != 0:
+			springs++
+		case c.B == physics.NoBody:
+			anchoredRods++
+		default:
+			rods++
+		}
+	}
+	tilted := slices.ContainsFunc(w.StaticBoxes, func(b physics.StaticBox) bool { return b.Angle != 0 })
+
+	parts := []struct {
+		name string
+		used bool
+	}{
+		{"emitter", len(s.emitters) > 0},
+		{"ramp (tilted box)", tilted},
+		{"seesaw (anchored rods)", anchoredRods > 0},
+		{"pulley (wheel and rods)", len(w.StaticCircles) > 0 && rods > 0},
+		{"boxes", len(w.StaticBoxes) > 0},
+		{"polygons", len(w.StaticPolygons) > 0},
+		{"chains", len(w.StaticChains) > 0},
+		{"heightfields", len(w.Heightfields) > 0},
+		{"dominoes (welds)", len(w.Welds) > 0},
+		{"wheels", len(w.Wheels) > 0},
+		{"springs", springs > 0},
+	}
+	for _, part := range parts {
+		if !part.used {
+			t.Errorf("the contraption has no %s", part.name)
+		}
+	}
+}
+
+// TestRainRunsForever checks the rain keeps pouring for a long run without
+// the world ever holding more than its limit.
+func TestRainRunsForever(t *testing.T) {
+	s := rainScene()
+	w := s.build()
+	defer w.Close()
+
+	spawned := 0
+	for tick := 0; tick < 5000; tick++ {
+		e := s.emitters[0]
+		e.update(w)
+		// The countdown only restarts when a ball has been emitted
+		if e.countdown == e.interval-1 {
+			spawned++
+		}
+		w.Step()
+		if n := len(w.Snapshot()); n > w.BodyLimit {
+			t.Fatalf("tick %d: %d bodies, over the limit of %d", tick, n, w.BodyLimit)
+		}
+	}
+	if spawned < 2000 {
+		t.Errorf("only %d balls poured in 5000 ticks", spawned)
+	}
+}
+
+// TestArenaScenesHoldTheirBalls runs the round and star arenas and checks
+// no ball ever gets out.
+func TestArenaScenesHoldTheirBalls(t *testing.T) {
+	for _, name := range []string{"bowl", "star"} {
+		t.Run(name, func(t *testing.T) {
+			w := presets[name]().build()
+			defer w.Close()
+			for tick := 0; tick < 2000; tick++ {
+				w.Step()
+				for i, b := range w.Snapshot() {
+					inside := false
+					switch w.Arena.Shape {
+					case physics.CircleArena:
+						offset := physics.Subtract(b.Position, w.Arena.Centre)
+						inside = offset.Magnitude() <= w.Arena.Radius-physics.BallRadius+epsilon
+					case physics.PolygonArena:
+						inside = w.Arena.Contains(b.Position)
+					}
+					if !inside {
+						t.Fatalf("tick %d: ball %d escaped to %v", tick, i, b.Position)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestTorusConservesMomentum checks a gas with no walls to push on keeps
+// its momentum.
+func TestTorusConservesMomentum(t *testing.T) {
+	w := torusScene().build()
+	defer w.Close()
+
+	before := w.Momentum()
+	for tick := 0; tick < 1000; tick++ {
+		w.Step()
+	}
+	drift := physics.Subtract(w.Momentum(), before)
+	if drift.Magnitude() > 1e-9 {
+		t.Errorf("momentum drifted by %v", drift)
+	}
+}
+
+// TestProjectileFallsShortOfVacuum checks each cannonball in the
+// projectile scene lands short of where it would in a vacuum, and that the
+// vacuum path lands where the textbook range puts it.
+func TestProjectileFallsShortOfVacuum(t *testing.T) {
+	s := projectileScene()
+	w := s.build()
+	defer w.Close()
+
+	var vacuum [][]physics.Vector
+	for _, i := range s.vacuum {
+		vacuum = append(vacuum, w.VacuumPath(i, trailLength))
+	}
+	landed := make([]float64, len(s.objects))
+	for range trailLength {
+		w.Step()
+		for _, hit := range w.LastImpacts() {
+			if landed[hit.A] == 0 {
+				landed[hit.A] = w.Snapshot()[hit.A].Position.X
+			}
+		}
+	}
+
+	for k, i := range s.vacuum {
+		launch := s.objects[i]
+		v := launch.Velocity
+		drop := physics.ScreenHeight - physics.BallRadius - launch.Position.Y
+		// Time to come back down past the launch height to the floor
+		flight := (-v.Y + math.Sqrt(v.Y*v.Y+2*s.gravity.Y*drop)) / s.gravity.Y
+		textbook := launch.Position.X + v.X*flight
+
+		path := vacuum[k]
+		end := path[len(path)-1].X
+		if math.Abs(end-textbook) > v.X {
+			t.Errorf("%s: vacuum path lands at %.0f, want %.0f", launch.Name, end, textbook)
+		}
+		if landed[i] == 0 || landed[i] > end-50 {
+			t.Errorf("%s: landed at %.0f through the air, want well short of %.0f", launch.Name, landed[i], end)
+		}
+	}
+}
+
+// TestFlocksLineUp flies the flock scene with and without alignment and
+// checks the boids only come to head the same way with it. Polarisation,
+// the length of the mean heading, is 1 for a flock flying as one and near
+// 0 for one flying every which way.
+func TestFlocksLineUp(t *testing.T) {
+	polarisation := func(alignment float64) [2]float64 {
+		settings := physics.FlockSettings{Reach: 90, Separation: 1.5, Alignment: alignment, Cohesion: 1, Cruise: 3, MaxForce: 0.05}
+		w := flockScene().build(physics.WithFlocking(settings), physics.WithDeterminism())
+		defer w.Close()
+		for tick := 0; tick < 3000; tick++ {
+			w.Step()
+		}
+
+		var headings [2]physics.Vector
+		var counts [2]int
+		for _, b := range w.Snapshot() {
+			headings[b.Flock-1] = physics.Add(headings[b.Flock-1], physics.UnitVector(b.Velocity))
+			counts[b.Flock-1]++
+		}
+		var p [2]float64
+		for k := range p {
+			p[k] = headings[k].Magnitude() / float64(counts[k])
+		}
+		return p
+	}
+
+	aligned, unaligned := polarisation(1), polarisation(0)
+	for k := range aligned {
+		if aligned[k] < 0.7 || unaligned[k] > 0.5 {
+			t.Errorf("flock %d: polarisation %.2f aligned and %.2f not, want over 0.7 and under 0.5", k+1, aligned[k], unaligned[k])
+		}
+	}
+}
+
+// TestRewindResumes records a run, rewinds part of it and checks each
+// step back lands exactly on the state recorded then, that stepping on
+// from there repeats the original run, and that the ring only reaches
+// back as far as it holds.
+func TestRewindResumes(t *testing.T) {
+	const capacity = 50
+	w := defaultScene().build(physics.WithDeterminism())
+	defer w.Close()
+	h := physics.NewHistory(capacity)
+	h.Record(w)
+
+	sums := []string{w.Checksum()}
+	for i := 0; i < 2*capacity; i++ {
+		w.Step()
+		h.Record(w)
+		sums = append(sums, w.Checksum())
+	}
+
+	for back := 1; back <= capacity/2; back++ {
+		if !h.Rewind(w) {
+			t.Fatalf("could not rewind %d steps", back)
+		}
+		if want := sums[len(sums)-1-back]; w.Checksum() != want {
+			t.Fatalf("%d steps back at checksum %s, want %s", back, w.Checksum(), want)
+		}
+	}
+	if want := uint64(2*capacity - capacity/2); w.Steps != want {
+		t.Errorf("world at step %d after rewinding, want %d", w.Steps, want)
+	}
+
+	// Stepping on replays the same future
+	for i := 0; i < capacity/2; i++ {
+		w.Step()
+		h.Record(w)
+	}
+	if want := sums[len(sums)-1]; w.Checksum() != want {
+		t.Errorf("resumed at checksum %s, want %s", w.Checksum(), want)
+	}
+
+	// Only the last capacity checkpoints are kept
+	rewound := 0
+	for h.Rewind(w) {
+		rewound++
+	}
+	if rewound != capacity-1 {
+		t.Errorf("rewound %d steps, want %d", rewound, capacity-1)
+	}
+	if want := sums[len(sums)-capacity]; w.Checksum() != want {
+		t.Errorf("oldest checkpoint at checksum %s, want %s", w.Checksum(), want)
+	}
+}
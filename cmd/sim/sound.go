@@ -0,0 +1,163 @@
+package main
+
+import (
+	"cmp"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"slices"
+
+	"physicsSim/physics"
+)
+
+const (
+	sampleRate = 44100
+
+	// quietestImpact is the closing speed, in pixels per tick, below which
+	// a collision makes no sound. A ball resting on something closes on it
+	// at about one tick of gravity every step, well under this.
+	quietestImpact = 1.0
+	// loudestImpulse is the impulse that plays at full volume.
+	loudestImpulse = 12.0
+	// soundCooldown is how many ticks a body stays quiet after it makes a
+	// sound, so a ball rattling about in a pile doesn't buzz.
+	soundCooldown = 6
+	// maxSoundsPerTick caps how many impacts sound at once; the hardest
+	// ones win.
+	maxSoundsPerTick = 6
+	// hearingRadius is how far from the middle of the view, in pixels, an
+	// impact still plays at full volume. Beyond it sounds fade with
+	// distance.
+	hearingRadius = physics.ScreenWidth / 2
+)
+
+// soundSet is the voice of one material: a struck tone with overtones at
+// multiples of its frequency, dying away over decay seconds, and a share
+// of noise at the attack.
+type soundSet struct {
+	frequency float64
+	overtones []float64
+	decay     float64
+	noise     float64
+}
+
+// soundSets holds each material's voice.
+var soundSets = [...]soundSet{
+	physics.Rubber: {frequency: 140, overtones: []float64{2}, decay: 0.04, noise: 0.1},
+	physics.Steel:  {frequency: 1400, overtones: []float64{2.76, 5.4}, decay: 0.25, noise: 0.05},
+	physics.Wood:   {frequency: 480, overtones: []float64{2.3}, decay: 0.06, noise: 0.3},
+	physics.Clay:   {frequency: 90, decay: 0.03, noise: 0.5},
+	physics.Snow:   {frequency: 220, decay: 0.02, noise: 0.8},
+}
+
+// render synthesises one hit as mono samples, with every frequency
+// scaled by pitch and the whole sound by volume. Noise comes from seed, so
+// different seeds give slightly different hits.
+func (s soundSet) render(pitch, volume float64, seed int64) []float32 {
+	rng := rand.New(rand.NewSource(seed))
+	samples := make([]float32, int(5*s.decay*sampleRate))
+	for i := range samples {
+		t := float64(i) / sampleRate
+		envelope := math.Exp(-t / s.decay)
+
+		tone := math.Sin(2 * math.Pi * s.frequency * pitch * t)
+		for k, overtone := range s.overtones {
+			// Overtones are quieter and fade faster than the fundamental
+			weight := 1 / float64(k+2)
+			tone += weight * math.Exp(-t*overtone/s.decay) * math.Sin(2*math.Pi*s.frequency*overtone*pitch*t)
+		}
+		// The noise is a click at the attack, gone in a tenth of the decay
+		click := s.noise * math.Exp(-10*t/s.decay) * (rng.Float64()*2 - 1)
+		samples[i] = float32(volume * envelope * (0.5*tone + click))
+	}
+	return samples
+}
+
+// loudness maps an impulse to a volume between 0 and 1.
+func loudness(impulse float64) float64 {
+	return math.Min(1, impulse/loudestImpulse)
+}
+
+// spatialize returns the level in each ear for a sound at position seen
+// through v. The sound pans with its place across the view, hard over once
+// it is off either side, and fades in inverse proportion to its distance
+// from the middle of the view past hearingRadius.
+func spatialize(position physics.Vector, v *viewport) (float64, float64) {
+	width, _ := v.span()
+	offset := physics.Subtract(position, v.centre())
+
+	// Equal-power panning keeps the loudness steady across the view
+	pan := math.Max(-1, math.Min(1, offset.X/(width/2)))
+	angle := (pan + 1) * math.Pi / 4
+	gain := math.Min(1, hearingRadius/offset.Magnitude())
+	return gain * math.Cos(angle), gain * math.Sin(angle)
+}
+
+// stereo lays mono samples out as the interleaved 32-bit float little
+// endian stereo Ebiten plays, at the given level in each ear.
+func stereo(samples []float32, left, right float64) []byte {
+	out := make([]byte, 0, len(samples)*8)
+	for _, sample := range samples {
+		out = binary.LittleEndian.AppendUint32(out, math.Float32bits(sample*float32(left)))
+		out = binary.LittleEndian.AppendUint32(out, math.Float32bits(sample*float32(right)))
+	}
+	return out
+}
+
+// impactSounds decides which collisions are worth hearing. Impacts from
+// every step in a tick are gathered with hear and thinned out by next.
+type impactSounds struct {
+	tick    int
+	pending []physics.Impact
+	// quietUntil is the tick each body may next make a sound
+	quietUntil []int
+}
+
+// hear queues the impacts of a step, dropping the ones too gentle to make
+// a sound.
+func (s *impactSounds) hear(impacts []physics.Impact) {
+	for _, hit := range impacts {
+		if hit.Speed >= quietestImpact {
+			s.pending = append(s.pending, hit)
+		}
+	}
+}
+
+// next ends the tick and returns the impacts to play for it: the hardest
+// few, skipping any body that has sounded within the cooldown.
+func (s *impactSounds) next() []physics.Impact {
+	slices.SortStableFunc(s.pending, func(p, q physics.Impact) int {
+		return cmp.Compare(q.Impulse, p.Impulse)
+	})
+
+	var chosen []physics.Impact
+	for _, hit := range s.pending {
+		if len(chosen) == maxSoundsPerTick {
+			break
+		}
+		if s.cooling(hit.A) || s.cooling(hit.B) {
+			continue
+		}
+		s.silence(hit.A)
+		s.silence(hit.B)
+		chosen = append(chosen, hit)
+	}
+
+	s.pending = s.pending[:0]
+	s.tick++
+	return chosen
+}
+
+func (s *impactSounds) cooling(body int) bool {
+	return body != physics.NoBody && body < len(s.quietUntil) && s.tick < s.quietUntil[body]
+}
+
+func (s *impactSounds) silence(body int) {
+	if body == physics.NoBody {
+		return
+	}
+	for len(s.quietUntil) <= body {
+		s.quietUntil = append(s.quietUntil, 0)
+	}
+	s.quietUntil[body] = s.tick + soundCooldown
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestHeadOnImpactIsRecorded checks a collision between two balls is
+// reported with its closing speed and the impulse it exchanged.
+func TestHeadOnImpactIsRecorded(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{
+		{Position: physics.Vector{X: 200, Y: 200}, Velocity: physics.Vector{X: 2}},
+		{Position: physics.Vector{X: 241, Y: 200}, Velocity: physics.Vector{X: -2}},
+	}, physics.Vector{})
+	defer w.Close()
+
+	w.Step()
+	impacts := w.LastImpacts()
+	if len(impacts) != 1 {
+		t.Fatalf("%d impacts, want 1", len(impacts))
+	}
+	// Equal unit masses closing at 4 swap velocities, exchanging 4
+	if hit := impacts[0]; math.Abs(hit.Speed-4) > epsilon || math.Abs(hit.Impulse-4) > epsilon {
+		t.Errorf("impact speed %v impulse %v, want 4 and 4", hit.Speed, hit.Impulse)
+	}
+}
+
+// TestRestingBallIsQuiet checks a ball sitting on the floor, which meets
+// it again every step, never makes a sound.
+func TestRestingBallIsQuiet(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{{Position: physics.Vector{X: 320, Y: physics.ScreenHeight - physics.BallRadius}}}, physics.Vector{Y: .3})
+	defer w.Close()
+
+	var s impactSounds
+	for ticks := 0; ticks < 300; ticks++ {
+		w.Step()
+		s.hear(w.LastImpacts())
+		if played := s.next(); len(played) > 0 {
+			t.Fatalf("tick %d: resting ball played %+v", ticks, played)
+		}
+	}
+}
+
+// TestSoundCooldown checks a body that has just sounded stays quiet for
+// the cooldown, then may sound again.
+func TestSoundCooldown(t *testing.T) {
+	hit := physics.Impact{A: 3, B: physics.NoBody, Speed: 5, Impulse: 5}
+	var s impactSounds
+	for ticks := 0; ticks <= soundCooldown; ticks++ {
+		s.hear([]physics.Impact{hit})
+		played := len(s.next())
+		want := 0
+		if ticks == 0 || ticks == soundCooldown {
+			want = 1
+		}
+		if played != want {
+			t.Errorf("tick %d: played %d sounds, want %d", ticks, played, want)
+		}
+	}
+}
+
+func TestSpatialize(t *testing.T) {
+	v := newViewport(physics.Vector{}, physics.ScreenWidth, physics.ScreenHeight)
+	v.camera.position = physics.Vector{X: 1000, Y: 0}
+	centre := physics.Vector{X: 1000 + physics.ScreenWidth/2, Y: physics.ScreenHeight / 2}
+	tests := []struct {
+		name        string
+		position    physics.Vector
+		left, right float64
+	}{
+		{"centre", centre, math.Sqrt2 / 2, math.Sqrt2 / 2},
+		{"right edge", physics.Add(centre, physics.Vector{X: physics.ScreenWidth / 2}), 0, 1},
+		{"left edge", physics.Subtract(centre, physics.Vector{X: physics.ScreenWidth / 2}), 1, 0},
+		{"far right", physics.Add(centre, physics.Vector{X: 2 * hearingRadius}), 0, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, right := spatialize(tt.position, v)
+			if math.Abs(left-tt.left) > epsilon || math.Abs(right-tt.right) > epsilon {
+				t.Errorf("levels = %.3f, %.3f, want %.3f, %.3f", left, right, tt.left, tt.right)
+			}
+		})
+	}
+}
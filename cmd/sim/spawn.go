@@ -0,0 +1,116 @@
+package main
+
+import (
+	"time"
+
+	"physicsSim/physics"
+)
+
+// throwWindow is how far back the cursor's movement is averaged over to
+// throw a ball with on release, and throwScale how fast the ball leaves
+// for every pixel per tick the cursor was moving.
+const (
+	throwWindow = 100 * time.Millisecond
+	throwScale  = 1
+)
+
+// spawner drops new balls where the player clicks, and picks up ones
+// already there to drag and throw. While it is on, a ghost of the next
+// ball follows the cursor, and a click only spawns it if it wouldn't land
+// on top of a body already there.
+type spawner struct {
+	active   bool
+	ghost    physics.Vector
+	blocked  bool
+	overlaps []int
+
+	// holding is set while body held is being dragged, its middle offset
+	// from the cursor, and moves are where the cursor has been lately.
+	// bodies is how many the world had when it was picked up
+	holding bool
+	held    int
+	bodies  int
+	offset  physics.Vector
+	moves   []cursorMove
+}
+
+// cursorMove is where the cursor was at a moment, to throw with.
+type cursorMove struct {
+	at   physics.Vector
+	when time.Time
+}
+
+// aim moves the ghost to at and checks whether it overlaps anything.
+func (s *spawner) aim(w *physics.World, at physics.Vector) {
+	s.ghost = at
+	s.overlaps = w.Overlapping(at, physics.BallRadius, s.overlaps[:0])
+	s.blocked = len(s.overlaps) > 0
+}
+
+// click spawns a ball at rest at the ghost unless it is blocked.
+func (s *spawner) click(w *physics.World) {
+	if !s.active || s.blocked {
+		return
+	}
+	w.Spawn(physics.Body{Position: s.ghost})
+	s.aim(w, s.ghost)
+}
+
+// press picks up the body under the screen point x, y in view to drag, or
+// clicks if there is none.
+func (s *spawner) press(w *physics.World, x, y float64, view physics.ScreenTransform, now time.Time) {
+	i, ok := w.BodyAtScreenPoint(x, y, view)
+	at := view.ScreenToWorld(physics.Vector{X: x, Y: y})
+	if !s.active || !ok {
+		s.click(w)
+		return
+	}
+	s.holding, s.held, s.bodies = true, i, len(w.Snapshot())
+	s.offset = physics.Subtract(w.Snapshot()[i].Position, at)
+	s.moves = append(s.moves[:0], cursorMove{at: at, when: now})
+}
+
+// drag carries the held body after the cursor, moving at the cursor's
+// speed, so it knocks aside what it is dragged into.
+func (s *spawner) drag(w *physics.World, at physics.Vector, now time.Time) {
+	if !s.holding || s.dropped(w) {
+		return
+	}
+	s.moves = append(s.moves, cursorMove{at: at, when: now})
+	for len(s.moves) > 2 && now.Sub(s.moves[1].when) >= throwWindow {
+		s.moves = s.moves[1:]
+	}
+	w.Teleport(s.held, physics.Add(at, s.offset), s.velocity())
+}
+
+// release lets go of the held body, throwing it on at the speed the cursor
+// was moving.
+func (s *spawner) release(w *physics.World) {
+	if !s.holding || s.dropped(w) {
+		return
+	}
+	w.Strike(s.held, physics.ScalarMult(s.velocity(), throwScale), physics.Vector{})
+	s.holding = false
+}
+
+// dropped lets go of the held body if bodies have been added or removed
+// since it was picked up, since removing one renumbers those after it and
+// held could now be another body or none, and reports whether it did.
+func (s *spawner) dropped(w *physics.World) bool {
+	if len(w.Snapshot()) == s.bodies {
+		return false
+	}
+	s.holding = false
+	return true
+}
+
+// velocity returns how fast the cursor has moved lately, in pixels per
+// tick.
+func (s *spawner) velocity() physics.Vector {
+	first, last := s.moves[0], s.moves[len(s.moves)-1]
+	ticks := float64(last.when.Sub(first.when)) / float64(tick)
+	if ticks <= 0 {
+		return physics.Vector{}
+	}
+	return physics.ScalarMult(physics.Subtract(last.at, first.at), 1/ticks)
+}
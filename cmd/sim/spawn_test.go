@@ -0,0 +1,91 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"physicsSim/physics"
+)
+
+// TestSpawnerRefusesOverlap checks the spawner is blocked over an existing
+// body, drops a ball in clear space, and is then blocked by that ball.
+func TestSpawnerRefusesOverlap(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{{Position: physics.Vector{X: 100, Y: 100}}}, physics.Vector{})
+	defer w.Close()
+	w.Step()
+
+	s := spawner{active: true}
+	s.aim(w, physics.Vector{X: 130, Y: 100})
+	if !s.blocked || len(s.overlaps) != 1 || s.overlaps[0] != 0 {
+		t.Fatalf("ghost on body 0 overlaps %v, want [0]", s.overlaps)
+	}
+	s.click(w)
+	if got := len(w.Snapshot()); got != 1 {
+		t.Fatalf("blocked click left %d bodies, want 1", got)
+	}
+
+	s.aim(w, physics.Vector{X: 141, Y: 100})
+	s.click(w)
+	if got := len(w.Snapshot()); got != 2 {
+		t.Fatalf("clear click left %d bodies, want 2", got)
+	}
+	w.Step()
+	if s.aim(w, physics.Vector{X: 150, Y: 120}); !s.blocked {
+		t.Error("ghost over the new ball isn't blocked")
+	}
+}
+
+// TestSpawnerDragsAndThrows presses on a ball, drags it along and lets go,
+// checking it follows the cursor where it was picked up and flies off at
+// the cursor's speed.
+func TestSpawnerDragsAndThrows(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{{Position: physics.Vector{X: 100, Y: 100}}}, physics.Vector{})
+	defer w.Close()
+	w.Step()
+
+	s := spawner{active: true}
+	view := newViewport(physics.Vector{}, physics.ScreenWidth, physics.ScreenHeight)
+	start := time.Unix(0, 0)
+	s.press(w, 105, 100, view, start)
+	if !s.holding || s.held != 0 {
+		t.Fatalf("pressing on the ball holds %v, %d, want it holding 0", s.holding, s.held)
+	}
+	for k := 1; k <= 6; k++ {
+		s.drag(w, physics.Vector{X: 105 + 4*float64(k), Y: 100}, start.Add(time.Duration(k)*tick))
+	}
+	if p := w.Snapshot()[0].Position; math.Abs(p.X-124) > epsilon || math.Abs(p.Y-100) > epsilon {
+		t.Errorf("dragged ball at %v, want it kept 5 behind the cursor at 124", p)
+	}
+	s.release(w)
+	if v := w.Snapshot()[0].Velocity; s.holding || math.Abs(v.X-4*throwScale) > epsilon || v.Y != 0 {
+		t.Errorf("thrown ball moving at %v, want %v across", v, 4*throwScale)
+	}
+
+	s.press(w, 300, 300, view, start)
+	if got := len(w.Snapshot()); s.holding || got != 2 {
+		t.Errorf("pressing on clear space left %d bodies, want a ball spawned there", got)
+	}
+}
+
+// TestSpawnerDropsRenumberedBody picks up the first of two balls, removes
+// it so the other takes its number, and checks dragging neither moves the
+// ball that did nor keeps hold of anything.
+func TestSpawnerDropsRenumberedBody(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{{Position: physics.Vector{X: 100, Y: 100}}, {Position: physics.Vector{X: 300, Y: 100}}}, physics.Vector{})
+	defer w.Close()
+	w.Step()
+
+	s := spawner{active: true}
+	view := newViewport(physics.Vector{}, physics.ScreenWidth, physics.ScreenHeight)
+	start := time.Unix(0, 0)
+	s.press(w, 100, 100, view, start)
+	if !s.holding || s.held != 0 {
+		t.Fatalf("pressing on the first ball holds %v, %d, want it holding 0", s.holding, s.held)
+	}
+	w.Remove(0)
+	s.drag(w, physics.Vector{X: 150, Y: 200}, start.Add(tick))
+	if p := w.Snapshot()[0].Position; s.holding || p != (physics.Vector{X: 300, Y: 100}) {
+		t.Errorf("after the held ball went, holding %v with the ball now body 0 at %v, want it let go and left where it was", s.holding, p)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"physicsSim/physics"
+)
+
+// stepWindow is how many of the latest steps the step time percentiles
+// are taken over.
+const stepWindow = 5 * physics.TicksPerSecond
+
+// stepTimes keeps how long each of the latest steps took. The frame rate
+// and average timings hide the odd slow step felt as a stutter, so the
+// HUD reads the median and the 95th and 99th percentiles instead.
+type stepTimes struct {
+	samples [stepWindow]time.Duration
+	// next is where the next sample goes, and count how many are kept
+	next, count int
+	sorted      []time.Duration
+}
+
+// add keeps d as the latest step's time, in place of the oldest once the
+// window is full.
+func (s *stepTimes) add(d time.Duration) {
+	s.samples[s.next] = d
+	s.next = (s.next + 1) % stepWindow
+	s.count = min(s.count+1, stepWindow)
+}
+
+// percentile returns the step time at or under which a share p of the
+// kept steps came, by nearest rank, or zero if none have been kept.
+func (s *stepTimes) percentile(p float64) time.Duration {
+	if s.count == 0 {
+		return 0
+	}
+	s.sorted = append(s.sorted[:0], s.samples[:s.count]...)
+	slices.Sort(s.sorted)
+	rank := int(math.Ceil(p*float64(s.count))) - 1
+	return s.sorted[max(rank, 0)]
+}
+
+// reading is the HUD line of the step time percentiles.
+func (s *stepTimes) reading() string {
+	return text("hud.steps", milliseconds(s.percentile(0.5)), milliseconds(s.percentile(0.95)), milliseconds(s.percentile(0.99)))
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestStepTimePercentiles fills the window with steady steps and one slow
+// one in fifty, checks the percentiles pick it out, and then that it
+// drops out of them once the window has moved past it.
+func TestStepTimePercentiles(t *testing.T) {
+	var s stepTimes
+	if got := s.percentile(0.99); got != 0 {
+		t.Errorf("p99 of no steps = %v, want 0", got)
+	}
+
+	for k := range stepWindow {
+		d := time.Millisecond
+		if k%50 == 49 {
+			d = 20 * time.Millisecond
+		}
+		s.add(d)
+	}
+	for _, tt := range []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0.5, time.Millisecond},
+		{0.95, time.Millisecond},
+		{0.99, 20 * time.Millisecond},
+	} {
+		if got := s.percentile(tt.p); got != tt.want {
+			t.Errorf("p%.0f = %v, want %v", tt.p*100, got, tt.want)
+		}
+	}
+
+	for range stepWindow {
+		s.add(2 * time.Millisecond)
+	}
+	if got := s.percentile(0.99); got != 2*time.Millisecond {
+		t.Errorf("p99 after a window of steady steps = %v, want 2ms", got)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"physicsSim/physics"
+)
+
+// maxSweepRuns is the most values one sweep steps through.
+const maxSweepRuns = 1000
+
+// sweepParameters are what a sweep can vary, by the name -sweep takes.
+// Each sets the parameter to value in a copy of the scene its own.
+var sweepParameters = map[string]func(s *scene, value float64){
+	"restitution": func(s *scene, value float64) {
+		for i := range s.objects {
+			s.objects[i].Restitution, s.objects[i].HasRestitution = value, true
+		}
+	},
+	"grip": func(s *scene, value float64) {
+		for i := range s.objects {
+			s.objects[i].Grip = value
+		}
+	},
+	"gravity": func(s *scene, value float64) {
+		s.gravity = physics.Vector{Y: value}
+	},
+	"wall-restitution": func(s *scene, value float64) {
+		s.options = append(s.options, physics.WithWallRestitution(value))
+	},
+}
+
+// sweepParameterNames returns the names of the parameters a sweep can
+// vary, sorted.
+func sweepParameterNames() []string {
+	return slices.Sorted(maps.Keys(sweepParameters))
+}
+
+// sweep runs a scene over and over, one parameter set to the next of its
+// values each time, for comparing how the scene plays out across them.
+// After the last value it starts again from the first.
+type sweep struct {
+	parameter string
+	values    []float64
+	run       int
+	// steps is how long each run lasts
+	steps uint64
+	// newScene builds the scene each run starts from, and options are the
+	// further options its world is built with
+	newScene func() scene
+	options  []physics.WorldOption
+}
+
+// parseSweep reads a sweep of the form parameter=from:to:step, such as
+// restitution=0:1:0.1, running each value for steps ticks of newScene.
+func parseSweep(spec string, steps uint64, newScene func() scene) (*sweep, error) {
+	parameter, span, _ := strings.Cut(spec, "=")
+	bounds := strings.Split(span, ":")
+	if len(bounds) != 3 {
+		return nil, fmt.Errorf("bad sweep %q, want parameter=from:to:step such as restitution=0:1:0.1", spec)
+	}
+	if _, known := sweepParameters[parameter]; !known {
+		return nil, fmt.Errorf("unknown sweep parameter %q, choose one of: %s", parameter, strings.Join(sweepParameterNames(), ", "))
+	}
+	var from, to, step float64
+	for k, bound := range []*float64{&from, &to, &step} {
+		var err error
+		if *bound, err = strconv.ParseFloat(bounds[k], 64); err != nil {
+			return nil, fmt.Errorf("bad sweep %q: %w", spec, err)
+		}
+	}
+	if step <= 0 || to < from {
+		return nil, fmt.Errorf("bad sweep %q: the step must be above zero and run from the lower value up", spec)
+	}
+	// A hair over the last step still counts, so 0:1:0.1 ends on 1
+	runs := int(math.Floor((to-from)/step+1e-9)) + 1
+	if runs > maxSweepRuns {
+		return nil, fmt.Errorf("bad sweep %q: %d runs, at most %d", spec, runs, maxSweepRuns)
+	}
+
+	s := &sweep{parameter: parameter, steps: steps, newScene: newScene}
+	for k := range runs {
+		// Rounded, so the labels read 0.3 rather than 0.30000000000000004
+		s.values = append(s.values, math.Round((from+float64(k)*step)*1e9)/1e9)
+	}
+	return s, nil
+}
+
+// scene returns a fresh copy of the scene with the parameter set for the
+// current run.
+func (s *sweep) scene() scene {
+	sc := s.newScene()
+	sc.objects = slices.Clone(sc.objects)
+	sc.options = slices.Clip(sc.options)
+	sweepParameters[s.parameter](&sc, s.values[s.run])
+	return sc
+}
+
+// next moves on to the next run, back to the first after the last.
+func (s *sweep) next() {
+	s.run = (s.run + 1) % len(s.values)
+}
+
+// over reports whether the current run has lasted as long as it should.
+func (s *sweep) over(w *physics.World) bool {
+	return w.Steps >= s.steps
+}
+
+// reading says which run of the sweep is showing and how long is left of
+// it.
+func (s *sweep) reading(w *physics.World) string {
+	value := strconv.FormatFloat(s.values[s.run], 'f', -1, 64)
+	left := float64(s.steps-min(w.Steps, s.steps)) / physics.TicksPerSecond
+	return text("sweep.run", s.parameter, value, s.run+1, len(s.values), left)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+func TestParseSweep(t *testing.T) {
+	tests := []struct {
+		spec string
+		want []float64
+	}{
+		{"restitution=0:1:0.1", []float64{0, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1}},
+		{"gravity=0.1:0.5:0.2", []float64{0.1, 0.3, 0.5}},
+		{"grip=0.5:0.5:1", []float64{0.5}},
+	}
+	for _, tt := range tests {
+		s, err := parseSweep(tt.spec, 60, defaultScene)
+		if err != nil {
+			t.Errorf("%s: %v", tt.spec, err)
+			continue
+		}
+		if !slices.Equal(s.values, tt.want) {
+			t.Errorf("%s sweeps %v, want %v", tt.spec, s.values, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"restitution", "restitution=0:1", "bounce=0:1:0.1", "restitution=1:0:0.1", "restitution=0:1:0", "restitution=0:1:x", "gravity=0:1:0.0001"} {
+		if _, err := parseSweep(spec, 60, defaultScene); err == nil {
+			t.Errorf("%s parsed, want an error", spec)
+		}
+	}
+}
+
+// TestSweepRunsEachValue steps through a restitution sweep of a scene that
+// is built once, as a scene file's is, and checks each run's scene has
+// its own value without touching the one it was copied from.
+func TestSweepRunsEachValue(t *testing.T) {
+	base := scene{objects: []physics.Body{{Position: physics.Vector{X: 100, Y: 100}}, {Position: physics.Vector{X: 200, Y: 100}}}}
+	s, err := parseSweep("restitution=0:1:0.5", 60, func() scene { return base })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []float64{0, 0.5, 1, 0} {
+		sc := s.scene()
+		for i, b := range sc.objects {
+			if got := b.Restitution; !b.HasRestitution || got != want {
+				t.Errorf("run %d: ball %d has restitution %v, want %v", s.run, i, got, want)
+			}
+		}
+		s.next()
+	}
+	if base.objects[0].HasRestitution {
+		t.Errorf("sweep changed the scene it copies to restitution %v", base.objects[0].Restitution)
+	}
+
+	w := s.scene().build()
+	defer w.Close()
+	for range 59 {
+		w.Step()
+	}
+	if s.over(w) {
+		t.Errorf("run over after %d steps, want it to last 60", w.Steps)
+	}
+	w.Step()
+	if !s.over(w) {
+		t.Errorf("run not over after %d steps, want it over at 60", w.Steps)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import "physicsSim/physics"
+
+// telemetry is the diagnostics panel printed under the HUD's timings: the
+// world's energy, momentum, collisions and bodies each frame, for checking
+// that a change to the physics still conserves what it should. It keeps
+// the energy and momentum the world had when it was shown, to read how far
+// they have drifted since.
+type telemetry struct {
+	shown    bool
+	energy   float64
+	momentum physics.Vector
+}
+
+// toggle shows the panel, measuring drift from s, or hides it.
+func (t *telemetry) toggle(s physics.Stats) {
+	t.shown = !t.shown
+	t.energy, t.momentum = s.Energy, s.Momentum
+}
+
+// lines returns the panel's lines for s, or none while it is hidden.
+func (t *telemetry) lines(s physics.Stats) []string {
+	if !t.shown {
+		return nil
+	}
+	drift := physics.Subtract(s.Momentum, t.momentum)
+	return []string{
+		text("telemetry.energy", s.Energy, s.Energy-t.energy),
+		text("telemetry.parts", s.KineticEnergy, s.PotentialEnergy),
+		text("telemetry.momentum", s.Momentum.X, s.Momentum.Y, drift.Magnitude()),
+		text("telemetry.collisions", s.Collisions, s.Bodies),
+	}
+}
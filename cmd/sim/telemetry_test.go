@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestTelemetryReadsDrift shows the panel on one world's stats and checks
+// it reads the next's against them, and that hiding it clears it.
+func TestTelemetryReadsDrift(t *testing.T) {
+	var panel telemetry
+	if lines := panel.lines(physics.Stats{}); lines != nil {
+		t.Fatalf("hidden panel reads %q, want nothing", lines)
+	}
+
+	panel.toggle(physics.Stats{Energy: 100, Momentum: physics.Vector{X: 3, Y: 4}})
+	lines := panel.lines(physics.Stats{
+		Energy:          98.5,
+		KineticEnergy:   40,
+		PotentialEnergy: 58.5,
+		Momentum:        physics.Vector{X: 3, Y: 4.25},
+		Collisions:      12,
+		Bodies:          7,
+	})
+	want := []string{
+		"energy 98.5, -1.50 since shown",
+		"kinetic 40.0 potential 58.5",
+		"momentum 3.00, 4.25, off by 0.250 since shown",
+		"collisions 12/s bodies 7",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("panel reads %q, want %q", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d reads %q, want %q", i, lines[i], want[i])
+		}
+	}
+
+	panel.toggle(physics.Stats{})
+	if lines := panel.lines(physics.Stats{}); lines != nil {
+		t.Errorf("panel hidden again reads %q, want nothing", lines)
+	}
+}
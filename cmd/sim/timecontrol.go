@@ -0,0 +1,67 @@
+package main
+
+import (
+	"slices"
+	"time"
+)
+
+// timeScales are the rates the simulation can run at, as multiples of
+// real time, from slow motion to fast forward.
+var timeScales = []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 16, 32, maxSpeed}
+
+// TimeController decides how many steps the world takes each update: as
+// many as the clock has due at the chosen time scale, or none while paused
+// but for one at a time asked for, to watch a collision frame by frame.
+type TimeController struct {
+	// scale is the index in timeScales of the rate it runs at
+	scale  int
+	paused bool
+	// stepping asks for a single step while paused
+	stepping bool
+}
+
+// newTimeController returns a controller running at real time.
+func newTimeController() TimeController {
+	return TimeController{scale: slices.Index(timeScales, 1)}
+}
+
+// Scale returns how many times faster than real time the simulation runs.
+func (t *TimeController) Scale() float64 {
+	return timeScales[t.scale]
+}
+
+// faster and slower move to the next time scale up or down, stopping at
+// either end.
+func (t *TimeController) faster() {
+	t.scale = min(t.scale+1, len(timeScales)-1)
+}
+
+func (t *TimeController) slower() {
+	t.scale = max(t.scale-1, 0)
+}
+
+// toggle pauses the simulation, or carries on with it.
+func (t *TimeController) toggle() {
+	t.paused = !t.paused
+	t.stepping = false
+}
+
+// step asks for a single step, pausing the simulation if it isn't.
+func (t *TimeController) step() {
+	t.paused = true
+	t.stepping = true
+}
+
+// steps returns how many steps are due by now, taking the clock's ticks
+// at the time scale, or the one step asked for while paused.
+func (t *TimeController) steps(c *clock, now time.Time) int {
+	if !t.paused {
+		return c.advance(now, t.Scale())
+	}
+	c.hold(now)
+	if t.stepping {
+		t.stepping = false
+		return 1
+	}
+	return 0
+}
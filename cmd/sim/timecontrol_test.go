@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"physicsSim/physics"
+)
+
+// TestTimeControllerSlowsPausesAndSteps runs the clock through a second at
+// a tenth of real time, a paused second with one step asked for, and a
+// second at real time again, checking each hands out the steps it should
+// and that time spent paused is never made up.
+func TestTimeControllerSlowsPausesAndSteps(t *testing.T) {
+	var c clock
+	tc := newTimeController()
+	for tc.Scale() > 0.1 {
+		tc.slower()
+	}
+	start := time.Unix(0, 0)
+	// Frames of 10ms scale exactly, so no time is lost rounding them
+	frame := 10 * time.Millisecond
+	run := func(from time.Duration) int {
+		steps := 0
+		for k := time.Duration(1); k <= time.Second/frame; k++ {
+			steps += tc.steps(&c, start.Add(from+k*frame))
+		}
+		return steps
+	}
+	tc.steps(&c, start)
+
+	if got, want := run(0), physics.TicksPerSecond/10; got != want {
+		t.Errorf("a second at 0.1x took %d steps, want %d", got, want)
+	}
+	tc.toggle()
+	tc.step()
+	if got := run(time.Second); got != 1 || !tc.paused {
+		t.Errorf("a paused second with one step asked for took %d steps, paused %v", got, tc.paused)
+	}
+	tc.toggle()
+	for tc.Scale() < 1 {
+		tc.faster()
+	}
+	// Part of a tick left over from slow motion may fall due too
+	if got := run(2 * time.Second); got < physics.TicksPerSecond || got > physics.TicksPerSecond+1 {
+		t.Errorf("a second at real time after pausing took %d steps, want %d", got, physics.TicksPerSecond)
+	}
+
+	for range len(timeScales) {
+		tc.faster()
+	}
+	if tc.Scale() != maxSpeed {
+		t.Errorf("fastest scale %v, want %v", tc.Scale(), float64(maxSpeed))
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import "physicsSim/physics"
+
+// zone is a box in the world that notices bodies coming into it.
+type zone struct {
+	min physics.Vector
+	max physics.Vector
+	// inside records which bodies were in the zone at the last check
+	inside []bool
+}
+
+func (z *zone) contains(p physics.Vector) bool {
+	return p.X >= z.min.X && p.X <= z.max.X && p.Y >= z.min.Y && p.Y <= z.max.Y
+}
+
+func (z *zone) centre() physics.Vector {
+	return physics.ScalarMult(physics.Add(z.min, z.max), 0.5)
+}
+
+// entered reports whether any body has come into the zone since the last
+// check.
+func (z *zone) entered(objects []physics.Body) bool {
+	for len(z.inside) < len(objects) {
+		z.inside = append(z.inside, false)
+	}
+	arrived := false
+	for i, currBall := range objects {
+		in := z.contains(currBall.Position)
+		if in && !z.inside[i] {
+			arrived = true
+		}
+		z.inside[i] = in
+	}
+	return arrived
+}
+
+// stopwatch times the simulation from a body entering its start zone to a
+// body entering its stop zone, and holds the reading until the start zone
+// is entered again.
+type stopwatch struct {
+	name    string
+	start   zone
+	stop    zone
+	running bool
+	// started and stopped are world step counts
+	started uint64
+	stopped uint64
+}
+
+func newStopwatch(name string, start, stop zone) *stopwatch {
+	return &stopwatch{name: name, start: start, stop: stop}
+}
+
+// update checks both zones against the world. Call it after every step.
+func (s *stopwatch) update(w *physics.World) {
+	objects := w.Snapshot()
+	enteredStart := s.start.entered(objects)
+	enteredStop := s.stop.entered(objects)
+	switch {
+	case s.running && enteredStop:
+		s.running = false
+		s.stopped = w.Steps
+	case !s.running && enteredStart:
+		s.running = true
+		s.started = w.Steps
+	}
+}
+
+// elapsed returns how many steps the stopwatch has timed, counting up to
+// now while it runs.
+func (s *stopwatch) elapsed(now uint64) uint64 {
+	if s.running {
+		// Rewinding can take the world back to before the start
+		return now - min(now, s.started)
+	}
+	return s.stopped - s.started
+}
+
+// reading describes the time on the stopwatch.
+func (s *stopwatch) reading(now uint64) string {
+	ticks := s.elapsed(now)
+	key := "stopwatch.stopped"
+	if s.running {
+		key = "stopwatch.running"
+	}
+	return text(key, s.name, float64(ticks)/physics.TicksPerSecond, ticks)
+}
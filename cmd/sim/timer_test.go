@@ -0,0 +1,64 @@
+package main
+
+import (
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestZoneEnteredOnlyOnArrival checks a zone reports a body coming into it
+// once, not again while it stays there.
+func TestZoneEnteredOnlyOnArrival(t *testing.T) {
+	z := zone{min: physics.Vector{X: 0, Y: 0}, max: physics.Vector{X: 100, Y: 100}}
+	outside := []physics.Body{{Position: physics.Vector{X: 200, Y: 50}}}
+	inside := []physics.Body{{Position: physics.Vector{X: 50, Y: 50}}}
+
+	steps := []struct {
+		objects []physics.Body
+		want    bool
+	}{
+		{outside, false},
+		{inside, true},
+		{inside, false},
+		{outside, false},
+		{inside, true},
+	}
+	for i, step := range steps {
+		if got := z.entered(step.objects); got != step.want {
+			t.Errorf("check %d: entered = %v, want %v", i, got, step.want)
+		}
+	}
+}
+
+// TestStopwatchTimesFall drops a ball through two zones and checks the
+// stopwatch reads the steps it took to fall between them.
+func TestStopwatchTimesFall(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{{Position: physics.Vector{X: 320, Y: 40}}}, physics.Vector{Y: .5})
+	defer w.Close()
+
+	sw := newStopwatch("fall",
+		zone{min: physics.Vector{X: 0, Y: 100}, max: physics.Vector{X: physics.ScreenWidth, Y: 120}},
+		zone{min: physics.Vector{X: 0, Y: 300}, max: physics.Vector{X: physics.ScreenWidth, Y: 320}})
+
+	enteredStart, enteredStop := uint64(0), uint64(0)
+	for tick := 0; tick < 100 && enteredStop == 0; tick++ {
+		w.Step()
+		sw.update(w)
+		y := w.Snapshot()[0].Position.Y
+		if enteredStart == 0 && y >= 100 {
+			enteredStart = w.Steps
+		}
+		if enteredStop == 0 && y >= 300 {
+			enteredStop = w.Steps
+		}
+	}
+	if enteredStop == 0 {
+		t.Fatal("ball never reached the stop zone")
+	}
+	if sw.running {
+		t.Error("stopwatch still running after the ball reached the stop zone")
+	}
+	if got, want := sw.elapsed(w.Steps), enteredStop-enteredStart; got != want {
+		t.Errorf("elapsed %d steps, want %d", got, want)
+	}
+}
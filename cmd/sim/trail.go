@@ -0,0 +1,45 @@
+package main
+
+import "physicsSim/physics"
+
+// trails remembers the recent positions of selected bodies so their paths
+// can be drawn behind them.
+type trails struct {
+	length int
+	bodies []int
+	points [][]physics.Vector
+	next   []int
+}
+
+// newTrails tracks the given bodies, keeping up to length positions each.
+func newTrails(length int, bodies []int) *trails {
+	return &trails{
+		length: length,
+		bodies: bodies,
+		points: make([][]physics.Vector, len(bodies)),
+		next:   make([]int, len(bodies)),
+	}
+}
+
+// record adds the current position of every tracked body, overwriting the
+// oldest once a trail is full.
+func (t *trails) record(objects []physics.Body) {
+	for i, body := range t.bodies {
+		if body >= len(objects) {
+			continue
+		}
+		position := objects[body].Position
+		if len(t.points[i]) < t.length {
+			t.points[i] = append(t.points[i], position)
+			continue
+		}
+		t.points[i][t.next[i]] = position
+		t.next[i] = (t.next[i] + 1) % t.length
+	}
+}
+
+// path returns trail i from oldest to newest position.
+func (t *trails) path(i int) []physics.Vector {
+	points := t.points[i]
+	return append(append([]physics.Vector(nil), points[t.next[i]:]...), points[:t.next[i]]...)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"physicsSim/physics"
+)
+
+// trajectories records the whole paths of chosen bodies, a point every so
+// many steps, to export for plotting elsewhere. Trails only keep the last
+// few positions to draw; these keep everything from the start. Bodies
+// renumber when one is removed, so a path can carry on along another.
+type trajectories struct {
+	bodies []int
+	names  []string
+	// start is the step of each path's first point, and every the steps
+	// between points
+	start uint64
+	every uint64
+	paths [][]physics.Vector
+}
+
+// newTrajectories starts recording the given bodies of w, or all of them
+// if none are given, every so many steps, from where they are now.
+func newTrajectories(w *physics.World, bodies []int, every int) *trajectories {
+	objects := w.Snapshot()
+	if len(bodies) == 0 {
+		for i := range objects {
+			bodies = append(bodies, i)
+		}
+	}
+	t := &trajectories{bodies: bodies, start: w.Steps, every: uint64(max(every, 1)), paths: make([][]physics.Vector, len(bodies))}
+	for _, body := range bodies {
+		name := ""
+		if body < len(objects) {
+			name = objects[body].Name
+		}
+		t.names = append(t.names, name)
+	}
+	t.record(w)
+	return t
+}
+
+// record adds every body's position if the world's step is due a point.
+// Stepping on again after a rewind replaces what was recorded from there,
+// and a path stops where its body went.
+func (t *trajectories) record(w *physics.World) {
+	step := w.Steps
+	if step < t.start || (step-t.start)%t.every != 0 {
+		return
+	}
+	n := int((step - t.start) / t.every)
+	objects := w.Snapshot()
+	for i, body := range t.bodies {
+		path := t.paths[i]
+		if len(path) > n {
+			path = path[:n]
+		}
+		if len(path) == n && body < len(objects) {
+			path = append(path, objects[body].Position)
+		}
+		t.paths[i] = path
+	}
+}
+
+// trajectoryFeature is one path as a GeoJSON feature: a line string in
+// world units, with y increasing downwards as on the screen.
+type trajectoryFeature struct {
+	Type       string               `json:"type"`
+	Properties trajectoryProperties `json:"properties"`
+	Geometry   trajectoryLine       `json:"geometry"`
+}
+
+// trajectoryProperties say which run and body a path is and when its points
+// were taken, so paths from several runs can be told apart and lined up.
+type trajectoryProperties struct {
+	Run   string `json:"run"`
+	Body  int    `json:"body"`
+	Name  string `json:"name,omitempty"`
+	Start uint64 `json:"startStep"`
+	Every uint64 `json:"stepsPerPoint"`
+	// Interval is the simulated time between points, in seconds
+	Interval float64 `json:"secondsPerPoint"`
+}
+
+// trajectoryLine is a path's points as a GeoJSON line string.
+type trajectoryLine struct {
+	Type        string       `json:"type"`
+	Coordinates [][2]float64 `json:"coordinates"`
+}
+
+// writeGeoJSON writes the paths as a GeoJSON feature collection of line
+// strings, one per body with at least two points, tagged with the run's
+// name.
+func (t *trajectories) writeGeoJSON(out io.Writer, run string) error {
+	collection := struct {
+		Type     string              `json:"type"`
+		Features []trajectoryFeature `json:"features"`
+	}{Type: "FeatureCollection", Features: []trajectoryFeature{}}
+	for i, path := range t.paths {
+		if len(path) < 2 {
+			continue
+		}
+		line := trajectoryLine{Type: "LineString", Coordinates: make([][2]float64, len(path))}
+		for k, p := range path {
+			line.Coordinates[k] = [2]float64{p.X, p.Y}
+		}
+		collection.Features = append(collection.Features, trajectoryFeature{
+			Type: "Feature",
+			Properties: trajectoryProperties{
+				Run:      run,
+				Body:     t.bodies[i],
+				Name:     t.names[i],
+				Start:    t.start,
+				Every:    t.every,
+				Interval: float64(t.every) / physics.TicksPerSecond,
+			},
+			Geometry: line,
+		})
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(collection)
+}
+
+// writeTrajectories writes the recorded paths of the named run to the
+// GeoJSON file at path.
+func writeTrajectories(path, run string, t *trajectories) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := t.writeGeoJSON(file, run); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
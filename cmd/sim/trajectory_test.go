@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestTrajectoriesExportAsGeoJSON records a falling and a still ball every
+// other step, through a rewind, and checks the export holds a line string
+// for each with every point from the start, tagged with the run and body.
+func TestTrajectoriesExportAsGeoJSON(t *testing.T) {
+	w := physics.NewWorld([]physics.Body{
+		{Position: physics.Vector{X: 100, Y: 100}, Name: "dropped"},
+		{Position: physics.Vector{X: 300, Y: 100}, Frozen: true},
+	}, physics.Vector{Y: 0.5})
+	defer w.Close()
+	history := physics.NewHistory(60)
+	history.Record(w)
+
+	paths := newTrajectories(w, nil, 2)
+	step := func(n int) {
+		for range n {
+			w.Step()
+			paths.record(w)
+			history.Record(w)
+		}
+	}
+	step(20)
+	for range 5 {
+		history.Rewind(w)
+	}
+	step(5)
+
+	var out strings.Builder
+	if err := paths.writeGeoJSON(&out, "test"); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Type     string
+		Features []struct {
+			Properties struct {
+				Run   string
+				Body  int
+				Name  string
+				Every uint64 `json:"stepsPerPoint"`
+			}
+			Geometry struct {
+				Type        string
+				Coordinates [][2]float64
+			}
+		}
+	}
+	if err := json.Unmarshal([]byte(out.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "FeatureCollection" || len(got.Features) != 2 {
+		t.Fatalf("exported a %q of %d features, want a FeatureCollection of 2", got.Type, len(got.Features))
+	}
+	dropped := got.Features[0]
+	if p := dropped.Properties; p.Run != "test" || p.Body != 0 || p.Name != "dropped" || p.Every != 2 {
+		t.Errorf("properties %+v, want run test, body 0 named dropped, every 2 steps", p)
+	}
+	line := dropped.Geometry.Coordinates
+	if dropped.Geometry.Type != "LineString" || len(line) != 11 {
+		t.Fatalf("path is a %s of %d points, want a LineString of 11", dropped.Geometry.Type, len(line))
+	}
+	for k := 1; k < len(line); k++ {
+		if line[k][1] <= line[k-1][1] {
+			t.Errorf("point %d at %v, want it below %v as the ball falls", k, line[k], line[k-1])
+		}
+	}
+	if still := got.Features[1].Geometry.Coordinates; still[0] != still[len(still)-1] {
+		t.Errorf("frozen ball's path runs from %v to %v, want it in place", still[0], still[len(still)-1])
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import "physicsSim/physics"
+
+const (
+	// minZoom and maxZoom bound how far a view zooms out and in
+	minZoom = 0.25
+	maxZoom = 8
+)
+
+// viewport is one view of the world, filling a rectangle of the screen
+// through its own camera. A zoom above one magnifies the world. A view can
+// follow a body, keeping it in the middle.
+type viewport struct {
+	camera camera
+	// at is the top-left corner of the view on the screen
+	at     physics.Vector
+	width  float64
+	height float64
+	zoom   float64
+	// follow is the body the view keeps centred, or NoBody. Bodies
+	// renumber when one is removed, so it can end up on another.
+	follow int
+	frame  frame
+}
+
+// newViewport returns an unzoomed view of the given size at the given
+// point on the screen, following nothing.
+func newViewport(at physics.Vector, width, height float64) *viewport {
+	return &viewport{at: at, width: width, height: height, zoom: 1, follow: physics.NoBody}
+}
+
+// span returns the width and height of the world the view shows.
+func (v *viewport) span() (float64, float64) {
+	return v.width / v.zoom, v.height / v.zoom
+}
+
+// centre returns the world point in the middle of the view.
+func (v *viewport) centre() physics.Vector {
+	width, height := v.span()
+	return physics.Add(v.camera.position, physics.Vector{X: width / 2, Y: height / 2})
+}
+
+// lookAt moves the camera so p is in the middle of the view.
+func (v *viewport) lookAt(p physics.Vector) {
+	width, height := v.span()
+	v.camera.position = physics.Subtract(p, physics.Vector{X: width / 2, Y: height / 2})
+}
+
+// contains reports whether the screen point p is inside the view.
+func (v *viewport) contains(p physics.Vector) bool {
+	return p.X >= v.at.X && p.X < v.at.X+v.width && p.Y >= v.at.Y && p.Y < v.at.Y+v.height
+}
+
+// ScreenToWorld returns the world point under the screen point p, making
+// the view a physics.ScreenTransform for picking bodies through.
+func (v *viewport) ScreenToWorld(p physics.Vector) physics.Vector {
+	return v.camera.screenToWorld(physics.ScalarMult(physics.Subtract(p, v.at), 1/v.zoom))
+}
+
+// pan moves the view by delta screen pixels and stops it following.
+func (v *viewport) pan(delta physics.Vector) {
+	v.camera.pan(physics.ScalarMult(delta, 1/v.zoom))
+	v.follow = physics.NoBody
+}
+
+// zoomBy zooms the view in by factor, or out below one, keeping the same
+// world point in the middle.
+func (v *viewport) zoomBy(factor float64) {
+	centre := v.centre()
+	v.zoom = max(minZoom, min(maxZoom, v.zoom*factor))
+	v.lookAt(centre)
+}
+
+// toggleFollow starts the view following the body nearest its middle, or
+// stops it if it is following one already.
+func (v *viewport) toggleFollow(objects []physics.Body) {
+	if v.follow != physics.NoBody {
+		v.follow = physics.NoBody
+		return
+	}
+	centre := v.centre()
+	best := 0.0
+	for i, b := range objects {
+		offset := physics.Subtract(b.Position, centre)
+		if d := offset.MagnitudeSquared(); v.follow == physics.NoBody || d < best {
+			v.follow, best = i, d
+		}
+	}
+}
+
+// track keeps a following view on its body, letting go if it has gone.
+func (v *viewport) track(objects []physics.Body) {
+	if v.follow == physics.NoBody {
+		return
+	}
+	if v.follow >= len(objects) {
+		v.follow = physics.NoBody
+		return
+	}
+	v.lookAt(objects[v.follow].Position)
+}
+
+// fitZoom returns the zoom at which all of extent just fits a view of the
+// given size.
+func fitZoom(extent physics.AABB, width, height float64) float64 {
+	span := physics.Subtract(extent.Max, extent.Min)
+	return min(width/span.X, height/span.Y)
+}
+
+// fitView returns a view filling a screen of the given size, scaled so all
+// of extent fits and centred on it, however the window is shaped.
+func fitView(extent physics.AABB, width, height float64) *viewport {
+	v := newViewport(physics.Vector{}, width, height)
+	v.zoom = fitZoom(extent, width, height)
+	v.lookAt(physics.ScalarMult(physics.Add(extent.Min, extent.Max), 0.5))
+	return v
+}
+
+// splitViews divides a screen of the given size down the middle: an
+// overview on the left, zoomed out to fit all of extent, and a view on the
+// right zoomed in twice as far as one fitting the whole screen would be,
+// on the body nearest the middle.
+func splitViews(objects []physics.Body, extent physics.AABB, width, height float64) []*viewport {
+	middle := physics.ScalarMult(physics.Add(extent.Min, extent.Max), 0.5)
+	overview := newViewport(physics.Vector{}, width/2, height)
+	overview.zoom = fitZoom(extent, overview.width, overview.height)
+	overview.lookAt(middle)
+
+	closeUp := newViewport(physics.Vector{X: width / 2}, width/2, height)
+	closeUp.zoom = 2 * fitZoom(extent, width, height)
+	closeUp.lookAt(middle)
+	closeUp.toggleFollow(objects)
+	closeUp.track(objects)
+	return []*viewport{overview, closeUp}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"physicsSim/physics"
+)
+
+// TestViewportMapsScreenToWorld checks a zoomed view placed off the corner
+// of the screen maps its own corner and middle to the right world points,
+// and keeps its middle where it was when zoomed.
+func TestViewportMapsScreenToWorld(t *testing.T) {
+	v := newViewport(physics.Vector{X: 320}, 320, 480)
+	v.zoom = 2
+	v.lookAt(physics.Vector{X: 100, Y: 200})
+
+	if got, want := v.ScreenToWorld(physics.Vector{X: 320}), (physics.Vector{X: 20, Y: 80}); got != want {
+		t.Errorf("view corner shows %v, want %v", got, want)
+	}
+	if got, want := v.ScreenToWorld(physics.Vector{X: 480, Y: 240}), (physics.Vector{X: 100, Y: 200}); got != want {
+		t.Errorf("view middle shows %v, want %v", got, want)
+	}
+
+	v.zoomBy(0.5)
+	if got := v.centre(); math.Abs(got.X-100) > epsilon || math.Abs(got.Y-200) > epsilon {
+		t.Errorf("middle moved to %v zooming out, want it kept at (100, 200)", got)
+	}
+	v.zoomBy(1000)
+	if v.zoom != maxZoom {
+		t.Errorf("zoom %v, want it held at %v", v.zoom, maxZoom)
+	}
+}
+
+// TestViewportFollowsBody checks a view picks the body nearest its middle
+// to follow, keeps it centred as it moves, stops following when panned and
+// lets go of a body that has gone.
+func TestViewportFollowsBody(t *testing.T) {
+	objects := []physics.Body{
+		{Position: physics.Vector{X: 50, Y: 50}},
+		{Position: physics.Vector{X: 300, Y: 250}},
+	}
+	v := newViewport(physics.Vector{}, 640, 480)
+	v.toggleFollow(objects)
+	if v.follow != 1 {
+		t.Fatalf("following body %d, want 1, the nearest the middle", v.follow)
+	}
+
+	objects[1].Position = physics.Vector{X: 500, Y: 100}
+	v.track(objects)
+	if got := v.centre(); got != objects[1].Position {
+		t.Errorf("view centred on %v, want the followed body at %v", got, objects[1].Position)
+	}
+
+	v.pan(physics.Vector{X: 10})
+	if v.follow != physics.NoBody {
+		t.Errorf("still following body %d after panning", v.follow)
+	}
+
+	v.follow = 1
+	v.track(objects[:1])
+	if v.follow != physics.NoBody {
+		t.Errorf("still following body %d after it was removed", v.follow)
+	}
+}
+
+// TestFitViewShowsWholeWorld fits the same world to windows of different
+// shapes and checks each shows all of it, as large as fits, in the middle.
+func TestFitViewShowsWholeWorld(t *testing.T) {
+	extent := physics.AABB{Max: physics.Vector{X: 640, Y: 480}}
+	for _, tt := range []struct {
+		width, height, zoom float64
+	}{
+		{640, 480, 1},
+		{1280, 480, 1},
+		{320, 480, 0.5},
+		{1280, 960, 2},
+	} {
+		v := fitView(extent, tt.width, tt.height)
+		if math.Abs(v.zoom-tt.zoom) > epsilon {
+			t.Errorf("%vx%v window: zoom %v, want %v", tt.width, tt.height, v.zoom, tt.zoom)
+		}
+		if got := v.centre(); math.Abs(got.X-320) > epsilon || math.Abs(got.Y-240) > epsilon {
+			t.Errorf("%vx%v window: centred on %v, want (320, 240)", tt.width, tt.height, got)
+		}
+		corner := v.ScreenToWorld(physics.Vector{})
+		far := v.ScreenToWorld(physics.Vector{X: tt.width, Y: tt.height})
+		if corner.X > epsilon || corner.Y > epsilon || far.X < 640-epsilon || far.Y < 480-epsilon {
+			t.Errorf("%vx%v window shows %v to %v, want all of the world", tt.width, tt.height, corner, far)
+		}
+	}
+
+	views := splitViews(nil, extent, 1280, 960)
+	if got, want := views[0].zoom, 1.0; math.Abs(got-want) > epsilon {
+		t.Errorf("overview zoom %v, want %v to fit half the window", got, want)
+	}
+	if got, want := views[1].zoom, 4.0; math.Abs(got-want) > epsilon {
+		t.Errorf("close-up zoom %v, want %v, twice a whole-window fit", got, want)
+	}
+}
@@ -0,0 +1,292 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// Unless given another integrator the world steps with semi-implicit Euler
+// at a fixed tick (dt = 1): the speed is updated first and the new speed
+// moves the body. Against the closed-form solutions below each integrator
+// is off by ordered, known amounts, documented with each tolerance.
+
+// flight steps a single ball under gravity until it falls back through
+// its starting height, returning the interpolated landing x, the highest
+// point reached and the number of steps taken.
+func flight(t *testing.T, start, velocity, gravity Vector) (float64, float64, int) {
+	t.Helper()
+
+	w := NewWorld([]Body{{Position: start, Velocity: velocity}}, gravity)
+	defer w.Close()
+
+	previous := start
+	apex := start.Y
+	for steps := 1; steps < 10000; steps++ {
+		w.Step()
+		current := w.Snapshot()[0].Position
+		apex = math.Min(apex, current.Y)
+
+		// Screen y grows downwards, so landing is crossing back below start
+		if current.Y >= start.Y && steps > 1 {
+			fraction := (start.Y - previous.Y) / (current.Y - previous.Y)
+			return previous.X + fraction*(current.X-previous.X), start.Y - apex, steps
+		}
+		previous = current
+	}
+	t.Fatal("ball never landed")
+	return 0, 0, 0
+}
+
+func TestProjectileRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		velocity Vector
+		gravity  float64
+	}{
+		{"shallow", Vector{X: 4, Y: -6}, .3},
+		{"steep", Vector{X: 1.5, Y: -11}, .3},
+		{"strong gravity", Vector{X: 3, Y: -12}, .9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := Vector{X: 60, Y: 440}
+			landing, _, _ := flight(t, start, tt.velocity, Vector{Y: tt.gravity})
+
+			// R = 2·vx·vy / g. Semi-implicit Euler lands exactly one step
+			// early, so the range is short by vx·dt; a tenth of that covers
+			// interpolating the landing point between steps.
+			exact := 2 * tt.velocity.X * -tt.velocity.Y / tt.gravity
+			want := exact - tt.velocity.X
+			got := landing - start.X
+			if tolerance := math.Abs(tt.velocity.X) * 0.1; math.Abs(got-want) > tolerance {
+				t.Errorf("range = %.3f, want %.3f ± %.3f (closed form %.3f)", got, want, tolerance, exact)
+			}
+		})
+	}
+}
+
+func TestProjectileApex(t *testing.T) {
+	velocity := Vector{X: 2, Y: -10}
+	gravity := .25
+	_, height, _ := flight(t, Vector{X: 60, Y: 440}, velocity, Vector{Y: gravity})
+
+	// H = vy² / 2g. Applying gravity before moving loses half a step of
+	// climb, so the apex is low by about vy·dt/2.
+	want := velocity.Y * velocity.Y / (2 * gravity)
+	if tolerance := math.Abs(velocity.Y) / 2; math.Abs(height-want) > tolerance {
+		t.Errorf("apex height = %.3f, want %.3f ± %.3f", height, want, tolerance)
+	}
+}
+
+func TestFreeFallTime(t *testing.T) {
+	start := Vector{X: 320, Y: 40}
+	floor := float64(ScreenHeight - BallRadius)
+	gravity := .3
+
+	w := NewWorld([]Body{{Position: start}}, Vector{Y: gravity})
+	defer w.Close()
+
+	steps := 0
+	for w.Snapshot()[0].Velocity.Y >= 0 {
+		w.Step()
+		steps++
+	}
+
+	// t = √(2h/g). The ball is caught by the floor on the step it would
+	// pass through it, so the count can run one step over.
+	want := math.Sqrt(2 * (floor - start.Y) / gravity)
+	if math.Abs(float64(steps)-want) > 1 {
+		t.Errorf("fell for %d steps, want %.2f ± 1", steps, want)
+	}
+}
+
+func TestCircularOrbit(t *testing.T) {
+	const (
+		mass   = 300
+		radius = 150
+	)
+	centre := Vector{X: 320, Y: 240}
+	speed := math.Sqrt(mass / radius)
+	objects := []Body{
+		{Position: centre, Mass: mass},
+		{Position: Add(centre, Vector{X: radius}), Velocity: Vector{Y: -speed}},
+	}
+	w := NewWorld(objects, Vector{}, WithAttraction(1))
+	defer w.Close()
+
+	// T = 2π·√(r³/GM). The massless body can't move the centre, and
+	// symplectic Euler keeps the orbit closed, so over several orbits the
+	// radius only wobbles and each lap takes the closed-form time.
+	period := 2 * math.Pi * math.Sqrt(radius*radius*radius/mass)
+	previous := objects[1].Position
+	var laps []int
+	for steps := 1; len(laps) < 5 && steps < 10000; steps++ {
+		w.Step()
+		current := w.Snapshot()[1].Position
+		offset := Subtract(current, centre)
+		if r := offset.Magnitude(); math.Abs(r-radius) > radius*0.01 {
+			t.Fatalf("step %d: radius %.3f, want %v ± 1%%", steps, r, radius)
+		}
+		// A lap ends climbing back up through the start
+		if previous.Y > centre.Y && current.Y <= centre.Y && current.X > centre.X {
+			laps = append(laps, steps)
+		}
+		previous = current
+	}
+
+	if len(laps) < 5 {
+		t.Fatalf("completed %d orbits, want 5", len(laps))
+	}
+	if got := float64(laps[4]) / 5; math.Abs(got-period) > 1 {
+		t.Errorf("orbit took %.2f steps, want %.2f ± 1", got, period)
+	}
+}
+
+// integrators are the world's integrators, each run against the closed
+// forms below with its own tolerance
+var integrators = []struct {
+	name       string
+	integrator Integrator
+}{
+	{"symplectic euler", SymplecticEuler{}},
+	{"velocity verlet", VelocityVerlet{}},
+	{"rk4", RK4{}},
+}
+
+// period steps w until body i has crossed x = across moving right laps
+// times, returning the mean number of steps a lap took, interpolated
+// between the steps either side of each crossing.
+func period(t *testing.T, w *World, i int, across float64, laps int) float64 {
+	t.Helper()
+
+	previous := w.Snapshot()[i].Position.X
+	var first, last float64
+	crossings := 0
+	for steps := 1; steps < 20000; steps++ {
+		w.Step()
+		current := w.Snapshot()[i].Position.X
+		if previous < across && current >= across {
+			at := float64(steps) - 1 + (across-previous)/(current-previous)
+			if crossings == 0 {
+				first = at
+			}
+			last = at
+			if crossings++; crossings > laps {
+				return (last - first) / float64(laps)
+			}
+		}
+		previous = current
+	}
+	t.Fatalf("completed %d laps, want %d", max(crossings-1, 0), laps)
+	return 0
+}
+
+func TestPendulumPeriod(t *testing.T) {
+	const (
+		length  = 100
+		gravity = .3
+		swing   = .1
+	)
+	anchor := Vector{X: 320, Y: 100}
+
+	// T = 2π·√(L/g), lengthened by θ₀²/16 for a swing of θ₀ radians.
+	// Symplectic Euler's swing is short by (ω·dt)²/24 of a period, as it
+	// is on a spring. The rod cancels the velocity that would stretch it
+	// between Kick and Drift, so any pull given in Drift is only taken out
+	// when the bob is moved back onto its circle, which costs it a little
+	// speed along the arc and slows the swing:
+	tests := map[string]float64{
+		// Ahead by 0.014 steps
+		"symplectic euler": 0.03,
+		// Half the pull in Drift: behind by about a tenth of a step
+		"velocity verlet": 0.15,
+		// All of it in Drift: behind by about a fifth
+		"rk4": 0.25,
+	}
+	want := 2 * math.Pi * math.Sqrt(length/gravity) * (1 + swing*swing/16)
+	for _, tt := range integrators {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []Body{{Position: Add(anchor, Vector{X: length * math.Sin(swing), Y: length * math.Cos(swing)})}}
+			w := NewWorld(objects, Vector{Y: gravity}, WithConstraints(NewAnchoredRod(objects, 0, anchor)), WithIntegrator(tt.integrator))
+			defer w.Close()
+
+			got := period(t, w, 0, anchor.X, 5)
+			if tolerance := tests[tt.name]; math.Abs(got-want) > tolerance {
+				t.Errorf("swing took %.3f steps, want %.3f ± %v", got, want, tolerance)
+			}
+		})
+	}
+}
+
+func TestSpringFrequency(t *testing.T) {
+	const (
+		stiffness = .01
+		stretch   = 20
+	)
+	anchor := Vector{X: 220, Y: 240}
+
+	// T = 2π·√(m/k). The spring pushes on the velocity between Kick and
+	// Drift, with no field for any integrator to spread over the step, so
+	// all three move the ball as symplectic Euler would, whose period is
+	// short by (ω·dt)²/24 of itself: 0.026 steps here.
+	tests := map[string]float64{
+		"symplectic euler": 0.05,
+		"velocity verlet":  0.05,
+		"rk4":              0.05,
+	}
+	want := 2 * math.Pi * math.Sqrt(1/stiffness)
+	for _, tt := range integrators {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := []Body{{Position: Add(anchor, Vector{X: 100})}}
+			spring := NewAnchoredSpring(objects, 0, anchor, stiffness, 0)
+			objects[0].Position.X += stretch
+			w := NewWorld(objects, Vector{}, WithConstraints(spring), WithIntegrator(tt.integrator))
+			defer w.Close()
+
+			got := period(t, w, 0, anchor.X+100, 5)
+			if tolerance := tests[tt.name]; math.Abs(got-want) > tolerance {
+				t.Errorf("oscillation took %.3f steps, want %.3f ± %v", got, want, tolerance)
+			}
+		})
+	}
+}
+
+func TestTerminalVelocity(t *testing.T) {
+	const (
+		gravity = .3
+		density = .1
+	)
+
+	// v = mg/c, with c the air's density times the ball's drag. Drag is
+	// taken implicitly after the Kick, so the speed the ball settles at
+	// is where the pull it gains over a step is what the air takes back.
+	// Symplectic Euler kicks with all of it first and settles at mg/c,
+	// but the others give some of the pull after the air has slowed the
+	// ball, where it isn't taken back:
+	tests := map[string]struct{ offset, tolerance float64 }{
+		"symplectic euler": {0, 1e-4},
+		// Half the pull arrives after the drag: faster by g·dt/2
+		"velocity verlet": {gravity / 2, 1e-4},
+		// All of the pull arrives after the drag: faster by g·dt
+		"rk4": {gravity, 1e-4},
+	}
+	for _, tt := range integrators {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld([]Body{{Position: Vector{X: 320, Y: 40}}}, Vector{Y: gravity}, WithDrag(LinearDrag, density), WithIntegrator(tt.integrator))
+			defer w.Close()
+
+			// The ball closes on its terminal speed by a factor of 1+c
+			// a step, so 120 steps bring it within a ten-thousandth,
+			// still well above the floor
+			for range 120 {
+				w.Step()
+			}
+			want := w.TerminalSpeed(0) + tests[tt.name].offset
+			got := w.Snapshot()[0].Velocity.Y
+			if tolerance := tests[tt.name].tolerance; math.Abs(got-want) > tolerance {
+				t.Errorf("fell at %.6f, want %.6f ± %v (closed form %.6f)", got, want, tolerance, w.TerminalSpeed(0))
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package physics
+
+import "math"
+
+// ArenaShape is the shape of the boundary that keeps balls in the world.
+type ArenaShape int
+
+const (
+	// BoxArena is a box from the origin, the size of the screen unless
+	// given another, the default
+	BoxArena ArenaShape = iota
+	// CircleArena is a round bowl
+	CircleArena
+	// PolygonArena is any simple closed polygon, convex or not
+	PolygonArena
+	// TorusArena has no walls: a ball leaving one edge of its box comes
+	// back in at the opposite edge, and balls touch across the seams
+	TorusArena
+	// OpenArena has no walls either, and a ball that gets further than a
+	// margin off its box is taken out of the world
+	OpenArena
+)
+
+// arenaSegments is how many straight lines a round arena is drawn with.
+const arenaSegments = 96
+
+// Arena is the world's boundary. Balls inside touch it only with their
+// edges; a ball that ends up outside is brought back in through the
+// nearest part of the wall.
+type Arena struct {
+	Shape  ArenaShape
+	Centre Vector
+	Radius float64
+	// Size is the width and height of a box, torus or open arena, from the
+	// origin; zero makes it ScreenWidth by ScreenHeight
+	Size Vector
+	// vertices go around the polygon in either direction; the last joins
+	// back to the first
+	vertices []Vector
+	// winding is 1 if the vertices go clockwise on screen, -1 if not
+	winding float64
+	// margin is how far off its box an open arena keeps balls
+	margin float64
+}
+
+// WithBoxArena walls the world in a box width by height from the origin
+// rather than the screen.
+func WithBoxArena(width, height float64) WorldOption {
+	return func(w *World) {
+		w.Arena = Arena{Shape: BoxArena, Size: Vector{X: width, Y: height}}
+	}
+}
+
+// WithCircleArena bounds the world by a circle rather than the screen.
+func WithCircleArena(centre Vector, radius float64) WorldOption {
+	return func(w *World) {
+		w.Arena = Arena{Shape: CircleArena, Centre: centre, Radius: radius}
+	}
+}
+
+// WithPolygonArena bounds the world by the closed polygon through vertices
+// rather than the screen.
+func WithPolygonArena(vertices ...Vector) WorldOption {
+	return func(w *World) {
+		w.Arena = Arena{Shape: PolygonArena, vertices: vertices, winding: 1}
+		if signedArea(vertices) < 0 {
+			w.Arena.winding = -1
+		}
+	}
+}
+
+// WithTorusArena wraps the world around at the screen edges. Rods are not
+// wrapped, so a rigged body should stay clear of the seams.
+func WithTorusArena() WorldOption {
+	return func(w *World) {
+		w.Arena = Arena{Shape: TorusArena}
+		size := w.Arena.size()
+		w.broadphase.wrapAround(size.X, size.Y)
+	}
+}
+
+// WithOpenArena takes the walls away and removes balls once they are more
+// than margin off the screen.
+func WithOpenArena(margin float64) WorldOption {
+	return func(w *World) {
+		w.Arena = Arena{Shape: OpenArena, margin: margin}
+	}
+}
+
+// signedArea is the shoelace area of a polygon, positive when its vertices
+// go clockwise on screen, where y points down.
+func signedArea(vertices []Vector) float64 {
+	area := 0.0
+	for k, a := range vertices {
+		b := vertices[(k+1)%len(vertices)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	return area / 2
+}
+
+// edge returns side k of the polygon.
+func (a *Arena) edge(k int) StaticSegment {
+	return StaticSegment{A: a.vertices[k], B: a.vertices[(k+1)%len(a.vertices)]}
+}
+
+// inward returns the unit normal of side k pointing into the polygon.
+func (a *Arena) inward(k int) Vector {
+	side := a.edge(k)
+	along := UnitVector(Subtract(side.B, side.A))
+	return ScalarMult(Vector{X: -along.Y, Y: along.X}, a.winding)
+}
+
+// Contains reports whether p lies inside the polygon, by counting how many
+// sides a ray from p to the right crosses.
+func (a *Arena) Contains(p Vector) bool {
+	inside := false
+	for k := range a.vertices {
+		side := a.edge(k)
+		if (side.A.Y > p.Y) != (side.B.Y > p.Y) {
+			crossing := side.A.X + (p.Y-side.A.Y)*(side.B.X-side.A.X)/(side.B.Y-side.A.Y)
+			if p.X < crossing {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// Outline returns the points to draw the arena through, closed back to the
+// first, or nil for a box.
+func (a *Arena) Outline() []Vector {
+	switch a.Shape {
+	case CircleArena:
+		points := make([]Vector, 0, arenaSegments+1)
+		for k := 0; k <= arenaSegments; k++ {
+			angle := 2 * math.Pi * float64(k) / arenaSegments
+			points = append(points, Add(a.Centre, Vector{X: a.Radius * math.Cos(angle), Y: a.Radius * math.Sin(angle)}))
+		}
+		return points
+	case PolygonArena:
+		return append(append([]Vector(nil), a.vertices...), a.vertices[0])
+	}
+	return nil
+}
+
+// Extent returns the box the arena takes up, in world units: its own box
+// for a box, torus or open arena, or the box around a round or polygonal
+// one. A view showing it shows the whole world.
+func (a *Arena) Extent() AABB {
+	switch a.Shape {
+	case CircleArena:
+		reach := Vector{X: a.Radius, Y: a.Radius}
+		return AABB{Min: Subtract(a.Centre, reach), Max: Add(a.Centre, reach)}
+	case PolygonArena:
+		box := AABB{Min: a.vertices[0], Max: a.vertices[0]}
+		for _, v := range a.vertices[1:] {
+			box.Min = Vector{X: min(box.Min.X, v.X), Y: min(box.Min.Y, v.Y)}
+			box.Max = Vector{X: max(box.Max.X, v.X), Y: max(box.Max.Y, v.Y)}
+		}
+		return box
+	}
+	return AABB{Max: a.size()}
+}
+
+// size returns the width and height of a box, torus or open arena.
+func (a *Arena) size() Vector {
+	if a.Size == (Vector{}) {
+		return Vector{X: ScreenWidth, Y: ScreenHeight}
+	}
+	return a.Size
+}
+
+// separation returns the shortest offset from q to p on the torus, which
+// may cross a seam.
+func (a *Arena) separation(p, q Vector) Vector {
+	offset, size := Subtract(p, q), a.size()
+	offset.X -= size.X * math.Round(offset.X/size.X)
+	offset.Y -= size.Y * math.Round(offset.Y/size.Y)
+	return offset
+}
+
+// SeamImages appends where a ball of the given radius at p also shows on
+// the torus: once more across each seam it overlaps, and across the corner
+// if it overlaps two.
+func (a *Arena) SeamImages(p Vector, radius float64, dst []Vector) []Vector {
+	shift, size := Vector{}, a.size()
+	if p.X < radius {
+		shift.X = size.X
+	} else if p.X > size.X-radius {
+		shift.X = -size.X
+	}
+	if p.Y < radius {
+		shift.Y = size.Y
+	} else if p.Y > size.Y-radius {
+		shift.Y = -size.Y
+	}
+	if shift.X != 0 {
+		dst = append(dst, Add(p, Vector{X: shift.X}))
+	}
+	if shift.Y != 0 {
+		dst = append(dst, Add(p, Vector{Y: shift.Y}))
+	}
+	if shift.X != 0 && shift.Y != 0 {
+		dst = append(dst, Add(p, shift))
+	}
+	return dst
+}
+
+// wrap brings ball i back onto the torus.
+func (w *World) wrap(objects []Body, i int) {
+	p, size := &objects[i].Position, w.Arena.size()
+	p.X = wrapped(p.X, size.X)
+	p.Y = wrapped(p.Y, size.Y)
+}
+
+// wrapped returns x wrapped into [0, size).
+func wrapped(x, size float64) float64 {
+	x = math.Mod(x, size)
+	if x < 0 {
+		x += size
+	}
+	return x
+}
+
+// removeEscaped takes every ball that has left an open arena out of
+// objects, returning the rest.
+func (w *World) removeEscaped(objects []Body) []Body {
+	if w.Arena.Shape != OpenArena {
+		return objects
+	}
+	m, size := w.Arena.margin, w.Arena.size()
+	for i := len(objects) - 1; i >= 0; i-- {
+		p := objects[i].Position
+		if p.X < -m || p.X > size.X+m || p.Y < -m || p.Y > size.Y+m {
+			objects = w.remove(objects, i)
+		}
+	}
+	return objects
+}
+
+// constrainToCircle keeps ball i inside a round arena.
+func (w *World) constrainToCircle(objects []Body, i int) {
+	offset := Subtract(objects[i].Position, w.Arena.Centre)
+	distance := offset.Magnitude()
+	if distance+objects[i].Size() <= w.Arena.Radius {
+		return
+	}
+	outward := Vector{Y: 1}
+	if distance > 0 {
+		outward = ScalarMult(offset, 1/distance)
+	}
+	rim := Add(w.Arena.Centre, ScalarMult(outward, w.Arena.Radius))
+	w.keepInside(objects, i, rim, ScalarMult(outward, -1))
+}
+
+// constrainToPolygon keeps ball i inside a polygonal arena. A ball that has
+// left is first brought back through the nearest side, then every side it
+// still overlaps pushes it away, so it settles into corners properly.
+func (w *World) constrainToPolygon(objects []Body, i int) {
+	a := &w.Arena
+	currBall := &objects[i]
+	if !a.Contains(currBall.Position) {
+		nearest, closest := 0, Vector{}
+		best := math.Inf(1)
+		for k := range a.vertices {
+			p := a.edge(k).Closest(currBall.Position)
+			gap := Subtract(p, currBall.Position)
+			if d := gap.MagnitudeSquared(); d < best {
+				nearest, closest, best = k, p, d
+			}
+		}
+		w.keepInside(objects, i, closest, a.inward(nearest))
+	}
+
+	for k := range a.vertices {
+		closest := a.edge(k).Closest(currBall.Position)
+		offset := Subtract(currBall.Position, closest)
+		distance := offset.Magnitude()
+		if distance >= currBall.Size() {
+			continue
+		}
+		normal := a.inward(k)
+		if distance > 0 {
+			normal = ScalarMult(offset, 1/distance)
+		}
+		w.keepInside(objects, i, closest, normal)
+	}
+}
+
+// keepInside puts ball i a radius in from the wall point closest along the
+// inward normal, and reflects its velocity about the wall if it was moving
+// out.
+func (w *World) keepInside(objects []Body, i int, closest, normal Vector) {
+	currBall := &objects[i]
+	currBall.Position = Add(closest, ScalarMult(normal, currBall.Size()))
+
+	approach := DotProduct(currBall.Velocity, normal)
+	if approach >= 0 {
+		return
+	}
+	currBall.Velocity = Subtract(currBall.Velocity, ScalarMult(normal, (1+w.restitution(currBall, -approach))*approach))
+	w.impacts = append(w.impacts, w.obstacleImpact(objects, i, closest, -approach))
+}
@@ -0,0 +1,165 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCircleArenaReflects fires a ball at a round wall off its normal and
+// checks the bounce keeps the speed along the wall and reverses the speed
+// into it.
+func TestCircleArenaReflects(t *testing.T) {
+	centre := Vector{X: 320, Y: 240}
+	w := NewWorld([]Body{{
+		Position: Vector{X: 320 + 175, Y: 240},
+		Velocity: Vector{X: 8, Y: 3},
+	}}, Vector{}, WithCircleArena(centre, 200))
+	defer w.Close()
+
+	w.Step()
+	b := w.Snapshot()[0]
+	offset := Subtract(b.Position, centre)
+	if distance := offset.Magnitude(); math.Abs(distance-(200-BallRadius)) > epsilon {
+		t.Fatalf("ball %v from the centre, want it resting against the wall at %v", distance, 200-BallRadius)
+	}
+	normal := UnitVector(offset)
+	tangent := Vector{X: -normal.Y, Y: normal.X}
+	before := Vector{X: 8, Y: 3}
+	if got, want := DotProduct(b.Velocity, normal), -DotProduct(before, normal); math.Abs(got-want) > epsilon {
+		t.Errorf("normal speed %v, want %v", got, want)
+	}
+	if got, want := DotProduct(b.Velocity, tangent), DotProduct(before, tangent); math.Abs(got-want) > epsilon {
+		t.Errorf("tangential speed %v, want %v", got, want)
+	}
+}
+
+// TestPolygonArenaContains checks the crossing test on a concave polygon,
+// whichever way round its vertices go.
+func TestPolygonArenaContains(t *testing.T) {
+	// An L shape with its notch in the top right
+	vertices := []Vector{{X: 0, Y: 0}, {X: 100, Y: 0}, {X: 100, Y: 100}, {X: 200, Y: 100}, {X: 200, Y: 200}, {X: 0, Y: 200}}
+	reversed := []Vector{{X: 0, Y: 200}, {X: 200, Y: 200}, {X: 200, Y: 100}, {X: 100, Y: 100}, {X: 100, Y: 0}, {X: 0, Y: 0}}
+	tests := []struct {
+		p    Vector
+		want bool
+	}{
+		{Vector{X: 50, Y: 50}, true},
+		{Vector{X: 150, Y: 150}, true},
+		{Vector{X: 150, Y: 50}, false},
+		{Vector{X: 250, Y: 150}, false},
+	}
+	for _, vs := range [][]Vector{vertices, reversed} {
+		w := NewWorld(nil, Vector{}, WithPolygonArena(vs...))
+		for _, tt := range tests {
+			if got := w.Arena.Contains(tt.p); got != tt.want {
+				t.Errorf("contains(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		}
+		// Every side's inward normal points at the L's middle
+		for k := range vs {
+			side := w.Arena.edge(k)
+			midpoint := ScalarMult(Add(side.A, side.B), 0.5)
+			if !w.Arena.Contains(Add(midpoint, w.Arena.inward(k))) {
+				t.Errorf("side %d inward normal %v points out", k, w.Arena.inward(k))
+			}
+		}
+		w.Close()
+	}
+}
+
+// TestTorusCollidesAcrossSeam sends a ball out of the left edge towards
+// one near the right, and checks it wraps round and knocks into it.
+func TestTorusCollidesAcrossSeam(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 10, Y: 200}, Velocity: Vector{X: -3}},
+		{Position: Vector{X: ScreenWidth - 80, Y: 200}, Velocity: Vector{X: 1}},
+	}, Vector{}, WithTorusArena())
+	defer w.Close()
+
+	for tick := 0; tick < 20; tick++ {
+		w.Step()
+	}
+	objects := w.Snapshot()
+	if objects[0].Velocity.X <= 0 || objects[1].Velocity.X >= 0 {
+		t.Fatalf("velocities %v and %v, want the balls to have bounced apart", objects[0].Velocity, objects[1].Velocity)
+	}
+	for i, b := range objects {
+		if b.Position.X < 0 || b.Position.X >= ScreenWidth {
+			t.Errorf("ball %d at %v, off the torus", i, b.Position)
+		}
+	}
+}
+
+// TestOpenArenaRemovesEscaped checks a ball is kept until it is past the
+// margin and removed after.
+func TestOpenArenaRemovesEscaped(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 320, Y: 240}},
+		{Position: Vector{X: ScreenWidth + 10, Y: 240}, Velocity: Vector{X: 4}},
+	}, Vector{}, WithOpenArena(20))
+	defer w.Close()
+
+	w.Step()
+	if got := len(w.Snapshot()); got != 2 {
+		t.Fatalf("%d bodies with the ball inside the margin, want 2", got)
+	}
+	w.Step()
+	w.Step()
+	if got := len(w.Snapshot()); got != 1 {
+		t.Fatalf("%d bodies with the ball past the margin, want 1", got)
+	}
+}
+
+// TestArenaExtent checks each arena's extent is the box it takes up in the
+// world, whatever the screen.
+func TestArenaExtent(t *testing.T) {
+	tests := []struct {
+		name   string
+		option WorldOption
+		want   AABB
+	}{
+		{"box", func(*World) {}, AABB{Max: Vector{X: ScreenWidth, Y: ScreenHeight}}},
+		{"sized box", WithBoxArena(1600, 900), AABB{Max: Vector{X: 1600, Y: 900}}},
+		{"circle", WithCircleArena(Vector{X: 300, Y: 200}, 150), AABB{Min: Vector{X: 150, Y: 50}, Max: Vector{X: 450, Y: 350}}},
+		{"polygon", WithPolygonArena(Vector{X: -100, Y: 0}, Vector{X: 960, Y: 40}, Vector{X: 500, Y: 720}), AABB{Min: Vector{X: -100}, Max: Vector{X: 960, Y: 720}}},
+	}
+	for _, tt := range tests {
+		w := NewWorld(nil, Vector{}, tt.option)
+		if got := w.Arena.Extent(); got != tt.want {
+			t.Errorf("%s arena extent %v, want %v", tt.name, got, tt.want)
+		}
+		w.Close()
+	}
+}
+
+// TestBoxArenaSize throws balls at the far walls of a box bigger than the
+// screen, one past where the screen's would be, and checks they bounce
+// off the box's own walls, and that Validate wants a box with room in it.
+func TestBoxArenaSize(t *testing.T) {
+	objects := []Body{
+		{Position: Vector{X: 1500, Y: 450}, Velocity: Vector{X: 8}},
+		{Position: Vector{X: 800, Y: 850}, Velocity: Vector{Y: 8}},
+	}
+	w := NewWorld(objects, Vector{}, WithBoxArena(1600, 900))
+	defer w.Close()
+	if problems := w.Validate(); len(problems) > 0 {
+		t.Fatalf("balls inside the box reported: %v", problems)
+	}
+	for range 20 {
+		w.Step()
+	}
+
+	right, bottom := w.Snapshot()[0], w.Snapshot()[1]
+	if right.Velocity.X >= 0 || right.Position.X+right.Size() > 1600 || right.Position.X < 1400 {
+		t.Errorf("ball thrown right is at %v moving %v, want it back off the wall at 1600", right.Position, right.Velocity)
+	}
+	if bottom.Velocity.Y >= 0 || bottom.Position.Y+bottom.Size() > 900 || bottom.Position.Y < 700 {
+		t.Errorf("ball thrown down is at %v moving %v, want it back off the floor at 900", bottom.Position, bottom.Velocity)
+	}
+
+	flat := NewWorld(nil, Vector{}, WithBoxArena(800, 0))
+	defer flat.Close()
+	if problems := flat.Validate(); len(problems) != 1 {
+		t.Errorf("box with no height gave %d problems, want 1: %v", len(problems), problems)
+	}
+}
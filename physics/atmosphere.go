@@ -0,0 +1,125 @@
+package physics
+
+import "math"
+
+// DragModel is how the air's drag grows with a ball's speed.
+type DragModel int
+
+const (
+	// QuadraticDrag grows with the square of the speed, as air's does on
+	// anything fast or large, the default
+	QuadraticDrag DragModel = iota
+	// LinearDrag grows in proportion to the speed, as it does on something
+	// slow and small through syrupy air
+	LinearDrag
+)
+
+// atmosphere is air for the bodies to fly through. Its drag slows a ball
+// in proportion to the density of the air around it and to its speed or
+// the square of it, depending on the model, and heavy balls feel it less.
+// The density falls off exponentially with height above the floor, the
+// bottom of the arena, shrinking by a factor of e every scaleHeight
+// world units. A zero value is a vacuum.
+type atmosphere struct {
+	density     float64
+	scaleHeight float64
+	model       DragModel
+}
+
+// WithAtmosphere fills the world with air of the given density at the
+// floor, thinning with height over scaleHeight units, or the same at
+// every height if scaleHeight is zero. A ball of unit mass moving at one
+// unit per tick through air of density 1 loses a unit per tick of speed
+// every tick. Its drag grows with the square of the speed.
+func WithAtmosphere(density, scaleHeight float64) WorldOption {
+	return func(w *World) {
+		w.atmosphere = atmosphere{density: density, scaleHeight: scaleHeight}
+	}
+}
+
+// WithDrag fills the world with air of the same density at every height,
+// whose drag grows with speed as model says, so a falling ball stops
+// speeding up at its terminal speed.
+func WithDrag(model DragModel, density float64) WorldOption {
+	return func(w *World) {
+		w.atmosphere = atmosphere{density: density, model: model}
+	}
+}
+
+func (a atmosphere) enabled() bool {
+	return a.density > 0
+}
+
+// densityAt returns the density of the air at height above the floor.
+func (a atmosphere) densityAt(height float64) float64 {
+	if a.scaleHeight <= 0 {
+		return a.density
+	}
+	return a.density * math.Exp(-height/a.scaleHeight)
+}
+
+// apply runs the air's drag on a ball for dt ticks, in air densest at
+// floor, the height of the bottom of the arena. The drag is taken
+// against the speed at the end of the interval rather than the start, so
+// however dense the air it slows the ball without ever turning it round.
+func (a atmosphere) apply(currBall *Body, floor, dt float64) {
+	drag := a.densityAt(floor-currBall.Position.Y) * currBall.dragCoefficient() * currBall.inverseMass() * dt
+	if a.model == QuadraticDrag {
+		// The speed it ends at, s, solves s(1 + drag s) = the speed it starts
+		// at, which this form of the root gives without cancelling away
+		speed := currBall.Velocity.Magnitude()
+		drag *= 2 * speed / (1 + math.Sqrt(1+4*drag*speed))
+	}
+	currBall.Velocity = ScalarMult(currBall.Velocity, 1/(1+drag))
+}
+
+// dragCoefficient returns how hard the air pulls on the ball. Balls
+// without a drag of their own all feel the air alike.
+func (b *Body) dragCoefficient() float64 {
+	if b.Drag == 0 {
+		return 1
+	}
+	return b.Drag
+}
+
+// TerminalSpeed returns the speed body i stops speeding up at falling
+// through the air at the floor, where drag matches gravity, or infinity in
+// a vacuum or without gravity to fall under.
+func (w *World) TerminalSpeed(i int) float64 {
+	objects := w.Snapshot()
+	pull := w.gravity.Magnitude()
+	if i >= len(objects) || !w.atmosphere.enabled() || pull == 0 {
+		return math.Inf(1)
+	}
+	b := &objects[i]
+	// Gravity pulls with the weight, the air back with density times drag
+	// times the speed, or its square
+	speed := pull * b.mass() / (w.atmosphere.densityAt(0) * b.dragCoefficient())
+	if w.atmosphere.model == QuadraticDrag {
+		return math.Sqrt(speed)
+	}
+	return speed
+}
+
+// VacuumPath returns where body i would go from now if the world had no
+// air, up to the first thing it hits or at most steps ticks.
+func (w *World) VacuumPath(i, steps int) []Vector {
+	if i >= len(w.Snapshot()) {
+		return nil
+	}
+	p := w.preview()
+	defer p.Close()
+	p.atmosphere = atmosphere{}
+
+	path := make([]Vector, 0, steps)
+	for range steps {
+		p.Step()
+		path = append(path, p.Snapshot()[i].Position)
+		for _, hit := range p.impacts {
+			if hit.A == i || hit.B == i {
+				return path
+			}
+		}
+	}
+	return path
+}
@@ -0,0 +1,69 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestAirThinsWithHeight checks the air's density drops by a factor of e
+// every scale height above the floor, and is even without one.
+func TestAirThinsWithHeight(t *testing.T) {
+	a := atmosphere{density: 0.01, scaleHeight: 100}
+	if got := a.densityAt(0); math.Abs(got-0.01) > 1e-12 {
+		t.Errorf("density at the floor = %v, want 0.01", got)
+	}
+	if got := a.densityAt(200); math.Abs(got-0.01/math.E/math.E) > 1e-12 {
+		t.Errorf("density two scale heights up = %v, want %v", got, 0.01/math.E/math.E)
+	}
+	if got := (atmosphere{density: 0.01}).densityAt(0); got != 0.01 {
+		t.Errorf("uniform density at the top = %v, want 0.01", got)
+	}
+}
+
+// TestDragSlowsHeavyBallsLess throws balls through thick air and checks
+// they slow without turning round, the heavy one least.
+func TestDragSlowsHeavyBallsLess(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 100, Y: 200}, Velocity: Vector{X: 5}},
+		{Position: Vector{X: 100, Y: 300}, Velocity: Vector{X: 5}, Mass: 10},
+	}, Vector{}, WithAtmosphere(1, 0))
+	defer w.Close()
+	w.Step()
+
+	light, heavy := w.Snapshot()[0].Velocity, w.Snapshot()[1].Velocity
+	if light.X <= 0 || light.X >= heavy.X || heavy.X >= 5 {
+		t.Errorf("after a step through the air light ball at %.3f, heavy at %.3f, want 0 < light < heavy < 5", light.X, heavy.X)
+	}
+}
+
+// TestFallingBallsReachTerminalSpeed drops balls through air of either
+// model on a world that wraps round, so they fall for good, and checks
+// each stops speeding up at its terminal speed, a draggier ball sooner.
+func TestFallingBallsReachTerminalSpeed(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		model   DragModel
+		density float64
+	}{
+		{"quadratic", QuadraticDrag, 0.01},
+		{"linear", LinearDrag, 0.05},
+	} {
+		w := NewWorld([]Body{
+			{Position: Vector{X: 100, Y: 100}},
+			{Position: Vector{X: 300, Y: 100}, Drag: 4},
+		}, Vector{Y: 0.3}, WithDrag(c.model, c.density), WithTorusArena())
+		for range 1000 {
+			w.Step()
+		}
+		for i, b := range w.Snapshot() {
+			want := w.TerminalSpeed(i)
+			if got := b.Velocity.Magnitude(); math.Abs(got-want) > 1e-3*want {
+				t.Errorf("%s: ball %d falling at %.4f, want its terminal speed %.4f", c.name, i, got, want)
+			}
+		}
+		if slow, fast := w.TerminalSpeed(1), w.TerminalSpeed(0); slow >= fast {
+			t.Errorf("%s: draggy ball's terminal speed %.3f, want below %.3f", c.name, slow, fast)
+		}
+		w.Close()
+	}
+}
@@ -0,0 +1,164 @@
+package physics
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	// bandSpacing is how far apart, in world units, the particles of a band
+	// start
+	bandSpacing = 8
+	// bandInverseMass makes each particle a tenth of a unit-mass ball
+	bandInverseMass = 10
+	// bandThickness is how far a band reaches beyond its particles
+	bandThickness = 2
+	// bandIterations is how many times a band is moved per substep, since
+	// its light particles need shorter steps than the balls
+	bandIterations = 4
+	// bandSmoothing is how many pieces each link is drawn with
+	bandSmoothing = 4
+)
+
+// Band is a closed loop of light particles joined by springs, like a
+// rubber band. The springs only pull, so a slack band goes limp, and a
+// stretched one squeezes whatever it is wrapped around.
+type Band struct {
+	positions  []Vector
+	velocities []Vector
+	// rest is the length each link pulls back to
+	rest      float64
+	stiffness float64
+	damping   float64
+}
+
+// WithBand loops a rubber band round a circle of the given radius. slack
+// is its length at rest as a share of the circle's, so below one it starts
+// off stretched.
+func WithBand(centre Vector, radius, slack float64) WorldOption {
+	return func(w *World) {
+		count := max(3, int(2*math.Pi*radius/bandSpacing))
+		b := &Band{
+			positions:  make([]Vector, count),
+			velocities: make([]Vector, count),
+			rest:       slack * 2 * math.Pi * radius / float64(count),
+			stiffness:  0.05,
+			damping:    0.05,
+		}
+		for k := range b.positions {
+			angle := 2 * math.Pi * float64(k) / float64(count)
+			b.positions[k] = Add(centre, Vector{X: radius * math.Cos(angle), Y: radius * math.Sin(angle)})
+		}
+		w.Bands = append(w.Bands, b)
+	}
+}
+
+// stretchBands moves every band on by dt ticks and lets it push against
+// the balls it touches. It runs serially, after the contacts.
+func (w *World) stretchBands(objects []Body, dt float64) {
+	step := dt / bandIterations
+	for _, b := range w.Bands {
+		for range bandIterations {
+			b.pull(w.gravity, step)
+			for k := range b.positions {
+				b.positions[k] = Add(b.positions[k], ScalarMult(b.velocities[k], step))
+				w.pressBand(objects, b, k)
+			}
+		}
+	}
+}
+
+// pull speeds each particle up by gravity and by the springs either side
+// of it over dt ticks.
+func (b *Band) pull(gravity Vector, dt float64) {
+	for k := range b.velocities {
+		b.velocities[k] = Add(b.velocities[k], ScalarMult(gravity, dt))
+	}
+	for k := range b.positions {
+		next := (k + 1) % len(b.positions)
+		offset := Subtract(b.positions[next], b.positions[k])
+		length := offset.Magnitude()
+		if length <= b.rest {
+			continue
+		}
+		along := ScalarMult(offset, 1/length)
+		parting := DotProduct(Subtract(b.velocities[next], b.velocities[k]), along)
+		tension := b.stiffness*(length-b.rest) + b.damping*parting
+		change := ScalarMult(along, tension*bandInverseMass*dt)
+		b.velocities[k] = Add(b.velocities[k], change)
+		b.velocities[next] = Subtract(b.velocities[next], change)
+	}
+}
+
+// pressBand pushes particle k of a band and every ball it overlaps apart,
+// by their shares of inverse mass, and stops them closing on each other.
+// In a box arena the particle is kept inside too.
+func (w *World) pressBand(objects []Body, b *Band, k int) {
+	p, v := &b.positions[k], &b.velocities[k]
+	pad := w.broadphase.reach() + bandThickness
+	corner := Vector{X: pad, Y: pad}
+	w.bandQuery = w.broadphase.query(Subtract(*p, corner), Add(*p, corner), w.bandQuery[:0])
+	for _, i := range w.bandQuery {
+		if i >= len(objects) {
+			continue
+		}
+		currBall := &objects[i]
+		reach := currBall.Size() + bandThickness
+		offset := Subtract(*p, currBall.Position)
+		distance := offset.Magnitude()
+		if distance >= reach || distance == 0 {
+			continue
+		}
+		normal := ScalarMult(offset, 1/distance)
+		invMass := currBall.inverseMass()
+		share := bandInverseMass / (bandInverseMass + invMass)
+
+		overlap := reach - distance
+		*p = Add(*p, ScalarMult(normal, overlap*share))
+		currBall.Position = Subtract(currBall.Position, ScalarMult(normal, overlap*(1-share)))
+
+		closing := DotProduct(Subtract(*v, currBall.Velocity), normal)
+		if closing < 0 {
+			*v = Subtract(*v, ScalarMult(normal, closing*share))
+			currBall.Velocity = Add(currBall.Velocity, ScalarMult(normal, closing*(1-share)))
+		}
+	}
+
+	if size := w.Arena.size(); w.Arena.Shape == BoxArena {
+		if p.X < 0 || p.X > size.X {
+			p.X = math.Max(0, math.Min(size.X, p.X))
+			v.X = 0
+		}
+		if p.Y < 0 || p.Y > size.Y {
+			p.Y = math.Max(0, math.Min(size.Y, p.Y))
+			v.Y = 0
+		}
+	}
+}
+
+// Curve returns points along a smooth closed curve through the band's
+// particles, a Catmull-Rom spline closed back to the first point.
+func (b *Band) Curve(dst []Vector) []Vector {
+	n := len(b.positions)
+	for k := range n {
+		p0, p1 := b.positions[(k+n-1)%n], b.positions[k]
+		p2, p3 := b.positions[(k+1)%n], b.positions[(k+2)%n]
+		for s := range bandSmoothing {
+			t := float64(s) / bandSmoothing
+			t2, t3 := t*t, t*t*t
+			// The spline's weights on each of the four points around t
+			point := Add(Add(ScalarMult(p0, -0.5*t3+t2-0.5*t), ScalarMult(p1, 1.5*t3-2.5*t2+1)),
+				Add(ScalarMult(p2, -1.5*t3+2*t2+0.5*t), ScalarMult(p3, 0.5*t3-0.5*t2)))
+			dst = append(dst, point)
+		}
+	}
+	return append(dst, b.positions[0])
+}
+
+// clone returns a copy of the band that can be moved separately.
+func (b *Band) clone() *Band {
+	c := *b
+	c.positions = slices.Clone(b.positions)
+	c.velocities = slices.Clone(b.velocities)
+	return &c
+}
@@ -0,0 +1,38 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestStretchedBandBundlesBalls loops a stretched band round a ring of
+// balls in empty space and checks it squeezes them in against the middle
+// one, while a slack band leaves them where they are.
+func TestStretchedBandBundlesBalls(t *testing.T) {
+	centre := Vector{X: ScreenWidth / 2, Y: ScreenHeight / 2}
+	spread := func(slack float64) float64 {
+		objects := []Body{{Position: centre}}
+		for k := 0; k < 6; k++ {
+			objects = append(objects, Body{Position: Add(centre, RotateBy(Vector{X: 60}, float64(k)*math.Pi/3))})
+		}
+		w := NewWorld(objects, Vector{}, WithBand(centre, 110, slack))
+		defer w.Close()
+		for tick := 0; tick < 600; tick++ {
+			w.Step()
+		}
+
+		furthest := 0.0
+		for _, b := range w.Snapshot() {
+			offset := Subtract(b.Position, centre)
+			furthest = max(furthest, offset.Magnitude())
+		}
+		return furthest
+	}
+
+	if got := spread(0.4); got > 2*BallRadius+1 {
+		t.Errorf("stretched band left a ball %.1f from the middle, want them touching it", got)
+	}
+	if got := spread(1.5); got < 59 || got > 61 {
+		t.Errorf("slack band moved the balls to %.1f from the middle, want them left at 60", got)
+	}
+}
@@ -0,0 +1,83 @@
+package physics
+
+import "slices"
+
+// contactBatches splits a substep's contacts into batches, none holding
+// two contacts on the same body, so every contact in a batch can be worked
+// on at once. Each contact goes in the batch straight after the last one
+// holding either of its bodies, so every body still meets its contacts in
+// the order they were found, and working through the batches in turn
+// comes out exactly as working through the contacts one at a time does,
+// however many workers share them.
+type contactBatches struct {
+	// order lists the contacts batch by batch, in the order they were
+	// found within each, and ends holds where each batch ends in it
+	order []int
+	ends  []int
+	// after is one past the batch each body was last seen in, batch the
+	// batch of each contact and next where each batch fills order from
+	after []int
+	batch []int
+	next  []int
+}
+
+// split batches the contacts between n bodies.
+func (b *contactBatches) split(contacts []Contact, n int) {
+	b.after = slices.Grow(b.after[:0], n)[:n]
+	clear(b.after)
+	b.batch = b.batch[:0]
+	b.ends = b.ends[:0]
+	for _, c := range contacts {
+		k := max(b.after[c.A], b.after[c.B])
+		b.after[c.A], b.after[c.B] = k+1, k+1
+		b.batch = append(b.batch, k)
+		if k == len(b.ends) {
+			b.ends = append(b.ends, 0)
+		}
+		b.ends[k]++
+	}
+
+	// Turn the batches' sizes into where each ends, then fill them in
+	b.next = append(b.next[:0], 0)
+	for k := range b.ends {
+		if k > 0 {
+			b.ends[k] += b.ends[k-1]
+		}
+		b.next = append(b.next, b.ends[k])
+	}
+	b.order = slices.Grow(b.order[:0], len(contacts))[:len(contacts)]
+	for i, k := range b.batch {
+		b.order[b.next[k]] = i
+		b.next[k]++
+	}
+}
+
+// batchesContacts reports whether this substep's contacts are many enough
+// to split across the pool.
+func (w *World) batchesContacts() bool {
+	return w.pool.size > 1 && len(w.contacts) >= parallelThreshold
+}
+
+// eachContact calls fn with the index of every contact, batch by batch,
+// the contacts of each batch split across the pool. No two contacts on a
+// body are worked on at once, so fn may change the two bodies of its
+// contact freely. Too few contacts to be worth splitting are worked
+// through in order on the calling goroutine instead, to the same result.
+func (w *World) eachContact(fn func(k int)) {
+	if !w.batchesContacts() {
+		for k := range w.contacts {
+			fn(k)
+		}
+		return
+	}
+	start := 0
+	for _, end := range w.batches.ends {
+		batch := w.batches.order[start:end]
+		w.pool.parallelFor(len(batch), func(_, from, to int) {
+			for _, k := range batch[from:to] {
+				fn(k)
+			}
+		})
+		start = end
+	}
+}
@@ -0,0 +1,135 @@
+// Package physics simulates balls moving in an arena, by default a box the
+// size of the screen: vector maths, bodies and the world that steps them,
+// with everything that can act on them along the way. It draws nothing;
+// the simulator in cmd/sim is one way to show it.
+package physics
+
+import "image/color"
+
+// The world is a box ScreenWidth by ScreenHeight world units unless its
+// arena says otherwise, and a ball without a radius of its own is
+// BallRadius from its middle to its edge. A unit is drawn as a pixel
+// unzoomed, but the sim fits the world to whatever window it has.
+const (
+	ScreenWidth  = 640
+	ScreenHeight = 480
+	BallRadius   = 20
+)
+
+// TicksPerSecond is how many steps make a second of simulation time at
+// normal speed, matching Ebiten's default tick rate.
+const TicksPerSecond = 60
+
+// Body is one ball in the world. The zero value is a unit-mass rubber ball of
+// BallRadius at rest at the origin.
+type Body struct {
+	Position Vector
+	Velocity Vector
+
+	// Spin is only used on a cloth: x and y are the velocity the ball's
+	// spin would roll it at, z is side spin about the vertical axis
+	Spin Vector
+
+	// Mass is how hard the ball is to push and how strongly it pulls
+	// on others under attraction; a ball with zero pulls on nothing and is
+	// pushed like a unit mass
+	Mass float64
+	// Radius is how far the ball reaches from its middle, in world units; zero
+	// makes it BallRadius
+	Radius float64
+	// Restitution scales how much speed the ball keeps off walls, static
+	// geometry and other balls once HasRestitution is set, zero then
+	// keeping none; unset, the ball is left to its material alone
+	Restitution    float64
+	HasRestitution bool
+	// Grip is the friction between the ball and walls, static geometry or
+	// other gripping balls, which rolls it along them and lets its spin
+	// drive it, as a tyre grips a road, and sets it spinning when it is
+	// struck a glancing blow; zero lets it slide
+	Grip float64
+	// Drag scales how hard the air pulls on the ball, as its shape would;
+	// zero leaves it at one
+	Drag float64
+	// SpeedLimit caps the ball's speed, in world units per tick, below any
+	// limit the world sets; zero leaves it to the world
+	SpeedLimit float64
+	Material   Material
+	// Name is shown over the ball when labels are on; it may be empty
+	Name string
+	// Color, if set, is what the ball is drawn in, over any colour its
+	// scene gives it
+	Color color.RGBA
+	// Expires is the world step the ball fades out and is removed at;
+	// zero keeps it for good
+	Expires uint64
+
+	// Moment is the ball's magnetic dipole, pointing from its south
+	// pole to its north; zero leaves it unmagnetised
+	Moment Vector
+	// AngularVelocity is how fast the ball turns, in radians per tick
+	// clockwise on screen, carrying its moment round with it, and Angle
+	// how far it has turned
+	AngularVelocity float64
+	Angle           float64
+
+	// Flock is which flock the ball flies with under flocking; zero
+	// means none
+	Flock int
+
+	// Frozen pins the ball in place until it is thawed
+	Frozen bool
+}
+
+// NewBall returns a ball at position moving at velocity, with the given
+// mass and radius.
+func NewBall(position, velocity Vector, mass, radius float64) Body {
+	return Body{Position: position, Velocity: velocity, Mass: mass, Radius: radius}
+}
+
+// Size returns the ball's radius. Balls without one are all BallRadius.
+func (b *Body) Size() float64 {
+	if b.Radius == 0 {
+		return BallRadius
+	}
+	return b.Radius
+}
+
+// turn rotates the ball clockwise by angle, carrying its moment round.
+func (b *Body) turn(angle float64) {
+	b.Angle += angle
+	b.Moment = RotateBy(b.Moment, angle)
+}
+
+// Strike sets a body's velocity and spin in the published state, as a cue
+// does between steps. It must not be called while a step runs.
+func (w *World) Strike(i int, velocity, spin Vector) {
+	objects := w.front.Load().objects
+	if i >= len(objects) {
+		return
+	}
+	objects[i].Velocity = velocity
+	objects[i].Spin = spin
+}
+
+// Teleport moves a body to position with a new velocity, keeping the rest
+// of its state. It must not be called while a step runs.
+func (w *World) Teleport(i int, position, velocity Vector) {
+	objects := w.front.Load().objects
+	if i >= len(objects) {
+		return
+	}
+	objects[i].Position = position
+	objects[i].Velocity = velocity
+	w.refile()
+}
+
+// Place moves a body to position and stops it. It must not be called
+// while a step runs.
+func (w *World) Place(i int, position Vector) {
+	objects := w.front.Load().objects
+	if i >= len(objects) {
+		return
+	}
+	objects[i] = Body{Position: position}
+	w.refile()
+}
@@ -0,0 +1,223 @@
+package physics
+
+import "math"
+
+// cellKey identifies one square of the broadphase grid.
+type cellKey struct {
+	x int
+	y int
+}
+
+// pair is a candidate collision between two bodies, always with a < b.
+type pair struct {
+	a int
+	b int
+}
+
+// aabbMargin is how far a body's fat bounding box reaches beyond the body
+// itself, so it can wander that far before the box needs refitting.
+const aabbMargin = BallRadius / 4
+
+// AABB is an axis-aligned bounding box from min to max.
+type AABB struct {
+	Min Vector
+	Max Vector
+}
+
+// ballBox returns the tightest box around a ball.
+func ballBox(b *Body) AABB {
+	reach := Vector{X: b.Size(), Y: b.Size()}
+	return AABB{Min: Subtract(b.Position, reach), Max: Add(b.Position, reach)}
+}
+
+// fattened returns the box grown by margin on every side.
+func (a AABB) fattened(margin float64) AABB {
+	pad := Vector{X: margin, Y: margin}
+	return AABB{Min: Subtract(a.Min, pad), Max: Add(a.Max, pad)}
+}
+
+// contains reports whether b lies wholly inside a.
+func (a AABB) contains(b AABB) bool {
+	return b.Min.X >= a.Min.X && b.Min.Y >= a.Min.Y && b.Max.X <= a.Max.X && b.Max.Y <= a.Max.Y
+}
+
+// broadphase is a uniform grid that persists between steps. Every body
+// remembers the cell it was filed under, so an update only touches the
+// bodies that actually crossed into a new cell; resting or slow bodies
+// cost a single comparison. It also keeps a fat bounding box round every
+// body, refitted only once the body pokes out of it. Cells are as wide as
+// the largest ball, or as WithCellSize sets if that is wider, and the grid
+// is rebuilt coarser if a larger ball arrives.
+type broadphase struct {
+	cellSize float64
+	cells    map[cellKey][]int
+	bodyCell []cellKey
+	boxes    []AABB
+	// wrap is how many cells across and down the grid repeats after, or
+	// zero if it doesn't, and wrapSize the width and height it repeats
+	// over
+	wrap     cellKey
+	wrapSize Vector
+}
+
+func newBroadphase(cellSize float64) *broadphase {
+	return &broadphase{
+		cellSize: cellSize,
+		cells:    make(map[cellKey][]int),
+	}
+}
+
+func (b *broadphase) cellFor(p Vector) cellKey {
+	return cellKey{
+		x: int(math.Floor(p.X / b.cellSize)),
+		y: int(math.Floor(p.Y / b.cellSize)),
+	}
+}
+
+// wrapAround makes the grid repeat every width by height, so bodies near
+// opposite edges are paired as neighbours.
+func (b *broadphase) wrapAround(width, height float64) {
+	b.wrapSize = Vector{X: width, Y: height}
+	b.wrap = cellKey{
+		x: int(math.Ceil(width / b.cellSize)),
+		y: int(math.Ceil(height / b.cellSize)),
+	}
+}
+
+// fileFor returns the cell a body at p is filed under, which on a wrapped
+// grid is always within its repeat.
+func (b *broadphase) fileFor(p Vector) cellKey {
+	return b.wrapped(b.cellFor(p))
+}
+
+func (b *broadphase) wrapped(cell cellKey) cellKey {
+	if b.wrap.x > 0 {
+		cell.x = ((cell.x % b.wrap.x) + b.wrap.x) % b.wrap.x
+		cell.y = ((cell.y % b.wrap.y) + b.wrap.y) % b.wrap.y
+	}
+	return cell
+}
+
+// reach returns the radius of the largest ball the grid is sized for.
+func (b *broadphase) reach() float64 {
+	return b.cellSize / 2
+}
+
+// update refiles every body whose cell changed since the last update.
+func (b *broadphase) update(objects []Body) {
+	// Bodies were removed, indices are no longer meaningful
+	if len(objects) < len(b.bodyCell) {
+		b.reset()
+	}
+
+	// A ball wider than a cell could touch one two cells away, so the grid
+	// is coarsened to fit it and everything refiled
+	widest := b.cellSize
+	for i := range objects {
+		widest = max(widest, 2*objects[i].Size())
+	}
+	if widest > b.cellSize {
+		b.resize(widest)
+	}
+
+	for i := range b.bodyCell {
+		cell := b.fileFor(objects[i].Position)
+		if cell != b.bodyCell[i] {
+			b.remove(i, b.bodyCell[i])
+			b.insert(i, cell)
+		}
+		if tight := ballBox(&objects[i]); !b.boxes[i].contains(tight) {
+			b.boxes[i] = tight.fattened(aabbMargin)
+		}
+	}
+
+	// File any bodies added since the last update
+	for i := len(b.bodyCell); i < len(objects); i++ {
+		b.bodyCell = append(b.bodyCell, cellKey{})
+		b.boxes = append(b.boxes, ballBox(&objects[i]).fattened(aabbMargin))
+		b.insert(i, b.fileFor(objects[i].Position))
+	}
+}
+
+// resize sets how wide the grid's cells are and refiles every body.
+func (b *broadphase) resize(cellSize float64) {
+	b.cellSize = cellSize
+	if b.wrap.x > 0 {
+		b.wrapAround(b.wrapSize.X, b.wrapSize.Y)
+	}
+	b.reset()
+}
+
+func (b *broadphase) reset() {
+	clear(b.cells)
+	b.bodyCell = b.bodyCell[:0]
+	b.boxes = b.boxes[:0]
+}
+
+func (b *broadphase) insert(i int, cell cellKey) {
+	b.cells[cell] = append(b.cells[cell], i)
+	b.bodyCell[i] = cell
+}
+
+func (b *broadphase) remove(i int, cell cellKey) {
+	members := b.cells[cell]
+	for k, member := range members {
+		if member == i {
+			members[k] = members[len(members)-1]
+			members = members[:len(members)-1]
+			break
+		}
+	}
+
+	if len(members) == 0 {
+		delete(b.cells, cell)
+	} else {
+		b.cells[cell] = members
+	}
+}
+
+// pairs appends every pair of bodies sharing a cell or sitting in
+// neighbouring cells, for the bodies with index in [start, end). With cells
+// at least the widest diameter across, no touching pair can be missed.
+// Ranges only read the grid, so several can be collected concurrently.
+func (b *broadphase) pairs(start, end int, dst []pair) []pair {
+	for i := start; i < end; i++ {
+		cell := b.bodyCell[i]
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				for _, j := range b.cells[b.wrapped(cellKey{cell.x + dx, cell.y + dy})] {
+					if j > i {
+						dst = append(dst, pair{a: i, b: j})
+					}
+				}
+			}
+		}
+	}
+	return dst
+}
+
+// query appends the index of every body filed in a cell that overlaps the
+// rectangle from min to max. Bodies are filed by their centre, so callers
+// should pad the rectangle by the largest radius they care about.
+func (b *broadphase) query(min, max Vector, dst []int) []int {
+	lo := b.cellFor(min)
+	hi := b.cellFor(max)
+
+	// A rectangle covering more cells than are occupied is cheaper to
+	// answer by walking the bodies themselves
+	if (hi.x-lo.x+1)*(hi.y-lo.y+1) > len(b.cells) {
+		for i, cell := range b.bodyCell {
+			if cell.x >= lo.x && cell.x <= hi.x && cell.y >= lo.y && cell.y <= hi.y {
+				dst = append(dst, i)
+			}
+		}
+		return dst
+	}
+
+	for x := lo.x; x <= hi.x; x++ {
+		for y := lo.y; y <= hi.y; y++ {
+			dst = append(dst, b.cells[cellKey{x, y}]...)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,69 @@
+package physics
+
+import "math"
+
+// StaticChain is an immovable outline of straight edges joined end to end,
+// for smooth static ground. Separate segments each push a ball out on
+// their own, so one rolling over the seam where two meet can catch on the
+// end of the next and be knocked back; a chain only pushes a ball away
+// from the point on it nearest the ball, so the ball rolls over its seams
+// as over one surface.
+type StaticChain struct {
+	Points []Vector
+	// Loop joins the last point back to the first
+	Loop bool
+	// Before and After, if set, are ghost vertices: where the outline goes
+	// on to past either end, as the chain it joins does. A ball at that end
+	// which is over the ghost edge is left to the chain the edge belongs
+	// to, so it rolls from one chain to the next without catching either.
+	Before *Vector
+	After  *Vector
+}
+
+// WithStaticChains adds chains of edges to the world.
+func WithStaticChains(chains ...StaticChain) WorldOption {
+	return func(w *World) {
+		w.StaticChains = append(w.StaticChains, chains...)
+	}
+}
+
+// edges returns how many edges the chain has.
+func (c StaticChain) edges() int {
+	switch {
+	case len(c.Points) < 2:
+		return 0
+	case c.Loop:
+		return len(c.Points)
+	}
+	return len(c.Points) - 1
+}
+
+// Closest returns the point on the chain nearest to p, and false if there
+// is none or it is an end of the chain that p is over the ghost edge
+// beyond.
+func (c StaticChain) Closest(p Vector) (Vector, bool) {
+	edges := c.edges()
+	if edges == 0 {
+		return Vector{}, false
+	}
+	var closest Vector
+	nearest := math.Inf(1)
+	for k := 0; k < edges; k++ {
+		s := StaticSegment{A: c.Points[k], B: c.Points[(k+1)%len(c.Points)]}
+		point := s.Closest(p)
+		offset := Subtract(p, point)
+		if distance := offset.MagnitudeSquared(); distance < nearest {
+			closest, nearest = point, distance
+		}
+	}
+	if c.Loop {
+		return closest, true
+	}
+	if first := c.Points[0]; c.Before != nil && closest == first && DotProduct(Subtract(p, first), Subtract(*c.Before, first)) > 0 {
+		return Vector{}, false
+	}
+	if last := c.Points[len(c.Points)-1]; c.After != nil && closest == last && DotProduct(Subtract(p, last), Subtract(*c.After, last)) > 0 {
+		return Vector{}, false
+	}
+	return closest, true
+}
@@ -0,0 +1,102 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// floor returns the points of a floor at y from x = 0 to width, every
+// spacing along, each a little lower than the last so each join is a
+// shallow valley.
+func floor(y, width, spacing float64) []Vector {
+	var points []Vector
+	for x := 0.0; x <= width; x += spacing {
+		points = append(points, Vector{X: x, Y: y + x/1000})
+	}
+	return points
+}
+
+// slideAcross slides a ball left along the ground the options build,
+// under gravity, and returns how fast it is still going.
+func slideAcross(t *testing.T, options ...WorldOption) float64 {
+	t.Helper()
+	options = append(options, WithWallRestitution(0.5))
+	w := NewWorld([]Body{{Position: Vector{X: 560, Y: 300 - BallRadius}, Velocity: Vector{X: -3}}}, Vector{Y: .3}, options...)
+	defer w.Close()
+	for i := 0; i < 120; i++ {
+		w.Step()
+	}
+	return -w.Snapshot()[0].Velocity.X
+}
+
+// TestChainRollsOverSeams slides a ball over a floor of short edges, first
+// as separate segments, which knock it back at their joins, then as one
+// chain, which lets it slide on.
+func TestChainRollsOverSeams(t *testing.T) {
+	points := floor(300, ScreenWidth, 20)
+	var segments []StaticSegment
+	for k := 1; k < len(points); k++ {
+		segments = append(segments, StaticSegment{A: points[k-1], B: points[k]})
+	}
+
+	separate := slideAcross(t, WithStaticSegments(segments...))
+	chained := slideAcross(t, WithStaticChains(StaticChain{Points: points}))
+	if math.Abs(chained-3) > 0.1 {
+		t.Errorf("ball slid across the chain at %v, want it still going at 3", chained)
+	}
+	if separate >= chained {
+		t.Errorf("ball slid across separate segments at %v, want them to catch it below the chain's %v", separate, chained)
+	}
+}
+
+// TestGhostVerticesJoinChains chops the floor into short chains end to
+// end and checks the ball slides on over their joins once each has ghost
+// vertices on along its neighbours, where without them it catches.
+func TestGhostVerticesJoinChains(t *testing.T) {
+	points := floor(300, ScreenWidth, 20)
+	var bare, ghosted []StaticChain
+	for k := 0; k+2 < len(points); k += 2 {
+		c := StaticChain{Points: points[k : k+3]}
+		bare = append(bare, c)
+		if k > 0 {
+			c.Before = &points[k-1]
+		}
+		if k+3 < len(points) {
+			c.After = &points[k+3]
+		}
+		ghosted = append(ghosted, c)
+	}
+
+	joined := slideAcross(t, WithStaticChains(ghosted...))
+	if math.Abs(joined-3) > 0.1 {
+		t.Errorf("ball slid across the ghosted chains at %v, want it still going at 3", joined)
+	}
+	if caught := slideAcross(t, WithStaticChains(bare...)); caught >= joined {
+		t.Errorf("ball slid across chains without ghosts at %v, want them to catch it below %v", caught, joined)
+	}
+}
+
+func TestChainClosest(t *testing.T) {
+	before := Vector{X: -10, Y: 0}
+	c := StaticChain{Points: []Vector{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}}, Before: &before}
+	tests := []struct {
+		name string
+		p    Vector
+		want Vector
+		ok   bool
+	}{
+		{"over the first edge", Vector{X: 4, Y: -3}, Vector{X: 4}, true},
+		{"round the corner", Vector{X: 13, Y: -3}, Vector{X: 10}, true},
+		{"past the open end", Vector{X: 12, Y: 14}, Vector{X: 10, Y: 10}, true},
+		{"over the ghost edge", Vector{X: -4, Y: -3}, Vector{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := c.Closest(tt.p)
+			if ok != tt.ok || ok && !vectorsClose(got, tt.want) {
+				t.Errorf("Closest(%v) = %v, %v, want %v, %v", tt.p, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package physics
+
+import "math"
+
+// ClothSettings models the felt of a pool table seen from above. A ball
+// skidding over the cloth feels sliding friction until its spin matches
+// its speed, then only the much weaker rolling resistance. A zero value
+// leaves the cloth disabled.
+type ClothSettings struct {
+	sliding float64
+	rolling float64
+}
+
+// WithCloth lays a cloth under the bodies with the given sliding and
+// rolling decelerations, in world units per tick squared.
+func WithCloth(sliding, rolling float64) WorldOption {
+	return func(w *World) {
+		w.Cloth = ClothSettings{sliding: sliding, rolling: rolling}
+	}
+}
+
+func (c ClothSettings) Enabled() bool {
+	return c.sliding > 0 || c.rolling > 0
+}
+
+// apply runs the cloth's friction on a ball for dt ticks.
+//
+// A solid ball's spin is tracked as the speed its surface would carry it
+// at if it were rolling, so it rolls exactly when Spin matches its
+// velocity. Friction at the cloth acts against the slip between the two,
+// slowing the ball and spinning it up two and a half times as fast, the
+// ratio set by a solid sphere's moment of inertia. Side spin about the
+// vertical axis, in z, wears off at the rolling rate.
+func (c ClothSettings) apply(currBall *Body, dt float64) {
+	velocity := Vector{X: currBall.Velocity.X, Y: currBall.Velocity.Y}
+	spin := Vector{X: currBall.Spin.X, Y: currBall.Spin.Y}
+	slip := Subtract(velocity, spin)
+
+	// Sliding stops once the slip is gone, leaving the ball rolling at the
+	// speed that keeps its angular momentum about the contact point
+	if slip.Magnitude() <= 3.5*c.sliding*dt {
+		rollingVelocity := ScalarMult(Add(ScalarMult(velocity, 5), ScalarMult(spin, 2)), 1.0/7)
+		velocity = slowed(rollingVelocity, c.rolling*dt)
+		spin = velocity
+	} else {
+		friction := ScalarMult(UnitVector(slip), c.sliding*dt)
+		velocity = Subtract(velocity, friction)
+		spin = Add(spin, ScalarMult(friction, 2.5))
+	}
+
+	currBall.Velocity.X, currBall.Velocity.Y = velocity.X, velocity.Y
+	currBall.Spin.X, currBall.Spin.Y = spin.X, spin.Y
+	currBall.Spin.Z = math.Copysign(math.Max(math.Abs(currBall.Spin.Z)-c.rolling*dt, 0), currBall.Spin.Z)
+}
+
+// slowed returns v shortened by amount, stopping at zero rather than
+// reversing.
+func slowed(v Vector, amount float64) Vector {
+	speed := v.Magnitude()
+	if speed <= amount {
+		return Vector{}
+	}
+	return ScalarMult(v, (speed-amount)/speed)
+}
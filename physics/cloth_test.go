@@ -0,0 +1,24 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestStunShotRollsAtFiveSevenths checks a ball struck dead centre skids
+// until friction has it rolling at 5/7 of its starting speed.
+func TestStunShotRollsAtFiveSevenths(t *testing.T) {
+	currBall := Body{Velocity: Vector{X: 7}}
+	cloth := ClothSettings{sliding: 0.2}
+
+	for i := 0; i < 100; i++ {
+		cloth.apply(&currBall, 1)
+	}
+
+	if math.Abs(currBall.Velocity.X-5) > epsilon {
+		t.Errorf("ball rolled at %v, want 5", currBall.Velocity.X)
+	}
+	if !vectorsClose(currBall.Spin, currBall.Velocity) {
+		t.Errorf("spin %v does not match velocity %v", currBall.Spin, currBall.Velocity)
+	}
+}
@@ -0,0 +1,279 @@
+package physics
+
+import "slices"
+
+// clothIterations is how many times a cloth's links are pulled back to
+// length per substep.
+const clothIterations = 8
+
+// linkKind is what a link of a cloth holds the grid against.
+type linkKind int
+
+const (
+	// structuralLink joins neighbours along a row or column, holding the
+	// cloth together
+	structuralLink linkKind = iota
+	// shearLink joins neighbours across a diagonal, keeping the squares
+	// from folding flat into diamonds
+	shearLink
+	// bendLink joins points two apart along a row or column, stiffening
+	// the cloth against folding over
+	bendLink
+)
+
+// clothLink joins points a and b of a cloth at a length.
+type clothLink struct {
+	a, b   int
+	length float64
+	kind   linkKind
+	torn   bool
+	// spans are the two structural links a bend link reaches over, which
+	// it tears with, so a torn cloth doesn't hang together by its bends
+	spans [2]int
+}
+
+// Cloth is a sheet woven from a grid of light points, moved by Verlet
+// integration as a rope is. Structural links join each point to its
+// neighbours, shear links cross each square and bend links reach over
+// every other point; a link stretched too far tears, and points can be
+// pinned where they are. Balls push the cloth aside and are held up by it.
+//
+// It has nothing to do with the pool table's cloth, ClothSettings, which
+// is friction under the balls.
+type Cloth struct {
+	columns, rows int
+	positions     []Vector
+	previous      []Vector
+	pinned        []bool
+	links         []clothLink
+
+	// Stiffness is the share of a structural link's stretch taken out each
+	// time the links are pulled to length, from 0 to 1, and ShearStiffness
+	// and BendStiffness the same for shear and bend links
+	Stiffness      float64
+	ShearStiffness float64
+	BendStiffness  float64
+	// TearStretch is how many times its length a structural or shear link
+	// stretches to before it tears, or zero for a cloth that never tears
+	TearStretch float64
+}
+
+// NewCloth weaves a cloth w points across and h down, spacing units apart,
+// with its top-left point at the origin. Place moves it and Pin holds
+// points of it still; WithCloths adds it to a world.
+func NewCloth(w, h int, spacing float64) *Cloth {
+	w, h = max(w, 1), max(h, 1)
+	c := &Cloth{
+		columns:        w,
+		rows:           h,
+		positions:      make([]Vector, w*h),
+		pinned:         make([]bool, w*h),
+		Stiffness:      1,
+		ShearStiffness: 0.5,
+		BendStiffness:  0.1,
+		TearStretch:    1.5,
+	}
+	for row := range h {
+		for column := range w {
+			c.positions[c.index(column, row)] = Vector{X: float64(column) * spacing, Y: float64(row) * spacing}
+		}
+	}
+	c.previous = slices.Clone(c.positions)
+
+	// Structural links go first, right then down from each point, so a
+	// bend link can find the two it spans
+	right := func(column, row int) int { return row*(w-1) + column }
+	down := func(column, row int) int { return h*(w-1) + row*w + column }
+	for row := range h {
+		for column := range w - 1 {
+			c.link(c.index(column, row), c.index(column+1, row), structuralLink, [2]int{-1, -1})
+		}
+	}
+	for row := range h - 1 {
+		for column := range w {
+			c.link(c.index(column, row), c.index(column, row+1), structuralLink, [2]int{-1, -1})
+		}
+	}
+	for row := range h - 1 {
+		for column := range w - 1 {
+			c.link(c.index(column, row), c.index(column+1, row+1), shearLink, [2]int{-1, -1})
+			c.link(c.index(column+1, row), c.index(column, row+1), shearLink, [2]int{-1, -1})
+		}
+	}
+	for row := range h {
+		for column := range w - 2 {
+			c.link(c.index(column, row), c.index(column+2, row), bendLink, [2]int{right(column, row), right(column+1, row)})
+		}
+	}
+	for row := range h - 2 {
+		for column := range w {
+			c.link(c.index(column, row), c.index(column, row+2), bendLink, [2]int{down(column, row), down(column, row+1)})
+		}
+	}
+	return c
+}
+
+// index returns the position in the cloth's slices of a point of the grid.
+func (c *Cloth) index(column, row int) int {
+	return row*c.columns + column
+}
+
+// link joins points a and b at their current distance apart.
+func (c *Cloth) link(a, b int, kind linkKind, spans [2]int) {
+	offset := Subtract(c.positions[b], c.positions[a])
+	c.links = append(c.links, clothLink{a: a, b: b, length: offset.Magnitude(), kind: kind, spans: spans})
+}
+
+// Place moves the cloth so its top-left point is at corner, leaving it at
+// rest. It returns the cloth, to be placed as it is woven.
+func (c *Cloth) Place(corner Vector) *Cloth {
+	offset := Subtract(corner, c.positions[0])
+	for k := range c.positions {
+		c.positions[k] = Add(c.positions[k], offset)
+	}
+	c.previous = slices.Clone(c.positions)
+	return c
+}
+
+// Pin holds a point of the cloth still where it is, and Unpin lets it go.
+// Points off the grid are ignored.
+func (c *Cloth) Pin(column, row int) {
+	if column >= 0 && column < c.columns && row >= 0 && row < c.rows {
+		c.pinned[c.index(column, row)] = true
+	}
+}
+
+func (c *Cloth) Unpin(column, row int) {
+	if column >= 0 && column < c.columns && row >= 0 && row < c.rows {
+		c.pinned[c.index(column, row)] = false
+	}
+}
+
+// Point returns where a point of the grid is.
+func (c *Cloth) Point(column, row int) Vector {
+	return c.positions[c.index(column, row)]
+}
+
+// Threads appends the two ends of every structural link still whole to
+// dst, for drawing the cloth as a mesh.
+func (c *Cloth) Threads(dst [][2]Vector) [][2]Vector {
+	for _, l := range c.links {
+		if l.kind == structuralLink && !l.torn {
+			dst = append(dst, [2]Vector{c.positions[l.a], c.positions[l.b]})
+		}
+	}
+	return dst
+}
+
+// Torn returns how many structural links of the cloth have torn.
+func (c *Cloth) Torn() int {
+	n := 0
+	for _, l := range c.links {
+		if l.kind == structuralLink && l.torn {
+			n++
+		}
+	}
+	return n
+}
+
+// WithCloths hangs cloths in the world.
+func WithCloths(cloths ...*Cloth) WorldOption {
+	return func(w *World) {
+		w.Cloths = append(w.Cloths, cloths...)
+	}
+}
+
+// swingCloths moves every cloth on by dt ticks and lets it push against
+// the balls it touches. It runs serially, after the contacts.
+func (w *World) swingCloths(objects []Body, dt float64) {
+	pull := ScalarMult(w.gravity, dt*dt)
+	for _, c := range w.Cloths {
+		for k, p := range c.positions {
+			if c.pinned[k] {
+				c.previous[k] = p
+				continue
+			}
+			c.positions[k] = Add(Add(p, Subtract(p, c.previous[k])), pull)
+			c.previous[k] = p
+		}
+		for range clothIterations {
+			c.tighten()
+			for k := range c.positions {
+				invMass := float64(pointInverseMass)
+				if c.pinned[k] {
+					invMass = 0
+				}
+				w.pressPoint(objects, &c.positions[k], &c.previous[k], invMass, dt)
+			}
+		}
+		c.tear()
+	}
+}
+
+// tighten pulls every whole link of the cloth towards its length with the
+// stiffness of its kind, moving its ends by equal shares of the error;
+// the whole of it goes to the free end of a link to a pinned point.
+func (c *Cloth) tighten() {
+	for _, l := range c.links {
+		if l.torn {
+			continue
+		}
+		pinnedA, pinnedB := c.pinned[l.a], c.pinned[l.b]
+		if pinnedA && pinnedB {
+			continue
+		}
+		offset := Subtract(c.positions[l.b], c.positions[l.a])
+		length := offset.Magnitude()
+		if length == 0 {
+			continue
+		}
+		stiffness := c.Stiffness
+		switch l.kind {
+		case shearLink:
+			stiffness = c.ShearStiffness
+		case bendLink:
+			stiffness = c.BendStiffness
+		}
+		correction := ScalarMult(offset, stiffness*(length-l.length)/length)
+		switch {
+		case pinnedA:
+			c.positions[l.b] = Subtract(c.positions[l.b], correction)
+		case pinnedB:
+			c.positions[l.a] = Add(c.positions[l.a], correction)
+		default:
+			half := ScalarMult(correction, 0.5)
+			c.positions[l.a] = Add(c.positions[l.a], half)
+			c.positions[l.b] = Subtract(c.positions[l.b], half)
+		}
+	}
+}
+
+// tear breaks every structural and shear link stretched past TearStretch
+// times its length, and the bend links over any torn structural one.
+func (c *Cloth) tear() {
+	if c.TearStretch <= 0 {
+		return
+	}
+	for k := range c.links {
+		l := &c.links[k]
+		if l.torn {
+			continue
+		}
+		if l.kind == bendLink {
+			l.torn = c.links[l.spans[0]].torn || c.links[l.spans[1]].torn
+			continue
+		}
+		offset := Subtract(c.positions[l.b], c.positions[l.a])
+		l.torn = offset.Magnitude() > c.TearStretch*l.length
+	}
+}
+
+// clone returns a copy of the cloth that can be moved separately.
+func (c *Cloth) clone() *Cloth {
+	d := *c
+	d.positions = slices.Clone(c.positions)
+	d.previous = slices.Clone(c.previous)
+	d.pinned = slices.Clone(c.pinned)
+	d.links = slices.Clone(c.links)
+	return &d
+}
@@ -0,0 +1,53 @@
+package physics
+
+import "testing"
+
+// TestClothHangsFromPins hangs a cloth by its top corners and checks they
+// hold still while the rest sags below them, without tearing.
+func TestClothHangsFromPins(t *testing.T) {
+	c := NewCloth(11, 6, 10).Place(Vector{X: 100, Y: 50})
+	c.Pin(0, 0)
+	c.Pin(10, 0)
+	w := NewWorld(nil, Vector{Y: 0.3}, WithCloths(c))
+	defer w.Close()
+	for range 300 {
+		w.Step()
+	}
+	if left, right := c.Point(0, 0), c.Point(10, 0); left != (Vector{X: 100, Y: 50}) || right != (Vector{X: 200, Y: 50}) {
+		t.Errorf("pinned corners moved to %v and %v", left, right)
+	}
+	if middle := c.Point(5, 0); middle.Y <= 50 {
+		t.Errorf("middle of the top edge at %v, want it sagging below the pins", middle)
+	}
+	if n := c.Torn(); n != 0 {
+		t.Errorf("%d links tore under the cloth's own weight, want none", n)
+	}
+}
+
+// TestBallTearsCloth throws a heavy ball up into a net pinned along its top
+// and checks it tears the net unless tearing is off, and either way the
+// net stops the ball short of the pinned edge.
+func TestBallTearsCloth(t *testing.T) {
+	throw := func(tear float64) (int, float64) {
+		c := NewCloth(21, 11, 12).Place(Vector{X: 200, Y: 60})
+		for column := range 21 {
+			c.Pin(column, 0)
+		}
+		c.TearStretch = tear
+		w := NewWorld([]Body{{Position: Vector{X: 320, Y: 440}, Velocity: Vector{Y: -25}, Mass: 50}}, Vector{Y: 0.3}, WithCloths(c))
+		defer w.Close()
+		highest := float64(ScreenHeight)
+		for range 200 {
+			w.Step()
+			highest = min(highest, w.Snapshot()[0].Position.Y)
+		}
+		return c.Torn(), highest
+	}
+
+	if torn, highest := throw(1.5); torn == 0 || highest < 60 {
+		t.Errorf("tearable net: %d links torn with the ball up to y %.1f, want some torn and it held below 60", torn, highest)
+	}
+	if torn, highest := throw(0); torn != 0 || highest < 60 {
+		t.Errorf("untearable net: %d links torn with the ball up to y %.1f, want none and it held below 60", torn, highest)
+	}
+}
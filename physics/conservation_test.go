@@ -0,0 +1,93 @@
+package physics
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTwoBallCollisionConservesMomentum(t *testing.T) {
+	tests := []struct {
+		name  string
+		balls []Body
+	}{
+		{"head on", []Body{
+			{Position: Vector{X: 250, Y: 240}, Velocity: Vector{X: 3}},
+			{Position: Vector{X: 390, Y: 240}, Velocity: Vector{X: -2}},
+		}},
+		{"oblique", []Body{
+			{Position: Vector{X: 250, Y: 230}, Velocity: Vector{X: 3, Y: 0.5}},
+			{Position: Vector{X: 390, Y: 250}, Velocity: Vector{X: -2, Y: -0.5}},
+		}},
+		{"one at rest", []Body{
+			{Position: Vector{X: 250, Y: 240}, Velocity: Vector{X: 4}},
+			{Position: Vector{X: 320, Y: 250}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld(tt.balls, Vector{})
+			defer w.Close()
+
+			momentum := w.Momentum()
+			energy := w.kineticEnergy()
+			collided := false
+			for i := 0; i < 60; i++ {
+				w.Step()
+				if len(w.contacts) > 0 {
+					collided = true
+				}
+			}
+			if !collided {
+				t.Fatal("balls never collided")
+			}
+
+			if got := w.Momentum(); !vectorsClose(got, momentum) {
+				t.Errorf("momentum = %v after collision, want %v", got, momentum)
+			}
+			if got := w.kineticEnergy(); math.Abs(got-energy) > epsilon {
+				t.Errorf("kinetic energy = %v after collision, want %v", got, energy)
+			}
+		})
+	}
+}
+
+// boxOfBalls lays balls out on a grid with no initial overlaps and gives
+// each a seeded random velocity.
+func boxOfBalls(columns, rows int) []Body {
+	rng := rand.New(rand.NewSource(2))
+	var objects []Body
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			objects = append(objects, Body{
+				Position: Vector{X: float64(column)*3*BallRadius + 2*BallRadius, Y: float64(row)*3*BallRadius + 2*BallRadius},
+				Velocity: Vector{X: rng.Float64()*6 - 3, Y: rng.Float64()*6 - 3},
+			})
+		}
+	}
+	return objects
+}
+
+// TestClosedBoxConservesEnergy fills the screen with elastic balls and no
+// gravity. Walls reverse momentum, but kinetic energy should survive
+// thousands of collisions.
+func TestClosedBoxConservesEnergy(t *testing.T) {
+	w := NewWorld(boxOfBalls(8, 6), Vector{})
+	defer w.Close()
+
+	energy := w.kineticEnergy()
+	collisions := 0
+	for i := 0; i < 5000; i++ {
+		w.Step()
+		collisions += len(w.contacts)
+	}
+	if collisions == 0 {
+		t.Fatal("balls never collided")
+	}
+
+	got := w.kineticEnergy()
+	if drift := math.Abs(got-energy) / energy; drift > 1e-9 {
+		t.Errorf("kinetic energy drifted by %.3g%% over %d contacts (%v -> %v)", drift*100, collisions, energy, got)
+	}
+}
@@ -0,0 +1,217 @@
+package physics
+
+// constraintIterations is how many times the constraints are relaxed each
+// step. Chains of constraints need several passes for corrections to
+// travel along them.
+const constraintIterations = 8
+
+// NoBody marks the missing second body of a constraint tied to an anchor.
+const NoBody = -1
+
+// DistanceConstraint is a rigid rod holding ball a at a fixed length from
+// ball b, or from anchor when b is NoBody. Given a Stiffness it is a spring
+// instead, pulling its ends towards Length rather than holding them there.
+type DistanceConstraint struct {
+	A      int
+	B      int
+	Anchor Vector
+	Length float64
+
+	// Stiffness is the spring's force per unit it is stretched or
+	// squeezed, or zero for a rigid rod, and Damping its force per unit
+	// per tick its ends part or close at
+	Stiffness float64
+	Damping   float64
+
+	// velocityAxis is the rod's direction when its velocity was last
+	// solved, so the position pass can turn the velocity with the rod.
+	velocityAxis Vector
+
+	// Strength is the speed a weld stands being pulled apart at in a
+	// substep before it breaks, or zero for a rod that never breaks, and
+	// pull is how fast the velocity pass last found its ends parting
+	Strength float64
+	pull     float64
+}
+
+// NewRod ties two balls together at their current separation.
+func NewRod(objects []Body, a, b int) DistanceConstraint {
+	offset := Subtract(objects[a].Position, objects[b].Position)
+	return DistanceConstraint{A: a, B: b, Length: offset.Magnitude()}
+}
+
+// NewAnchoredRod ties a ball to a fixed point at their current separation.
+func NewAnchoredRod(objects []Body, a int, anchor Vector) DistanceConstraint {
+	offset := Subtract(objects[a].Position, anchor)
+	return DistanceConstraint{A: a, B: NoBody, Anchor: anchor, Length: offset.Magnitude()}
+}
+
+// NewSpring joins two balls with a spring of the given stiffness and
+// damping, at rest at their current separation.
+func NewSpring(objects []Body, a, b int, stiffness, damping float64) DistanceConstraint {
+	c := NewRod(objects, a, b)
+	c.Stiffness, c.Damping = stiffness, damping
+	return c
+}
+
+// NewAnchoredSpring hangs a ball from a fixed point by a spring of the
+// given stiffness and damping, at rest at their current separation.
+func NewAnchoredSpring(objects []Body, a int, anchor Vector, stiffness, damping float64) DistanceConstraint {
+	c := NewAnchoredRod(objects, a, anchor)
+	c.Stiffness, c.Damping = stiffness, damping
+	return c
+}
+
+// WithConstraints adds constraints to the world.
+func WithConstraints(constraints ...DistanceConstraint) WorldOption {
+	return func(w *World) {
+		w.Constraints = append(w.Constraints, constraints...)
+	}
+}
+
+// End returns the position and velocity at the far end of the rod.
+func (c *DistanceConstraint) End(objects []Body) (Vector, Vector) {
+	if c.B == NoBody {
+		return c.Anchor, Vector{}
+	}
+	return objects[c.B].Position, objects[c.B].Velocity
+}
+
+// inverseMasses returns the inverse mass at each end of the rod; a fixed
+// anchor can't move.
+func (c *DistanceConstraint) inverseMasses(objects []Body) (float64, float64) {
+	if c.B == NoBody {
+		return objects[c.A].inverseMass(), 0
+	}
+	return objects[c.A].inverseMass(), objects[c.B].inverseMass()
+}
+
+// axis returns the unit vector from the far end to ball a and the current
+// length of the rod.
+func (c *DistanceConstraint) axis(objects []Body) (Vector, float64) {
+	endPosition, _ := c.End(objects)
+	offset := Subtract(objects[c.A].Position, endPosition)
+	distance := offset.Magnitude()
+	if distance == 0 {
+		return Vector{}, 0
+	}
+	return ScalarMult(offset, 1/distance), distance
+}
+
+// spring pulls or pushes a spring's ends towards its length for dt ticks,
+// the damping slowing them parting or closing.
+func (c *DistanceConstraint) spring(objects []Body, dt float64) {
+	axis, distance := c.axis(objects)
+	if c.Stiffness == 0 || distance == 0 {
+		return
+	}
+	invMassA, invMassB := c.inverseMasses(objects)
+	_, endVelocity := c.End(objects)
+	parting := DotProduct(Subtract(objects[c.A].Velocity, endVelocity), axis)
+	impulse := ScalarMult(axis, (-c.Stiffness*(distance-c.Length)-c.Damping*parting)*dt)
+
+	currBall := &objects[c.A]
+	currBall.Velocity = Add(currBall.Velocity, ScalarMult(impulse, invMassA))
+	if c.B != NoBody {
+		otherBall := &objects[c.B]
+		otherBall.Velocity = Subtract(otherBall.Velocity, ScalarMult(impulse, invMassB))
+	}
+}
+
+// solveVelocity removes any relative velocity that would stretch or
+// compress the rod, leaving motion around it untouched. Springs are left
+// to give.
+func (c *DistanceConstraint) solveVelocity(objects []Body) {
+	if c.Stiffness != 0 {
+		return
+	}
+	axis, _ := c.axis(objects)
+	invMassA, invMassB := c.inverseMasses(objects)
+	_, endVelocity := c.End(objects)
+
+	c.velocityAxis = axis
+
+	stretch := DotProduct(Subtract(objects[c.A].Velocity, endVelocity), axis)
+	c.pull += stretch
+	impulse := ScalarMult(axis, stretch/(invMassA+invMassB))
+
+	currBall := &objects[c.A]
+	currBall.Velocity = Subtract(currBall.Velocity, ScalarMult(impulse, invMassA))
+	if c.B != NoBody {
+		otherBall := &objects[c.B]
+		otherBall.Velocity = Add(otherBall.Velocity, ScalarMult(impulse, invMassB))
+	}
+}
+
+// solvePosition moves the ends back to the rod's length, each by its share
+// of the error, removing the drift that moving along a tangent builds up.
+//
+// A rod swinging round turns its ends' relative velocity with it. The
+// velocity pass only made that velocity tangent to where the rod was, so
+// it is rotated here through the angle the rod has turned since; dropping
+// the now-radial part instead would bleed energy every step.
+func (c *DistanceConstraint) solvePosition(objects []Body) {
+	axis, distance := c.axis(objects)
+	if c.Stiffness != 0 || distance == 0 {
+		return
+	}
+	invMassA, invMassB := c.inverseMasses(objects)
+	invMassSum := invMassA + invMassB
+	correction := ScalarMult(axis, (distance-c.Length)/invMassSum)
+
+	_, endVelocity := c.End(objects)
+	relative := Subtract(objects[c.A].Velocity, endVelocity)
+	turned := relative
+	if c.velocityAxis != (Vector{}) {
+		turned = RotateBetween(relative, c.velocityAxis, axis)
+	}
+	change := Subtract(turned, relative)
+	c.velocityAxis = axis
+
+	currBall := &objects[c.A]
+	currBall.Position = Subtract(currBall.Position, ScalarMult(correction, invMassA))
+	currBall.Velocity = Add(currBall.Velocity, ScalarMult(change, invMassA/invMassSum))
+	if c.B != NoBody {
+		otherBall := &objects[c.B]
+		otherBall.Position = Add(otherBall.Position, ScalarMult(correction, invMassB))
+		otherBall.Velocity = Subtract(otherBall.Velocity, ScalarMult(change, invMassB/invMassSum))
+	}
+}
+
+// solveConstraintVelocities relaxes the velocity of every constraint in
+// turn, several times over.
+func (w *World) solveConstraintVelocities(objects []Body) {
+	for i := range w.Constraints {
+		w.Constraints[i].pull = 0
+	}
+	for i := range w.Welds {
+		w.Welds[i].warmStart(objects)
+	}
+	for iteration := 0; iteration < constraintIterations; iteration++ {
+		for i := range w.Constraints {
+			w.Constraints[i].solveVelocity(objects)
+		}
+		for i := range w.Welds {
+			w.Welds[i].solveVelocity(objects)
+		}
+		for i := range w.Wheels {
+			w.Wheels[i].solveVelocity(objects)
+		}
+	}
+}
+
+// solveConstraintPositions relaxes the length of every constraint in turn,
+// several times over.
+func (w *World) solveConstraintPositions(objects []Body) {
+	for iteration := 0; iteration < constraintIterations; iteration++ {
+		for i := range w.Constraints {
+			w.Constraints[i].solvePosition(objects)
+		}
+		for i := range w.Welds {
+			w.Welds[i].solvePosition(objects)
+		}
+		for i := range w.Wheels {
+			w.Wheels[i].solvePosition(objects)
+		}
+	}
+}
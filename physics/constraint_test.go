@@ -0,0 +1,60 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestSpringSettlesUnderWeight hangs a heavy ball from a damped spring
+// and checks it settles as far below the spring's length as its weight
+// stretches it, mg/k.
+func TestSpringSettlesUnderWeight(t *testing.T) {
+	objects := []Body{{Position: Vector{X: 300, Y: 200}, Mass: 2}}
+	anchor := Vector{X: 300, Y: 100}
+	w := NewWorld(objects, Vector{Y: 0.3}, WithOpenArena(1000), WithConstraints(NewAnchoredSpring(objects, 0, anchor, 0.1, 0.2)))
+	defer w.Close()
+	for range 600 {
+		w.Step()
+	}
+	b := w.Snapshot()[0]
+	if want := 200 + 0.3*2/0.1; math.Abs(b.Position.Y-want) > 0.1 || math.Abs(b.Velocity.Y) > 0.01 {
+		t.Errorf("hanging ball at %v moving %v, want it settled at y %.1f", b.Position, b.Velocity, want)
+	}
+}
+
+// TestSpringOscillates stretches an undamped spring between two free balls
+// and checks they swing in and out as far either side of its length, at
+// the period of two masses on a spring, with their momentum untouched.
+func TestSpringOscillates(t *testing.T) {
+	objects := []Body{{Position: Vector{X: 200, Y: 200}}, {Position: Vector{X: 320, Y: 200}}}
+	spring := NewSpring(objects, 0, 1, 0.02, 0)
+	spring.Length = 100
+	w := NewWorld(objects, Vector{}, WithOpenArena(1000), WithConstraints(spring))
+	defer w.Close()
+
+	// Two unit masses swing as one of a half on a spring, at 2π√(μ/k)
+	period := 2 * math.Pi * math.Sqrt(0.5/0.02)
+	closest, furthest := math.Inf(1), 0.0
+	for range int(10 * period) {
+		w.Step()
+		objects := w.Snapshot()
+		d := Subtract(objects[1].Position, objects[0].Position)
+		closest, furthest = min(closest, d.Magnitude()), max(furthest, d.Magnitude())
+		if momentum := Add(objects[0].Velocity, objects[1].Velocity); momentum.Magnitude() > 1e-9 {
+			t.Fatalf("momentum %v, want none", momentum)
+		}
+	}
+	if math.Abs(closest-80) > 0.5 || math.Abs(furthest-120) > 0.5 {
+		t.Errorf("balls swung between %.2f and %.2f apart, want 80 and 120", closest, furthest)
+	}
+
+	half := NewWorld([]Body{{Position: Vector{X: 200, Y: 200}}, {Position: Vector{X: 320, Y: 200}}}, Vector{}, WithOpenArena(1000), WithConstraints(spring))
+	defer half.Close()
+	for range int(math.Round(period / 2)) {
+		half.Step()
+	}
+	objects = half.Snapshot()
+	if d := objects[1].Position.X - objects[0].Position.X; d > 81 {
+		t.Errorf("balls %.2f apart half a period on, want them closest, about 80", d)
+	}
+}
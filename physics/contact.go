@@ -0,0 +1,359 @@
+package physics
+
+import (
+	"math"
+	"slices"
+)
+
+// Contact is a touching pair found by the narrowphase. Normal points from
+// B towards A.
+type Contact struct {
+	A           int
+	B           int
+	Normal      Vector
+	Penetration float64
+
+	// Restitution is the share of their closing speed the two balls part
+	// at, from their materials unless a pre-solve hook changes it
+	Restitution float64
+	// Friction caps the impulse that slows the balls sliding past each
+	// other, as a share of the impulse pushing them apart; zero lets them
+	// slide freely
+	Friction float64
+	// Disabled drops the contact for this substep, so the balls pass
+	// through each other as if they weren't touching
+	Disabled bool
+}
+
+// PreSolve is called for every contact before the solver responds to it,
+// with copies of the two balls as they are at that moment. It may change
+// the contact's restitution and friction or disable it, but not its
+// bodies, normal or penetration.
+type PreSolve func(c *Contact, a, b Body)
+
+// WithPreSolve has hook look over every contact before it is solved, to
+// tune or drop it: to make a ball pass one way through others, say, or to
+// make some pairs grip as they roll.
+func WithPreSolve(hook PreSolve) WorldOption {
+	return func(w *World) {
+		w.preSolve = hook
+	}
+}
+
+// findContacts runs the narrowphase over the broadphase pairs in
+// parallel, one list per chunk, then joins the lists in chunk order so the
+// contacts come out in the order of the pairs.
+func (w *World) findContacts(objects []Body) {
+	w.contacts = w.contacts[:0]
+	if w.ignoreContacts {
+		return
+	}
+	joined := w.joinedPairs()
+	chunks := w.pool.chunks(len(w.pairs))
+	for len(w.chunkContacts) < chunks {
+		w.chunkContacts = append(w.chunkContacts, nil)
+	}
+
+	w.pool.parallelFor(len(w.pairs), func(chunk, start, end int) {
+		found := w.chunkContacts[chunk][:0]
+		for _, p := range w.pairs[start:end] {
+			// Far bodies don't collide among themselves
+			if w.far[p.a] && w.far[p.b] {
+				continue
+			}
+			if joined[pair{a: min(p.a, p.b), b: max(p.a, p.b)}] {
+				continue
+			}
+			if c, ok := w.testContact(objects, p); ok {
+				found = append(found, c)
+			}
+		}
+		w.chunkContacts[chunk] = found
+	})
+
+	for _, found := range w.chunkContacts[:chunks] {
+		w.contacts = append(w.contacts, found...)
+	}
+}
+
+// testContact reports whether the two balls of a pair overlap, measuring
+// across the world's edges if it wraps round.
+func (w *World) testContact(objects []Body, p pair) (Contact, bool) {
+	if w.Arena.Shape == TorusArena {
+		return touching(objects, p, w.Arena.separation(objects[p.a].Position, objects[p.b].Position))
+	}
+	return testPair(objects, p)
+}
+
+// testPair reports whether the two balls of a pair overlap.
+func testPair(objects []Body, p pair) (Contact, bool) {
+	return touching(objects, p, Subtract(objects[p.a].Position, objects[p.b].Position))
+}
+
+// touching reports whether the two balls of a pair overlap, given the
+// offset from b's centre to a's.
+func touching(objects []Body, p pair, distanceVector Vector) (Contact, bool) {
+	currBall := &objects[p.a]
+	otherBall := &objects[p.b]
+
+	// Check if balls are colliding, comparing squared lengths so a miss
+	// never pays for a square root. Written as a negated less-than so a NaN
+	// distance counts as a miss and can't spread to the other ball.
+	reach := currBall.Size() + otherBall.Size()
+	distanceSquared := distanceVector.MagnitudeSquared()
+	if !(distanceSquared < reach*reach) {
+		return Contact{}, false
+	}
+	distance := math.Sqrt(distanceSquared)
+
+	// Calculate collision normal (unit vector between centers), reusing the
+	// distance rather than normalising from scratch
+	normal := ScalarMult(distanceVector, 1/distance)
+
+	// Perfectly overlapping balls have no line between their centres, so
+	// push them apart along their relative velocity or, failing that, a
+	// fixed axis
+	if distance == 0 {
+		normal = UnitVector(Subtract(otherBall.Velocity, currBall.Velocity))
+		if normal == (Vector{}) {
+			normal = Vector{X: 1}
+		}
+	}
+
+	return Contact{
+		A:           p.a,
+		B:           p.b,
+		Normal:      normal,
+		Penetration: reach - distance,
+	}, true
+}
+
+// preSolveContacts gives every contact its restitution and friction and
+// passes it to the pre-solve hook, if there is one, then drops those the
+// hook disabled.
+func (w *World) preSolveContacts(objects []Body) {
+	for k := range w.contacts {
+		c := &w.contacts[k]
+		currBall, otherBall := &objects[c.A], &objects[c.B]
+		speed := -DotProduct(Subtract(currBall.Velocity, otherBall.Velocity), c.Normal)
+
+		// The bounce is the geometric mean of the two balls' at this
+		// speed, so two alike bounce as one does off a perfectly elastic
+		// wall, and two plain rubber balls perfectly elastically
+		c.Restitution = math.Sqrt(currBall.bounciness(speed) * otherBall.bounciness(speed))
+		// Two balls grip each other as the geometric mean of their grips,
+		// so one that slides on everything slides on a rough ball too
+		c.Friction = math.Sqrt(currBall.Grip * otherBall.Grip)
+		if w.preSolve != nil {
+			w.preSolve(c, *currBall, *otherBall)
+		}
+	}
+	if w.preSolve != nil {
+		w.contacts = slices.DeleteFunc(w.contacts, func(c Contact) bool { return c.Disabled })
+	}
+}
+
+// solvedContact is how fast a contact's balls were closing when it was
+// solved, and the impulse it took to push them apart.
+type solvedContact struct {
+	speed   float64
+	impulse float64
+}
+
+// solveContacts applies the collision impulse for every contact, in
+// batches across the pool when there are enough, then records the impacts
+// in the order the contacts were found.
+func (w *World) solveContacts(objects []Body) {
+	if w.batchesContacts() {
+		w.batches.split(w.contacts, len(objects))
+	}
+	w.solved = slices.Grow(w.solved[:0], len(w.contacts))[:len(w.contacts)]
+	w.eachContact(func(k int) {
+		w.solved[k] = solveContact(objects, w.contacts[k])
+	})
+
+	// Velocities are all the solver changes, so each impact is where its
+	// balls were when it was solved
+	for k, c := range w.contacts {
+		w.deepest = max(w.deepest, c.Penetration)
+		if s := w.solved[k]; s.impulse > 0 {
+			otherBall := &objects[c.B]
+			w.impacts = append(w.impacts, Impact{
+				A:        c.A,
+				B:        c.B,
+				Position: Add(otherBall.Position, ScalarMult(c.Normal, otherBall.Size())),
+				Speed:    s.speed,
+				Impulse:  s.impulse,
+			})
+		}
+	}
+}
+
+// solveContact applies the collision impulse and friction for a contact.
+func solveContact(objects []Body, c Contact) solvedContact {
+	currBall, otherBall := &objects[c.A], &objects[c.B]
+	speed := -DotProduct(Subtract(currBall.Velocity, otherBall.Velocity), c.Normal)
+	impulse := resolve(currBall, otherBall, c, currBall.inverseMass(), otherBall.inverseMass(), c.Restitution)
+	rub(currBall, otherBall, c, currBall.inverseMass(), otherBall.inverseMass(), impulse)
+	return solvedContact{speed: speed, impulse: impulse}
+}
+
+// inverseMass returns how easily contacts and rods push the ball around.
+// Frozen balls can't be pushed at all.
+func (b *Body) inverseMass() float64 {
+	if b.Frozen {
+		return 0
+	}
+	return 1 / b.mass()
+}
+
+// inverseInertia returns how easily welds and magnets turn the ball, taking
+// it for a solid sphere. Frozen balls can't be turned at all.
+func (b *Body) inverseInertia() float64 {
+	r := b.Size()
+	return b.inverseMass() / (0.4 * r * r)
+}
+
+// mass returns how much the ball weighs. Balls without a mass all weigh
+// one unit.
+func (b *Body) mass() float64 {
+	if b.Mass == 0 {
+		return 1
+	}
+	return b.Mass
+}
+
+// inertia returns how hard the ball is to turn, taking it for a solid
+// sphere.
+func (b *Body) inertia() float64 {
+	r := b.Size()
+	return 0.4 * b.mass() * r * r
+}
+
+// resolve applies the impulse for a contact between two bodies with the
+// given inverse masses. It returns the size of the impulse, which is zero
+// if the bodies were already separating.
+func resolve(currBall *Body, otherBall *Body, c Contact, invMassA, invMassB, restitution float64) float64 {
+	invMassSum := invMassA + invMassB
+	if invMassSum == 0 {
+		return 0
+	}
+
+	// Calculate relative velocity
+	relativeVelocity := Subtract(currBall.Velocity, otherBall.Velocity)
+
+	// Calculate velocity along the normal
+	velocityAlongNormal := DotProduct(relativeVelocity, c.Normal)
+
+	// Only proceed if balls are moving towards each other
+	if velocityAlongNormal > 0 {
+		return 0
+	}
+
+	// Calculate impulse scalar
+	impulse := -(1 + restitution) * velocityAlongNormal / invMassSum
+
+	// Apply impulse
+	impulseVector := ScalarMult(c.Normal, impulse)
+
+	// Update velocities
+	currBall.Velocity = Add(currBall.Velocity, ScalarMult(impulseVector, invMassA))
+	otherBall.Velocity = Subtract(otherBall.Velocity, ScalarMult(impulseVector, invMassB))
+	return impulse
+}
+
+// rub applies the friction of a contact whose balls were just pushed apart
+// by impulse, slowing their surfaces sliding past each other where they
+// touch by as much as the contact's friction allows and no more than
+// brings them to a stop. Friction at the surface turns both balls, so a
+// glancing blow sets them spinning and a spinning ball throws the one it
+// hits sideways.
+func rub(currBall *Body, otherBall *Body, c Contact, invMassA, invMassB, impulse float64) {
+	if c.Friction <= 0 || impulse <= 0 || invMassA+invMassB == 0 {
+		return
+	}
+	// The normal points from the other ball to this one, and they touch
+	// in the middle of where they overlap
+	rA := ScalarMult(c.Normal, c.Penetration/2-currBall.Size())
+	rB := ScalarMult(c.Normal, otherBall.Size()-c.Penetration/2)
+	relativeVelocity := Subtract(
+		Add(currBall.Velocity, CrossProduct(Vector{Z: currBall.AngularVelocity}, rA)),
+		Add(otherBall.Velocity, CrossProduct(Vector{Z: otherBall.AngularVelocity}, rB)),
+	)
+	sliding := Subtract(relativeVelocity, ScalarMult(c.Normal, DotProduct(relativeVelocity, c.Normal)))
+	speed := sliding.Magnitude()
+	if speed == 0 {
+		return
+	}
+	tangent := ScalarMult(sliding, 1/speed)
+	armA, armB := CrossProduct(rA, tangent).Z, CrossProduct(rB, tangent).Z
+	invInertiaA, invInertiaB := currBall.inverseInertia(), otherBall.inverseInertia()
+	resistance := invMassA + invMassB + invInertiaA*armA*armA + invInertiaB*armB*armB
+
+	grip := min(speed/resistance, c.Friction*impulse)
+	currBall.Velocity = Add(currBall.Velocity, ScalarMult(tangent, -grip*invMassA))
+	otherBall.Velocity = Add(otherBall.Velocity, ScalarMult(tangent, grip*invMassB))
+	currBall.AngularVelocity -= grip * armA * invInertiaA
+	otherBall.AngularVelocity += grip * armB * invInertiaB
+}
+
+// positionCorrection eases overlapping bodies apart by moving them
+// directly, without touching their velocities, a share of the overlap at a
+// time. Pushing them the whole depth at once adds energy whenever the
+// overlap didn't come from their own approach, as when a stack settles
+// under gravity, and sets stacked bodies jittering. A little overlap, the
+// slop, is left alone so resting contacts stay touching from one step to
+// the next.
+type positionCorrection struct {
+	// slop is the overlap left, in world units
+	slop float64
+	// factor is the share of the rest removed each pass
+	factor float64
+	// limit caps how far, in world units, a pair is moved apart in one pass
+	limit float64
+	// iterations is how many passes over the contacts each substep makes
+	iterations int
+}
+
+var defaultCorrection = positionCorrection{slop: 0.05, factor: 0.8, limit: BallRadius / 2, iterations: 4}
+
+// WithPositionCorrection sets how overlapping bodies are eased apart: the
+// overlap in world units left alone, the share of the rest removed per pass,
+// from 0 to 1, the most a pair is moved apart in one, and how many passes
+// over the contacts each substep makes.
+func WithPositionCorrection(slop, factor, limit float64, iterations int) WorldOption {
+	return func(w *World) {
+		w.correction = positionCorrection{slop: slop, factor: factor, limit: limit, iterations: iterations}
+	}
+}
+
+// correctPositions eases apart every pair of balls found touching this
+// substep, in the solver's batches. Each pass measures the overlaps
+// afresh, since easing one pair apart can push either ball into a
+// neighbour, so a pile shares out its overlap as non-linear Gauss-Seidel
+// does.
+func (w *World) correctPositions(objects []Body) {
+	for range w.correction.iterations {
+		w.eachContact(func(k int) {
+			found := w.contacts[k]
+			c, ok := w.testContact(objects, pair{a: found.A, b: found.B})
+			if !ok {
+				return
+			}
+			currBall, otherBall := &objects[c.A], &objects[c.B]
+			w.correction.separate(currBall, otherBall, c, currBall.inverseMass(), otherBall.inverseMass())
+		})
+	}
+}
+
+// separate moves two overlapping bodies with the given inverse masses
+// apart along the contact normal, the lighter one further.
+func (p positionCorrection) separate(currBall, otherBall *Body, c Contact, invMassA, invMassB float64) {
+	invMassSum := invMassA + invMassB
+	if invMassSum == 0 || c.Penetration <= p.slop {
+		return
+	}
+	separation := min(p.factor*(c.Penetration-p.slop), p.limit) / invMassSum
+	currBall.Position = Add(currBall.Position, ScalarMult(c.Normal, separation*invMassA))
+	otherBall.Position = Subtract(otherBall.Position, ScalarMult(c.Normal, separation*invMassB))
+}
@@ -0,0 +1,249 @@
+package physics
+
+import (
+	"math"
+	"math/rand"
+	goreflect "reflect"
+	"testing"
+	"testing/quick"
+)
+
+// randomContact is an overlapping, approaching pair of balls with random
+// masses, velocities, contact angle and restitution.
+type randomContact struct {
+	a, b             Body
+	massA, massB     float64
+	restitution      float64
+	distanceAtImpact float64
+}
+
+func (randomContact) Generate(rng *rand.Rand, _ int) goreflect.Value {
+	angle := rng.Float64() * 2 * math.Pi
+	normal := Vector{X: math.Cos(angle), Y: math.Sin(angle)}
+	distance := (0.05 + 0.95*rng.Float64()) * 2 * BallRadius
+
+	randomVelocity := func() Vector {
+		return Vector{X: rng.NormFloat64() * 5, Y: rng.NormFloat64() * 5}
+	}
+
+	c := randomContact{
+		a:                Body{Position: Add(Vector{X: 300, Y: 200}, ScalarMult(normal, distance)), Velocity: randomVelocity()},
+		b:                Body{Position: Vector{X: 300, Y: 200}, Velocity: randomVelocity()},
+		massA:            math.Exp(rng.NormFloat64() * 2),
+		massB:            math.Exp(rng.NormFloat64() * 2),
+		restitution:      rng.Float64(),
+		distanceAtImpact: distance,
+	}
+	return goreflect.ValueOf(c)
+}
+
+// solve finds and resolves the contact, returning the balls afterwards.
+func (c randomContact) solve() (Body, Body, Contact) {
+	objects := []Body{c.a, c.b}
+	found, ok := testPair(objects, pair{a: 0, b: 1})
+	if !ok {
+		panic("generated balls do not overlap")
+	}
+	resolve(&objects[0], &objects[1], found, 1/c.massA, 1/c.massB, c.restitution)
+	defaultCorrection.separate(&objects[0], &objects[1], found, 1/c.massA, 1/c.massB)
+	return objects[0], objects[1], found
+}
+
+func (c randomContact) energy(a, b Body) float64 {
+	return 0.5*c.massA*a.Velocity.MagnitudeSquared() + 0.5*c.massB*b.Velocity.MagnitudeSquared()
+}
+
+var quickConfig = &quick.Config{MaxCount: 5000, Rand: rand.New(rand.NewSource(3))}
+
+func TestResolveNeverGainsEnergy(t *testing.T) {
+	property := func(c randomContact) bool {
+		a, b, _ := c.solve()
+		before := c.energy(c.a, c.b)
+		return c.energy(a, b) <= before*(1+1e-12)+1e-12
+	}
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestResolveImpulsesAreSymmetric(t *testing.T) {
+	property := func(c randomContact) bool {
+		a, b, _ := c.solve()
+		changeA := ScalarMult(Subtract(a.Velocity, c.a.Velocity), c.massA)
+		changeB := ScalarMult(Subtract(b.Velocity, c.b.Velocity), c.massB)
+
+		// Equal and opposite impulses leave total momentum unchanged
+		net := Add(changeA, changeB)
+		scale := math.Max(1, changeA.Magnitude())
+		return net.Magnitude() <= 1e-9*scale
+	}
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestResolveNeverDeepensPenetration(t *testing.T) {
+	property := func(c randomContact) bool {
+		a, b, found := c.solve()
+		offset := Subtract(a.Position, b.Position)
+		after := offset.Magnitude()
+		if after < c.distanceAtImpact-1e-9 {
+			return false
+		}
+
+		// The overlap shrinks by the correction's share of all but the
+		// slop, up to its limit, however the balls are moving
+		p := defaultCorrection
+		want := found.Penetration - min(p.factor*max(found.Penetration-p.slop, 0), p.limit)
+		return math.Abs(2*BallRadius-after-want) <= 1e-9
+	}
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestResolveLeavesBallsSeparating(t *testing.T) {
+	property := func(c randomContact) bool {
+		a, b, found := c.solve()
+		relative := DotProduct(Subtract(a.Velocity, b.Velocity), found.Normal)
+
+		// Any approach is reversed and scaled by the restitution
+		before := DotProduct(Subtract(c.a.Velocity, c.b.Velocity), found.Normal)
+		if before > 0 {
+			return relative == before
+		}
+		return math.Abs(relative+c.restitution*before) <= 1e-9*math.Max(1, -before)
+	}
+	if err := quick.Check(property, quickConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestOverlapEasesApart drops two balls onto each other at rest and checks
+// the overlap shrinks over a few steps down to the slop without leaving
+// them moving, and that the deepest overlap of each step is reported.
+func TestOverlapEasesApart(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 310, Y: 240}},
+		{Position: Vector{X: 330, Y: 240}},
+	}, Vector{})
+	defer w.Close()
+
+	overlap := func() float64 {
+		objects := w.Snapshot()
+		gap := Subtract(objects[1].Position, objects[0].Position)
+		return 2*BallRadius - gap.Magnitude()
+	}
+	w.Step()
+	if got := w.LastPenetration(); math.Abs(got-20) > 1e-9 {
+		t.Errorf("first step reported an overlap of %.3f, want 20", got)
+	}
+	if got := overlap(); got <= defaultCorrection.slop || got >= 20 {
+		t.Errorf("after one step the balls overlap by %.3f, want part of the 20 removed", got)
+	}
+	for range 10 {
+		w.Step()
+	}
+	if got := overlap(); got > defaultCorrection.slop+1e-9 || got < 0 {
+		t.Errorf("after eleven steps the balls overlap by %.3f, want at most the slop %v", got, defaultCorrection.slop)
+	}
+	for i, b := range w.Snapshot() {
+		if b.Velocity != (Vector{}) {
+			t.Errorf("ball %d was set moving at %v", i, b.Velocity)
+		}
+	}
+}
+
+// TestPreSolveMakesOneWayContacts lets only balls moving right pass
+// through others, and checks one does while the ball coming back the other
+// way is stopped.
+func TestPreSolveMakesOneWayContacts(t *testing.T) {
+	oneWay := func(c *Contact, a, b Body) {
+		if a.Velocity.X > 0 || b.Velocity.X > 0 {
+			c.Disabled = true
+		}
+	}
+	w := NewWorld([]Body{
+		{Position: Vector{X: 200, Y: 240}, Velocity: Vector{X: 4}},
+		{Position: Vector{X: 300, Y: 240}},
+	}, Vector{}, WithPreSolve(oneWay))
+	defer w.Close()
+	for range 50 {
+		w.Step()
+	}
+	objects := w.Snapshot()
+	if objects[0].Position.X < objects[1].Position.X || objects[1].Velocity != (Vector{}) {
+		t.Fatalf("the ball moving right was stopped: %v and %v", objects[0].Position, objects[1].Position)
+	}
+
+	w.Strike(0, Vector{X: -4}, Vector{})
+	for range 50 {
+		w.Step()
+	}
+	objects = w.Snapshot()
+	if objects[0].Position.X < objects[1].Position.X || objects[1].Velocity.X >= 0 {
+		t.Errorf("the ball moving left passed through: %v and %v, the other moving at %v", objects[0].Position, objects[1].Position, objects[1].Velocity)
+	}
+}
+
+// TestPreSolveFriction hits a ball a glancing blow with and without
+// friction on the contact, and checks friction drags the struck ball along
+// with the hit, keeps the bounce's normal impulse and loses energy.
+func TestPreSolveFriction(t *testing.T) {
+	glance := func(options ...WorldOption) []Body {
+		w := NewWorld([]Body{
+			{Position: Vector{X: 300, Y: 240}, Velocity: Vector{X: 4}},
+			{Position: Vector{X: 339, Y: 255}},
+		}, Vector{}, options...)
+		defer w.Close()
+		w.Step()
+		return w.Snapshot()
+	}
+	smooth := glance()
+	rough := glance(WithPreSolve(func(c *Contact, _, _ Body) { c.Friction = 0.5 }))
+
+	normal := UnitVector(Subtract(smooth[1].Position, smooth[0].Position))
+	along := func(v Vector) float64 { return DotProduct(v, normal) }
+	energy := func(objects []Body) float64 {
+		return objects[0].Velocity.MagnitudeSquared() + objects[1].Velocity.MagnitudeSquared()
+	}
+	if math.Abs(along(smooth[1].Velocity)-along(rough[1].Velocity)) > 0.05 {
+		t.Errorf("friction changed the push along the normal from %.3f to %.3f", along(smooth[1].Velocity), along(rough[1].Velocity))
+	}
+	if smooth[1].Velocity.X >= rough[1].Velocity.X {
+		t.Errorf("struck ball moving at %v with friction, want it dragged further right than %v", rough[1].Velocity, smooth[1].Velocity)
+	}
+	if energy(rough) >= energy(smooth)-1e-9 {
+		t.Errorf("friction kept all the energy, %.3f", energy(rough))
+	}
+}
+
+// TestGrippingBallsSpinEachOther hits a gripping ball a glancing blow with
+// another and checks both come away spinning, the surfaces' friction
+// having turned them, while their angular momentum about the origin, of
+// motion and turning together, is kept. Position correction moves balls
+// without a push and so would change it, so it is turned off.
+func TestGrippingBallsSpinEachOther(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 300, Y: 240}, Velocity: Vector{X: 4}, Grip: 0.5},
+		{Position: Vector{X: 339, Y: 255}, Grip: 0.5},
+	}, Vector{}, WithPositionCorrection(0, 0, 0, 0))
+	defer w.Close()
+	angularMomentum := func() float64 {
+		total := 0.0
+		for _, b := range w.Snapshot() {
+			total += b.mass()*CrossProduct(b.Position, b.Velocity).Z + b.inertia()*b.AngularVelocity
+		}
+		return total
+	}
+	before := angularMomentum()
+	w.Step()
+
+	objects := w.Snapshot()
+	if objects[0].AngularVelocity == 0 || objects[1].AngularVelocity == 0 {
+		t.Errorf("spins after the glancing blow %v and %v, want both turning", objects[0].AngularVelocity, objects[1].AngularVelocity)
+	}
+	if got := angularMomentum(); math.Abs(got-before) > 1e-6*math.Abs(before) {
+		t.Errorf("angular momentum went from %v to %v", before, got)
+	}
+}
@@ -0,0 +1,39 @@
+package physics
+
+// FieldAt returns the acceleration a massless, unmagnetised ball at rest at
+// p would feel from the world's force fields: gravity with its zones and
+// sources, and the pull of the bodies if they attract one another. It is
+// for drawing the field; the bodies are those of the last step.
+func (w *World) FieldAt(p Vector) Vector {
+	return Add(w.GravityAt(p), w.AttractionAt(p))
+}
+
+// AttractionAt returns the acceleration at p towards the bodies with mass,
+// or nothing if the world has no attraction.
+func (w *World) AttractionAt(p Vector) Vector {
+	if w.attraction == 0 {
+		return Vector{}
+	}
+	return ScalarMult(pullAt(w.Snapshot(), p, NoBody), w.attraction)
+}
+
+// MagneticFieldAt returns the magnetic field at p of the bar magnets and
+// the magnetised balls, scaled by the world's magnetism, or nothing if the
+// world has none. A magnetised ball there turns to line up with it.
+func (w *World) MagneticFieldAt(p Vector) Vector {
+	if w.magnetism == 0 {
+		return Vector{}
+	}
+	var field Vector
+	for _, magnet := range w.Magnets {
+		north, south, strength := magnet.poles()
+		field = Add(field, poleField(strength, Subtract(p, north)))
+		field = Add(field, poleField(-strength, Subtract(p, south)))
+	}
+	for _, b := range w.Snapshot() {
+		if b.Moment != (Vector{}) {
+			field = Add(field, dipoleField(b.Moment, Subtract(p, b.Position)))
+		}
+	}
+	return ScalarMult(field, w.magnetism)
+}
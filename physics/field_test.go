@@ -0,0 +1,44 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestFieldAtAddsAttraction checks the field beside a heavy body adds its
+// pull to the world's gravity, and that a massless body adds nothing.
+func TestFieldAtAddsAttraction(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 300, Y: 200}, Mass: 100},
+		{Position: Vector{X: 100, Y: 100}, Mass: 0},
+	}, Vector{Y: 0.3}, WithAttraction(2))
+	defer w.Close()
+
+	got := w.FieldAt(Vector{X: 250, Y: 200})
+	pull := 2 * 100 * 50 / math.Pow(50*50+attractionSoftening*attractionSoftening, 1.5)
+	if math.Abs(got.X-pull) > 1e-9 || math.Abs(got.Y-0.3) > 1e-9 {
+		t.Errorf("field %v, want (%v, 0.3)", got, pull)
+	}
+}
+
+// TestMagneticFieldRunsNorthToSouth checks the field beside a bar magnet
+// runs back along it from its north pole to its south, as a compass needle
+// there would line up, and that a world without magnetism has no field.
+func TestMagneticFieldRunsNorthToSouth(t *testing.T) {
+	magnet := StaticMagnet{Position: Vector{X: 300, Y: 200}, Moment: Vector{X: 1000}, Length: 100}
+	w := NewWorld(nil, Vector{}, WithMagnetism(1, magnet))
+	defer w.Close()
+
+	if got := w.MagneticFieldAt(Vector{X: 300, Y: 260}); got.X >= 0 || math.Abs(got.Y) > 1e-9 {
+		t.Errorf("field beside the magnet's middle %v, want it pointing back along the magnet", got)
+	}
+	if got := w.MagneticFieldAt(Vector{X: 400, Y: 200}); got.X <= 0 {
+		t.Errorf("field past the north pole %v, want it pointing on away from it", got)
+	}
+
+	plain := NewWorld(nil, Vector{})
+	defer plain.Close()
+	if got := plain.MagneticFieldAt(Vector{X: 300, Y: 260}); got != (Vector{}) {
+		t.Errorf("field %v without magnetism, want none", got)
+	}
+}
@@ -0,0 +1,103 @@
+package physics
+
+// FlockSettings steers the balls of each flock the way boids fly: away
+// from flockmates crowding them, towards their heading and towards their
+// middle. Each weight scales how hard its rule steers; each rule asks for
+// a change of velocity towards flying at cruise speed its way, capped at
+// MaxForce per tick. A zero value leaves flocking disabled.
+type FlockSettings struct {
+	// Reach is how far a ball can see its flockmates, in world units
+	Reach      float64
+	Separation float64
+	Alignment  float64
+	Cohesion   float64
+	Cruise     float64
+	MaxForce   float64
+}
+
+// WithFlocking steers every ball with a flock by the given settings.
+// Balls without one, walls, obstacles and fields go on acting on the
+// flock as usual.
+func WithFlocking(settings FlockSettings) WorldOption {
+	return func(w *World) {
+		w.flocking = settings
+	}
+}
+
+func (f FlockSettings) enabled() bool {
+	return f.Reach > 0
+}
+
+// offset returns the offset from q to p, across a seam if the world wraps.
+func (w *World) offset(p, q Vector) Vector {
+	if w.Arena.Shape == TorusArena {
+		return w.Arena.separation(p, q)
+	}
+	return Subtract(p, q)
+}
+
+// flock steers every ball that belongs to a flock for dt ticks, each from
+// what its flockmates were doing at the start of the substep.
+func (w *World) flock(objects []Body, dt float64) {
+	f := w.flocking
+	if !f.enabled() {
+		return
+	}
+
+	// Steering reads every ball's velocity, so work from a copy
+	w.flockVelocities = w.flockVelocities[:0]
+	for i := range objects {
+		w.flockVelocities = append(w.flockVelocities, objects[i].Velocity)
+	}
+
+	w.pool.parallelFor(len(objects), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			currBall := &objects[i]
+			scale := w.lodTimestep(i)
+			if currBall.Flock == 0 || scale == 0 {
+				continue
+			}
+
+			var away, heading, middle Vector
+			neighbours := 0
+			for j := range objects {
+				if j == i || objects[j].Flock != currBall.Flock {
+					continue
+				}
+				offset := w.offset(currBall.Position, objects[j].Position)
+				distanceSquared := offset.MagnitudeSquared()
+				if distanceSquared >= f.Reach*f.Reach || distanceSquared == 0 {
+					continue
+				}
+				// Closer flockmates push harder
+				away = Add(away, ScalarMult(offset, 1/distanceSquared))
+				heading = Add(heading, w.flockVelocities[j])
+				middle = Add(middle, offset)
+				neighbours++
+			}
+			if neighbours == 0 {
+				continue
+			}
+
+			velocity := w.flockVelocities[i]
+			steer := ScalarMult(f.steer(away, velocity), f.Separation)
+			steer = Add(steer, ScalarMult(f.steer(heading, velocity), f.Alignment))
+			// middle sums offsets from the flockmates, so it points away
+			steer = Add(steer, ScalarMult(f.steer(ScalarMult(middle, -1), velocity), f.Cohesion))
+			currBall.Velocity = Add(currBall.Velocity, ScalarMult(steer, dt*scale))
+		}
+	})
+}
+
+// steer returns the change of velocity that would have a ball flying at
+// cruise speed in direction, capped at MaxForce.
+func (f FlockSettings) steer(direction, velocity Vector) Vector {
+	if direction == (Vector{}) {
+		return Vector{}
+	}
+	change := Subtract(ScalarMult(UnitVector(direction), f.Cruise), velocity)
+	if size := change.Magnitude(); size > f.MaxForce {
+		change = ScalarMult(change, f.MaxForce/size)
+	}
+	return change
+}
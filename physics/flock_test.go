@@ -0,0 +1,14 @@
+package physics
+
+import "testing"
+
+func TestSteerIsCapped(t *testing.T) {
+	f := FlockSettings{Cruise: 3, MaxForce: 0.05}
+	change := f.steer(Vector{X: 1}, Vector{X: -3})
+	if got := change.Magnitude(); got > f.MaxForce+epsilon {
+		t.Errorf("steered by %v, want at most %v", got, f.MaxForce)
+	}
+	if change.X <= 0 {
+		t.Errorf("steered by %v, want towards +x", change)
+	}
+}
@@ -0,0 +1,59 @@
+package physics
+
+import "math"
+
+// Freeze pins body i where it is, stopping it dead. A frozen body stands
+// as still as static geometry: it ignores gravity and every other force,
+// and nothing it touches can push it, until it is thawed. It must not be
+// called while a step runs.
+func (w *World) Freeze(i int) {
+	objects := w.front.Load().objects
+	if i >= len(objects) {
+		return
+	}
+	objects[i].Frozen = true
+	objects[i].Velocity = Vector{}
+	objects[i].Spin = Vector{}
+	objects[i].AngularVelocity = 0
+}
+
+// Thaw lets a frozen body i move again, from rest. It must not be called
+// while a step runs.
+func (w *World) Thaw(i int) {
+	objects := w.front.Load().objects
+	if i >= len(objects) {
+		return
+	}
+	objects[i].Frozen = false
+}
+
+// BodyAt returns the body under the point p, the one whose middle is
+// nearest if several are, or false if there is none.
+func (w *World) BodyAt(p Vector) (int, bool) {
+	objects := w.Snapshot()
+	found, nearest := -1, math.Inf(1)
+	for _, i := range w.QueryRect(p, p, nil) {
+		if i >= len(objects) {
+			continue
+		}
+		offset := Subtract(objects[i].Position, p)
+		if distance := offset.Magnitude(); distance < objects[i].Size() && distance < nearest {
+			found, nearest = i, distance
+		}
+	}
+	return found, found >= 0
+}
+
+// ScreenTransform maps points on a screen, in pixels, to the world points
+// drawn there, as a camera does.
+type ScreenTransform interface {
+	ScreenToWorld(p Vector) Vector
+}
+
+// BodyAtScreenPoint returns the body drawn under the screen point x, y
+// through view, as BodyAt does for the world point there. Tools picking
+// bodies with the mouse should go through it rather than undoing the view
+// themselves.
+func (w *World) BodyAtScreenPoint(x, y float64, view ScreenTransform) (int, bool) {
+	return w.BodyAt(view.ScreenToWorld(Vector{X: x, Y: y}))
+}
@@ -0,0 +1,114 @@
+package physics
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestFrozenBodyHoldsStill freezes a ball in mid-air, drops another onto
+// it and checks the frozen one neither falls nor is knocked aside while the
+// other bounces off, then thaws it and checks it falls.
+func TestFrozenBodyHoldsStill(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 320, Y: 300}, Velocity: Vector{X: 3}},
+		{Position: Vector{X: 325, Y: 200}},
+	}, Vector{Y: 0.3})
+	defer w.Close()
+	w.Step()
+	pinned := w.Snapshot()[0].Position
+
+	i, ok := w.BodyAt(Add(pinned, Vector{X: 5, Y: -5}))
+	if !ok || i != 0 {
+		t.Fatalf("body at the pinned ball = %d, %v, want 0", i, ok)
+	}
+	w.Freeze(i)
+
+	bounced := false
+	for range 120 {
+		w.Step()
+		objects := w.Snapshot()
+		if objects[0].Position != pinned {
+			t.Fatalf("frozen ball moved to %v", objects[0].Position)
+		}
+		bounced = bounced || objects[1].Velocity.Y < 0
+	}
+	if !bounced {
+		t.Error("the dropped ball never bounced off the frozen one")
+	}
+
+	w.Thaw(0)
+	for range 10 {
+		w.Step()
+	}
+	if objects := w.Snapshot(); objects[0].Position.Y <= pinned.Y {
+		t.Errorf("thawed ball at %v, want it fallen below %v", objects[0].Position, pinned)
+	}
+	if _, ok := w.BodyAt(Vector{X: 20, Y: 20}); ok {
+		t.Error("found a body in an empty corner")
+	}
+}
+
+// zoomedView is a view magnifying the world by zoom, with the world point
+// corner at its top-left.
+type zoomedView struct {
+	corner Vector
+	zoom   float64
+}
+
+func (v zoomedView) ScreenToWorld(p Vector) Vector {
+	return Add(v.corner, ScalarMult(p, 1/v.zoom))
+}
+
+// TestBodyAtScreenPoint picks balls through a panned and zoomed view,
+// checking the screen point is undone back to the world before testing
+// which ball it is in.
+func TestBodyAtScreenPoint(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 100, Y: 100}},
+		{Position: Vector{X: 300, Y: 100}},
+	}, Vector{})
+	defer w.Close()
+	w.Step()
+
+	view := zoomedView{corner: Vector{X: 250, Y: 50}, zoom: 2}
+	if i, ok := w.BodyAtScreenPoint(110, 110, view); !ok || i != 1 {
+		t.Errorf("body at screen point 110, 110 = %d, %v, want 1", i, ok)
+	}
+	if _, ok := w.BodyAtScreenPoint(0, 0, view); ok {
+		t.Error("found a body at the top-left of the view, which shows empty space")
+	}
+}
+
+// TestBodyAtBetweenSteps picks bodies as a paused world would have them,
+// never stepped since they were added, moved or removed.
+func TestBodyAtBetweenSteps(t *testing.T) {
+	w := NewWorld([]Body{{Position: Vector{X: 100, Y: 100}}}, Vector{Y: 0.3})
+	defer w.Close()
+	if i, ok := w.BodyAt(Vector{X: 100, Y: 100}); !ok || i != 0 {
+		t.Errorf("body at the first ball before any step = %d, %v, want 0", i, ok)
+	}
+
+	spawned := w.Spawn(Body{Position: Vector{X: 400, Y: 300}})
+	if i, ok := w.BodyAt(Vector{X: 400, Y: 300}); !ok || i != spawned {
+		t.Errorf("body at a ball spawned while paused = %d, %v, want %d", i, ok, spawned)
+	}
+	if got := w.QueryRect(Vector{X: 390, Y: 290}, Vector{X: 410, Y: 310}, nil); !slices.Contains(got, spawned) {
+		t.Errorf("query round the spawned ball found %v, want it to include %d", got, spawned)
+	}
+
+	w.Teleport(spawned, Vector{X: 550, Y: 150}, Vector{})
+	if i, ok := w.BodyAt(Vector{X: 550, Y: 150}); !ok || i != spawned {
+		t.Errorf("body where the ball was teleported = %d, %v, want %d", i, ok, spawned)
+	}
+	if _, ok := w.BodyAt(Vector{X: 400, Y: 300}); ok {
+		t.Error("found a body where the teleported ball used to be")
+	}
+
+	w.Remove(0)
+	if i, ok := w.BodyAt(Vector{X: 550, Y: 150}); !ok || i != 0 {
+		t.Errorf("body at the ball moved down by a removal = %d, %v, want 0", i, ok)
+	}
+	if _, ok := w.BodyAt(Vector{X: 100, Y: 100}); ok {
+		t.Error("found the removed ball")
+	}
+}
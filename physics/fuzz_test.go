@@ -0,0 +1,132 @@
+package physics
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// fuzzLimit bounds the finite inputs whose results must also be finite.
+// Beyond it velocities can legitimately overflow float64 within a step.
+const fuzzLimit = 1e6
+
+// decodeBodies turns fuzz input into a gravity vector and up to 64 balls,
+// reading each float64 from eight bytes so every bit pattern, including
+// NaN and infinities, is reachable.
+func decodeBodies(data []byte) (Vector, []Body) {
+	next := func() float64 {
+		if len(data) < 8 {
+			return 0
+		}
+		f := math.Float64frombits(binary.LittleEndian.Uint64(data))
+		data = data[8:]
+		return f
+	}
+
+	gravity := Vector{X: next(), Y: next()}
+	var objects []Body
+	for len(data) >= 32 && len(objects) < 64 {
+		objects = append(objects, Body{
+			Position: Vector{X: next(), Y: next()},
+			Velocity: Vector{X: next(), Y: next()},
+		})
+	}
+	return gravity, objects
+}
+
+func encodeBodies(gravity Vector, objects []Body) []byte {
+	var data []byte
+	put := func(f float64) {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(f))
+	}
+
+	put(gravity.X)
+	put(gravity.Y)
+	for _, ball := range objects {
+		put(ball.Position.X)
+		put(ball.Position.Y)
+		put(ball.Velocity.X)
+		put(ball.Velocity.Y)
+	}
+	return data
+}
+
+func withinFuzzLimit(fs ...float64) bool {
+	for _, f := range fs {
+		if math.IsNaN(f) || math.Abs(f) > fuzzLimit {
+			return false
+		}
+	}
+	return true
+}
+
+// crossingBalls returns a few balls thrown across each other's paths.
+func crossingBalls() []Body {
+	var objects []Body
+	for k := range 6 {
+		velocity := Vector{X: 2, Y: 3}
+		if k%2 == 1 {
+			velocity = Vector{X: -1, Y: -2}
+		}
+		objects = append(objects, Body{Position: Vector{X: 40 + 60*float64(k), Y: 60 + 30*float64(k%3)}, Velocity: velocity})
+	}
+	return objects
+}
+
+// ballGrid returns a grid of balls filling the screen, each heading off
+// its own way.
+func ballGrid() []Body {
+	const (
+		columns = 8
+		rows    = 6
+	)
+	var objects []Body
+	for k := range columns * rows {
+		heading := 2.39996 * float64(k)
+		objects = append(objects, Body{
+			Position: Vector{X: (float64(k%columns) + 0.5) * ScreenWidth / columns, Y: (float64(k/columns) + 0.5) * ScreenHeight / rows},
+			Velocity: Vector{X: 3 * math.Cos(heading), Y: 3 * math.Sin(heading)},
+		})
+	}
+	return objects
+}
+
+// FuzzWorldStep steps arbitrary body configurations looking for panics,
+// hangs and NaN. Any input must step without crashing; reasonable finite
+// input must stay finite.
+func FuzzWorldStep(f *testing.F) {
+	f.Add(encodeBodies(Vector{Y: .3}, crossingBalls()))
+	f.Add(encodeBodies(Vector{}, ballGrid()))
+	f.Add(encodeBodies(Vector{Y: .3}, []Body{
+		{Position: Vector{X: 100, Y: 100}},
+		{Position: Vector{X: 100, Y: 100}},
+	}))
+	f.Add(encodeBodies(Vector{Y: 1}, []Body{
+		{Position: Vector{X: -5000, Y: 1e5}, Velocity: Vector{X: 900, Y: -900}},
+		{Position: Vector{X: 320, Y: 240}, Velocity: Vector{X: -fuzzLimit}},
+	}))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		gravity, objects := decodeBodies(data)
+
+		sane := withinFuzzLimit(gravity.X, gravity.Y)
+		for _, ball := range objects {
+			sane = sane && withinFuzzLimit(ball.Position.X, ball.Position.Y, ball.Velocity.X, ball.Velocity.Y)
+		}
+
+		w := NewWorld(objects, gravity)
+		defer w.Close()
+		for i := 0; i < 50; i++ {
+			w.Step()
+		}
+
+		if !sane {
+			return
+		}
+		for i, ball := range w.Snapshot() {
+			if !finite(ball.Position) || !finite(ball.Velocity) {
+				t.Fatalf("ball %d became non-finite: %+v", i, ball)
+			}
+		}
+	})
+}
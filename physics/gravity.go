@@ -0,0 +1,39 @@
+package physics
+
+import "math"
+
+// attractionSoftening keeps the pull between two bodies finite when they
+// pass through one another, in world units.
+const attractionSoftening = 1
+
+// WithAttraction makes every body with mass pull on every other body by
+// Newton's inverse-square law with gravitational constant g. Massless
+// bodies are pulled but pull on nothing.
+func WithAttraction(g float64) WorldOption {
+	return func(w *World) {
+		w.attraction = g
+	}
+}
+
+// WithoutContacts stops bodies colliding with each other. Walls, static
+// geometry and constraints still apply.
+func WithoutContacts() WorldOption {
+	return func(w *World) {
+		w.ignoreContacts = true
+	}
+}
+
+// pullAt sums the inverse-square pull at p towards every body with mass
+// but skip, before the gravitational constant.
+func pullAt(objects []Body, p Vector, skip int) Vector {
+	var pull Vector
+	for j := range objects {
+		if j == skip || objects[j].Mass == 0 {
+			continue
+		}
+		offset := Subtract(objects[j].Position, p)
+		distanceSquared := offset.MagnitudeSquared() + attractionSoftening*attractionSoftening
+		pull = Add(pull, ScalarMult(offset, objects[j].Mass/(distanceSquared*math.Sqrt(distanceSquared))))
+	}
+	return pull
+}
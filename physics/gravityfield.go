@@ -0,0 +1,69 @@
+package physics
+
+// GravitySource is one layer of a world's gravity field. Given the gravity
+// the world and the layers before it make at p, it returns the gravity
+// there with its own pull added, or put in its place.
+type GravitySource interface {
+	Gravity(p, g Vector) Vector
+}
+
+// UniformGravity adds the same acceleration everywhere, such as a steady
+// wind.
+type UniformGravity struct {
+	Acceleration Vector
+}
+
+// PointGravity pulls towards Centre by the inverse square of the distance,
+// as a planet or star would, Strength being the pull one unit away. Inside
+// Radius the pull falls off to nothing at the centre, as inside a solid
+// planet, so a body passing through it isn't flung off.
+type PointGravity struct {
+	Centre   Vector
+	Strength float64
+	Radius   float64
+}
+
+// RegionGravity replaces the gravity made by everything before it with
+// Acceleration inside Box, and leaves it alone outside.
+type RegionGravity struct {
+	Box          StaticBox
+	Acceleration Vector
+}
+
+// WithGravitySources layers sources over the world's gravity and its
+// zones, in the order given, so a region listed last overrides the pull of
+// everything before it.
+func WithGravitySources(sources ...GravitySource) WorldOption {
+	return func(w *World) {
+		w.GravitySources = append(w.GravitySources, sources...)
+	}
+}
+
+// Gravity adds the uniform acceleration to g.
+func (u UniformGravity) Gravity(_, g Vector) Vector {
+	return Add(g, u.Acceleration)
+}
+
+// Gravity adds the pull towards the centre to g.
+func (s PointGravity) Gravity(p, g Vector) Vector {
+	offset := Subtract(s.Centre, p)
+	distance := offset.Magnitude()
+	if distance == 0 {
+		return g
+	}
+	reach := max(distance, s.Radius)
+	pull := s.Strength / (reach * reach)
+	if distance < s.Radius {
+		pull *= distance / s.Radius
+	}
+	return Add(g, ScalarMult(offset, pull/distance))
+}
+
+// Gravity returns the region's acceleration at a point inside it, and g
+// anywhere else.
+func (r RegionGravity) Gravity(p, g Vector) Vector {
+	if r.Box.Contains(p) {
+		return r.Acceleration
+	}
+	return g
+}
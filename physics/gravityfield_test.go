@@ -0,0 +1,64 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGravitySourcesLayer checks the world's gravity, a uniform wind, a
+// point source and a weightless region combine in order at a few points.
+func TestGravitySourcesLayer(t *testing.T) {
+	w := NewWorld(nil, Vector{Y: 0.3}, WithGravitySources(
+		UniformGravity{Acceleration: Vector{X: 0.1}},
+		PointGravity{Centre: Vector{X: 400, Y: 200}, Strength: 1000, Radius: 20},
+		RegionGravity{Box: StaticBox{Max: Vector{X: 100, Y: 100}}},
+	))
+	defer w.Close()
+
+	tests := []struct {
+		name string
+		p    Vector
+		want Vector
+	}{
+		{"far off", Vector{X: 400, Y: 10200}, Vector{X: 0.1, Y: 0.3 - 1e-5}},
+		{"beside the source", Vector{X: 300, Y: 200}, Vector{X: 0.2, Y: 0.3}},
+		{"inside its radius", Vector{X: 390, Y: 200}, Vector{X: 0.1 + 1.25, Y: 0.3}},
+		{"at its centre", Vector{X: 400, Y: 200}, Vector{X: 0.1, Y: 0.3}},
+		{"in the region", Vector{X: 50, Y: 50}, Vector{}},
+	}
+	for _, tt := range tests {
+		got := w.GravityAt(tt.p)
+		if math.Abs(got.X-tt.want.X) > 1e-9 || math.Abs(got.Y-tt.want.Y) > 1e-9 {
+			t.Errorf("%s: gravity %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestPointGravityHoldsAnOrbit sets a ball circling a point source at the
+// speed for a circular orbit and checks it is still about as far out after
+// going once round.
+func TestPointGravityHoldsAnOrbit(t *testing.T) {
+	const (
+		radius = 100.0
+		speed  = 2.0
+	)
+	centre := Vector{X: ScreenWidth / 2, Y: ScreenHeight / 2}
+	w := NewWorld([]Body{
+		{Position: Add(centre, Vector{X: radius}), Velocity: Vector{Y: speed}},
+	}, Vector{}, WithSubsteps(4), WithGravitySources(
+		PointGravity{Centre: centre, Strength: speed * speed * radius, Radius: 10},
+	))
+	defer w.Close()
+
+	period := 2 * math.Pi * radius / speed
+	for i := 0; i < int(period); i++ {
+		w.Step()
+		offset := Subtract(w.Snapshot()[0].Position, centre)
+		if d := offset.Magnitude(); math.Abs(d-radius) > radius*0.05 {
+			t.Fatalf("tick %d: %.1f from the centre, want about %.0f", i, d, radius)
+		}
+	}
+	if end := Subtract(w.Snapshot()[0].Position, Add(centre, Vector{X: radius})); end.Magnitude() > radius*0.2 {
+		t.Errorf("ball ended a period %v from where it started, want it back near there", end)
+	}
+}
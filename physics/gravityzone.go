@@ -0,0 +1,34 @@
+package physics
+
+// GravityZone is a box inside which gravity is multiplied by scale: -1
+// turns it upside down, 0 switches it off and a fraction between weakens
+// it.
+type GravityZone struct {
+	Box   StaticBox
+	Scale float64
+}
+
+// WithGravityZones adds regions with their own gravity to the world.
+// Where zones overlap, the first one given wins.
+func WithGravityZones(zones ...GravityZone) WorldOption {
+	return func(w *World) {
+		w.GravityZones = append(w.GravityZones, zones...)
+	}
+}
+
+// GravityAt returns the gravity a body at p feels: the world's, scaled by
+// the first zone holding p, with each of the gravity sources layered over
+// it in turn.
+func (w *World) GravityAt(p Vector) Vector {
+	g := w.gravity
+	for _, z := range w.GravityZones {
+		if z.Box.Contains(p) {
+			g = ScalarMult(w.gravity, z.Scale)
+			break
+		}
+	}
+	for _, s := range w.GravitySources {
+		g = s.Gravity(p, g)
+	}
+	return g
+}
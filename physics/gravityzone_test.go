@@ -0,0 +1,34 @@
+package physics
+
+import "testing"
+
+// TestGravityZones drops a ball in each kind of zone and one outside them
+// all, and checks each falls, floats or rises as its zone says, with the
+// first of two overlapping zones winning.
+func TestGravityZones(t *testing.T) {
+	gravity := Vector{Y: 0.3}
+	objects := []Body{
+		{Position: Vector{X: 100, Y: 200}},
+		{Position: Vector{X: 250, Y: 200}},
+		{Position: Vector{X: 400, Y: 200}},
+		{Position: Vector{X: 550, Y: 200}},
+	}
+	w := NewWorld(objects, gravity, WithGravityZones(
+		GravityZone{Box: StaticBox{Min: Vector{X: 200}, Max: Vector{X: 300, Y: ScreenHeight}}, Scale: 0},
+		GravityZone{Box: StaticBox{Min: Vector{X: 350}, Max: Vector{X: 450, Y: ScreenHeight}}, Scale: -1},
+		GravityZone{Box: StaticBox{Min: Vector{X: 500}, Max: Vector{X: 600, Y: ScreenHeight}}, Scale: 0.25},
+		GravityZone{Box: StaticBox{Min: Vector{X: 200}, Max: Vector{X: ScreenWidth, Y: ScreenHeight}}, Scale: 2},
+	))
+	defer w.Close()
+
+	const ticks = 10
+	for i := 0; i < ticks; i++ {
+		w.Step()
+	}
+	for i, scale := range []float64{1, 0, -1, 0.25} {
+		want := ScalarMult(gravity, scale*ticks)
+		if got := w.Snapshot()[i].Velocity; !vectorsClose(got, want) {
+			t.Errorf("ball %d moving at %v, want %v for gravity times %v", i, got, want, scale)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package physics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GuardMode is what the NaN guard does with a body whose state has gone
+// to NaN or infinity.
+type GuardMode int
+
+const (
+	// HaltOnNaN stops the world at the last step every body was sound,
+	// leaving the bad one where it can be looked into
+	HaltOnNaN GuardMode = iota
+	// ClampNaN puts the bad body back where it was before the step and
+	// stops it, and carries on
+	ClampNaN
+)
+
+// guard watches for bodies going to NaN or infinity, which otherwise
+// vanish from the screen and poison whatever they touch next.
+type guard struct {
+	enabled bool
+	mode    GuardMode
+	report  func(*Diagnosis)
+	// halted is what stopped the world, or nil while it runs
+	halted *Diagnosis
+}
+
+// Diagnosis describes a body found with a NaN or infinity in its state: the
+// step it happened in, the body as it came out of it and as it went in,
+// and the contacts and impacts it was in along the way.
+type Diagnosis struct {
+	Step     uint64
+	Body     int
+	State    Body
+	Before   Body
+	Contacts []Contact
+	Impacts  []Impact
+}
+
+// Error dumps the diagnosis over several lines, to be read through.
+func (d *Diagnosis) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "body %d went bad in step %d\n", d.Body, d.Step)
+	fmt.Fprintf(&b, "  after:  position %v velocity %v angle %v turning %v\n", d.State.Position, d.State.Velocity, d.State.Angle, d.State.AngularVelocity)
+	fmt.Fprintf(&b, "  before: position %v velocity %v angle %v turning %v mass %v radius %v\n", d.Before.Position, d.Before.Velocity, d.Before.Angle, d.Before.AngularVelocity, d.Before.mass(), d.Before.Size())
+	for _, c := range d.Contacts {
+		fmt.Fprintf(&b, "  contact %d-%d normal %v overlap %v\n", c.A, c.B, c.Normal, c.Penetration)
+	}
+	for _, hit := range d.Impacts {
+		fmt.Fprintf(&b, "  impact %d-%d at %v speed %v impulse %v\n", hit.A, hit.B, hit.Position, hit.Speed, hit.Impulse)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// WithNaNGuard checks every body after each step for a NaN or infinity in
+// its position, velocity, spin or angle, and halts the world or clamps the
+// body as mode says. report, if set, is given a diagnosis of each bad body
+// found, to log or show; it must not step the world.
+func WithNaNGuard(mode GuardMode, report func(*Diagnosis)) WorldOption {
+	return func(w *World) {
+		w.guard = guard{enabled: true, mode: mode, report: report}
+	}
+}
+
+// Halted returns the diagnosis of the body that halted the world, or nil
+// if it hasn't been.
+func (w *World) Halted() *Diagnosis {
+	return w.guard.halted
+}
+
+// sound reports whether a body's state is all numbers.
+func (b *Body) sound() bool {
+	for _, x := range []float64{b.Position.X, b.Position.Y, b.Velocity.X, b.Velocity.Y, b.Spin.X, b.Spin.Y, b.Spin.Z, b.AngularVelocity, b.Angle} {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// inspect looks the step just taken over for bad bodies, carrying it from
+// before as objects. It returns false if the world halts, in which case
+// the step mustn't be published.
+func (w *World) inspect(before, objects []Body) bool {
+	for i := range objects {
+		if objects[i].sound() {
+			continue
+		}
+		d := &Diagnosis{Step: w.Steps + 1, Body: i, State: objects[i]}
+		if i < len(before) {
+			d.Before = before[i]
+		}
+		for _, c := range w.contacts {
+			if c.A == i || c.B == i {
+				d.Contacts = append(d.Contacts, c)
+			}
+		}
+		for _, hit := range w.impacts {
+			if hit.A == i || hit.B == i {
+				d.Impacts = append(d.Impacts, hit)
+			}
+		}
+		if w.guard.report != nil {
+			w.guard.report(d)
+		}
+		if w.guard.mode == HaltOnNaN {
+			w.guard.halted = d
+			return false
+		}
+		// Clamped, the body starts again from where it was, at rest
+		objects[i] = d.Before
+		objects[i].Velocity, objects[i].Spin, objects[i].AngularVelocity = Vector{}, Vector{}, 0
+		if !objects[i].sound() {
+			extent := w.Arena.Extent()
+			objects[i].Position, objects[i].Angle = ScalarMult(Add(extent.Min, extent.Max), 0.5), 0
+		}
+	}
+	return true
+}
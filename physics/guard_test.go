@@ -0,0 +1,58 @@
+package physics
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestNaNGuard starts a ball off with a NaN velocity beside a sound one,
+// checking the halting guard stops the world on the first step with the
+// last sound state still published, and the clamping one stops the ball
+// where it was and lets the other carry on.
+func TestNaNGuard(t *testing.T) {
+	bodies := func() []Body {
+		return []Body{
+			{Position: Vector{X: 200, Y: 200}, Velocity: Vector{X: math.NaN()}},
+			{Position: Vector{X: 400, Y: 200}},
+		}
+	}
+
+	var reported []*Diagnosis
+	w := NewWorld(bodies(), Vector{Y: 0.3}, WithNaNGuard(HaltOnNaN, func(d *Diagnosis) {
+		reported = append(reported, d)
+	}))
+	defer w.Close()
+	for range 5 {
+		w.Step()
+	}
+	d := w.Halted()
+	if d == nil || len(reported) != 1 || reported[0] != d {
+		t.Fatalf("halted with %v after reporting %d, want the one report", d, len(reported))
+	}
+	if d.Body != 0 || d.Step != 1 || w.Steps != 0 {
+		t.Errorf("halted on body %d in step %d with %d taken, want body 0 in step 1 with none", d.Body, d.Step, w.Steps)
+	}
+	if !strings.Contains(d.Error(), "body 0 went bad in step 1") {
+		t.Errorf("diagnosis reads %q", d.Error())
+	}
+	if objects := w.Snapshot(); objects[1].Position != (Vector{X: 400, Y: 200}) {
+		t.Errorf("halted world shows the sound ball at %v, want it where it started", objects[1].Position)
+	}
+
+	w = NewWorld(bodies(), Vector{Y: 0.3}, WithNaNGuard(ClampNaN, nil))
+	defer w.Close()
+	for range 5 {
+		w.Step()
+	}
+	if w.Halted() != nil || w.Steps != 5 {
+		t.Fatalf("clamping world halted after %d steps", w.Steps)
+	}
+	objects := w.Snapshot()
+	if !objects[0].sound() || objects[0].Position.X != 200 {
+		t.Errorf("clamped ball at %v moving %v, want it sound near where it started", objects[0].Position, objects[0].Velocity)
+	}
+	if objects[1].Position.Y <= 200 {
+		t.Errorf("sound ball at %v, want it falling", objects[1].Position)
+	}
+}
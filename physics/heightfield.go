@@ -0,0 +1,96 @@
+package physics
+
+import "math"
+
+// Heightfield is immovable rolling ground: its surface is at height
+// Heights[k] a distance k*Spacing along from X, straight between, and it is
+// solid all the way down below that. A ball only tests the few stretches it
+// is over, so a long, finely sampled landscape costs no more than a short
+// one.
+type Heightfield struct {
+	X       float64
+	Spacing float64
+	Heights []float64
+}
+
+// NewHeightfield samples height, which gives the surface's y at an x, at
+// samples evenly spaced points from x to x+width.
+func NewHeightfield(x, width float64, samples int, height func(x float64) float64) Heightfield {
+	h := Heightfield{X: x, Spacing: width / float64(max(samples-1, 1))}
+	for k := 0; k < samples; k++ {
+		h.Heights = append(h.Heights, height(x+float64(k)*h.Spacing))
+	}
+	return h
+}
+
+// WithHeightfields adds rolling ground to the world.
+func WithHeightfields(fields ...Heightfield) WorldOption {
+	return func(w *World) {
+		w.Heightfields = append(w.Heightfields, fields...)
+	}
+}
+
+// point returns the surface's k-th sample.
+func (h Heightfield) point(k int) Vector {
+	return Vector{X: h.X + float64(k)*h.Spacing, Y: h.Heights[k]}
+}
+
+// HeightAt returns the surface's y at x, or +Inf where x is off either end
+// of it.
+func (h Heightfield) HeightAt(x float64) float64 {
+	if len(h.Heights) < 2 || h.Spacing <= 0 {
+		return math.Inf(1)
+	}
+	along := (x - h.X) / h.Spacing
+	if along < 0 || along > float64(len(h.Heights)-1) {
+		return math.Inf(1)
+	}
+	k := min(int(along), len(h.Heights)-2)
+	t := along - float64(k)
+	return h.Heights[k] + t*(h.Heights[k+1]-h.Heights[k])
+}
+
+// Closest returns the point on the surface nearest to p among the
+// stretches within reach of it either side, and whether there is one.
+func (h Heightfield) Closest(p Vector, reach float64) (Vector, bool) {
+	if len(h.Heights) < 2 || h.Spacing <= 0 {
+		return Vector{}, false
+	}
+	first := max(int(math.Floor((p.X-reach-h.X)/h.Spacing)), 0)
+	last := min(int(math.Ceil((p.X+reach-h.X)/h.Spacing)), len(h.Heights)-1)
+	found, nearest := false, math.Inf(1)
+	var closest Vector
+	for k := first; k < last; k++ {
+		c := StaticSegment{A: h.point(k), B: h.point(k + 1)}.Closest(p)
+		offset := Subtract(p, c)
+		if distance := offset.MagnitudeSquared(); distance < nearest {
+			found, nearest, closest = true, distance, c
+		}
+	}
+	return closest, found
+}
+
+// collideHeightfield pushes a ball up out of the ground, bouncing it off
+// like a wall. A ball that has sunk in past its middle is pushed back up
+// to the surface, rather than on through to the underside.
+func (w *World) collideHeightfield(currBall *Body, h Heightfield) (Vector, float64) {
+	reach := currBall.Size()
+	closest, ok := h.Closest(currBall.Position, reach)
+	if !ok {
+		return Vector{}, 0
+	}
+	offset := Subtract(currBall.Position, closest)
+	distance := offset.Magnitude()
+	normal := Vector{Y: -1}
+	switch {
+	case currBall.Position.Y > h.HeightAt(currBall.Position.X):
+		if distance > 0 {
+			normal = ScalarMult(offset, -1/distance)
+		}
+	case distance >= reach:
+		return Vector{}, 0
+	case distance > 0:
+		normal = ScalarMult(offset, 1/distance)
+	}
+	return w.bounceOff(currBall, closest, normal, 0)
+}
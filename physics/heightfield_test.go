@@ -0,0 +1,64 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHeightfieldHeightAt(t *testing.T) {
+	h := Heightfield{X: 100, Spacing: 10, Heights: []float64{300, 320, 310}}
+	tests := []struct {
+		name string
+		x    float64
+		want float64
+	}{
+		{"first sample", 100, 300},
+		{"between samples", 105, 310},
+		{"last sample", 120, 310},
+		{"before the start", 99, math.Inf(1)},
+		{"past the end", 121, math.Inf(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := h.HeightAt(tt.x); got != tt.want {
+				t.Errorf("HeightAt(%v) = %v, want %v", tt.x, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBallRollsDownHeightfield drops a ball onto a slope of finely
+// sampled ground and checks it never sinks into it and ends up further
+// down the slope.
+func TestBallRollsDownHeightfield(t *testing.T) {
+	ground := NewHeightfield(0, ScreenWidth, 129, func(x float64) float64 { return 300 + 0.3*x })
+	w := NewWorld([]Body{{Position: Vector{X: 100, Y: 200}}}, Vector{Y: .3}, WithHeightfields(ground), WithWallRestitution(0.2))
+	defer w.Close()
+
+	for i := 0; i < 120; i++ {
+		w.Step()
+		p := w.Snapshot()[0].Position
+		if depth := p.Y + BallRadius*math.Cos(math.Atan(0.3)) - ground.HeightAt(p.X); depth > 1 {
+			t.Fatalf("step %d: ball %v into the ground", i, depth)
+		}
+	}
+	if x := w.Snapshot()[0].Position.X; x < 150 {
+		t.Errorf("ball at x = %v, want it rolled on down the slope", x)
+	}
+}
+
+// TestHeightfieldPushesSunkBallUp starts a ball half under flat ground and
+// checks it comes out on top rather than through the bottom.
+func TestHeightfieldPushesSunkBallUp(t *testing.T) {
+	ground := NewHeightfield(0, ScreenWidth, 17, func(float64) float64 { return 300 })
+	w := NewWorld([]Body{{Position: Vector{X: 320, Y: 305}}}, Vector{Y: .3}, WithHeightfields(ground), WithWallRestitution(0))
+	defer w.Close()
+
+	for i := 0; i < 30; i++ {
+		w.Step()
+	}
+	if y, want := w.Snapshot()[0].Position.Y, 300.0-BallRadius; math.Abs(y-want) > 1 {
+		t.Errorf("ball at y = %v, want it resting on the ground at %v", y, want)
+	}
+}
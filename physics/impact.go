@@ -0,0 +1,105 @@
+package physics
+
+// Impact is a collision from the last step, kept so the game can play a
+// sound for it. Walls and static geometry have no body, so b is NoBody for
+// those.
+type Impact struct {
+	A        int
+	B        int
+	Position Vector
+	// Speed is how fast the two sides were closing along the normal
+	Speed float64
+	// Impulse is the momentum the collision exchanged
+	Impulse float64
+}
+
+// impactHandler is a callback listening for hard impacts on one body.
+type impactHandler struct {
+	body      int
+	threshold float64
+	callback  func(Impact)
+}
+
+// OnImpact calls callback for every impact on body i that exchanges at
+// least threshold of impulse, so damage or a sound isn't set off by every
+// tick of a body resting on another. It is called as each step ends, in
+// the order the impacts happened, with the body on either side of the
+// impact; the callback may read the world but must not add or remove
+// bodies, which would renumber the rest of the step's impacts. Handlers
+// follow their body as others are removed, and go with it.
+func (w *World) OnImpact(i int, threshold float64, callback func(Impact)) {
+	w.impactHandlers = append(w.impactHandlers, impactHandler{body: i, threshold: threshold, callback: callback})
+}
+
+// CollisionHandler is called for a collision between a and b, or a and a
+// wall or static geometry when b is nil. The bodies are the step's
+// published state, for reading only.
+type CollisionHandler func(a, b *Body, hit Impact)
+
+// OnCollision calls callback for every impact in the world, between two
+// bodies or a body and a wall, with where it happened and the impulse it
+// exchanged, to play sounds, keep score or gather statistics by. It is
+// called as each step ends, in the order the impacts happened, and resting
+// contacts are impacts every tick, so a callback wanting only real hits
+// should check hit.Impulse. Like OnImpact's, it must not add or remove
+// bodies.
+func (w *World) OnCollision(callback CollisionHandler) {
+	w.collisionHandlers = append(w.collisionHandlers, callback)
+}
+
+// reportImpacts calls the impact and collision handlers for the last
+// step's impacts.
+func (w *World) reportImpacts() {
+	if len(w.impactHandlers) == 0 && len(w.collisionHandlers) == 0 {
+		return
+	}
+	objects := w.Snapshot()
+	for _, hit := range w.impacts {
+		for _, h := range w.impactHandlers {
+			if (hit.A == h.body || hit.B == h.body) && hit.Impulse >= h.threshold {
+				h.callback(hit)
+			}
+		}
+		if len(w.collisionHandlers) == 0 {
+			continue
+		}
+		a, b := &objects[hit.A], (*Body)(nil)
+		if hit.B != NoBody {
+			b = &objects[hit.B]
+		}
+		for _, callback := range w.collisionHandlers {
+			callback(a, b, hit)
+		}
+	}
+}
+
+// LastImpacts returns every collision of the last completed step. The
+// slice is only valid until the next step begins.
+func (w *World) LastImpacts() []Impact {
+	return w.impacts
+}
+
+// obstacleImpact describes ball i hitting something immovable at position
+// with the given closing speed.
+func (w *World) obstacleImpact(objects []Body, i int, position Vector, speed float64) Impact {
+	return Impact{
+		A:        i,
+		B:        NoBody,
+		Position: position,
+		Speed:    speed,
+		Impulse:  (1 + w.restitution(&objects[i], speed)) * speed * objects[i].mass(),
+	}
+}
+
+// Materials returns the materials heard in an impact: the ball's, and the
+// other ball's too if it is made of something else.
+func (hit Impact) Materials(objects []Body) []Material {
+	if hit.A >= len(objects) {
+		return nil
+	}
+	heard := []Material{objects[hit.A].Material}
+	if hit.B != NoBody && hit.B < len(objects) && objects[hit.B].Material != heard[0] {
+		heard = append(heard, objects[hit.B].Material)
+	}
+	return heard
+}
@@ -0,0 +1,91 @@
+package physics
+
+import "testing"
+
+// TestOnImpactIgnoresRestingContacts rests a ball on the floor and rolls
+// another into it, checking a handler with a threshold hears only the hit
+// while one without hears the ball resting on the floor every tick too.
+func TestOnImpactIgnoresRestingContacts(t *testing.T) {
+	ground := float64(ScreenHeight - BallRadius)
+	w := NewWorld([]Body{
+		{Position: Vector{X: 300, Y: ground}},
+		{Position: Vector{X: 100, Y: ground}, Velocity: Vector{X: 5}},
+	}, Vector{Y: .3}, WithWallRestitution(0))
+	defer w.Close()
+
+	var hard []Impact
+	all := 0
+	w.OnImpact(0, 2, func(hit Impact) { hard = append(hard, hit) })
+	w.OnImpact(0, 0, func(Impact) { all++ })
+	for i := 0; i < 60; i++ {
+		w.Step()
+	}
+
+	if len(hard) != 1 || hard[0].A+hard[0].B != 1 {
+		t.Fatalf("hard impacts %v, want the one between the two balls", hard)
+	}
+	if all < 30 {
+		t.Errorf("heard %d impacts without a threshold, want one most ticks from resting on the floor", all)
+	}
+}
+
+// TestOnImpactFollowsItsBody removes a body before the one a handler is on
+// and checks the handler still hears its own body being hit.
+func TestOnImpactFollowsItsBody(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 50, Y: 50}},
+		{Position: Vector{X: 300, Y: 200}},
+		{Position: Vector{X: 100, Y: 200}, Velocity: Vector{X: 5}},
+	}, Vector{})
+	defer w.Close()
+
+	var heard []Impact
+	w.OnImpact(1, 1, func(hit Impact) { heard = append(heard, hit) })
+	w.OnImpact(0, 0, func(hit Impact) { t.Errorf("handler on a removed body heard %v", hit) })
+	w.Remove(0)
+	for i := 0; i < 60; i++ {
+		w.Step()
+	}
+	if len(heard) != 1 || heard[0].A+heard[0].B != 1 {
+		t.Errorf("heard %v, want the hit between bodies 0 and 1", heard)
+	}
+}
+
+// TestOnCollisionHearsBallsAndWalls rolls one ball into another, which goes
+// on into the right wall, and checks the handler hears both hits with the
+// bodies involved, where they met and a positive impulse.
+func TestOnCollisionHearsBallsAndWalls(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 100, Y: 200}, Velocity: Vector{X: 5}, Name: "striker"},
+		{Position: Vector{X: 300, Y: 200}, Name: "target"},
+	}, Vector{})
+	defer w.Close()
+
+	var balls, walls int
+	w.OnCollision(func(a, b *Body, hit Impact) {
+		if hit.Impulse <= 0 {
+			t.Errorf("impact %v exchanged no impulse", hit)
+		}
+		if b == nil {
+			walls++
+			if a.Name != "target" || hit.Position.X < ScreenWidth-1 {
+				t.Errorf("%s hit a wall at %v, want the target at the right wall", a.Name, hit.Position)
+			}
+			return
+		}
+		balls++
+		if a.Name+b.Name != "strikertarget" && a.Name+b.Name != "targetstriker" {
+			t.Errorf("collision between %s and %s, want the striker and target", a.Name, b.Name)
+		}
+		if x := hit.Position.X; x < 190 || x > 310 {
+			t.Errorf("balls met at %v, want between their starting places", hit.Position)
+		}
+	})
+	// The target comes back off the wall for the striker in about 160 ticks
+	for i := 0; i < 120; i++ {
+		w.Step()
+	}
+	if balls != 1 || walls == 0 {
+		t.Errorf("heard %d collisions between balls and %d with walls, want 1 and at least 1", balls, walls)
+	}
+}
@@ -0,0 +1,97 @@
+package physics
+
+// An Acceleration returns the acceleration a body would feel at p from the
+// world's force fields: gravity with its zones and sources, and the pull
+// of the other bodies where they stood at the start of the substep.
+type Acceleration func(p Vector) Vector
+
+// An Integrator moves a body through a substep of dt ticks under its
+// Acceleration. The move is split in two so constraints can cancel any
+// velocity that would stretch them in between: Kick changes only the
+// velocity, before they are solved, and Drift moves the body after. Other
+// forces, such as springs, magnets and drag, have already changed the
+// velocity by the time Drift is called.
+type Integrator interface {
+	Kick(b *Body, a Acceleration, dt float64)
+	Drift(b *Body, a Acceleration, dt float64)
+}
+
+// WithIntegrator makes the world move its bodies with integrator rather
+// than symplectic Euler.
+func WithIntegrator(integrator Integrator) WorldOption {
+	return func(w *World) {
+		w.Integrator = integrator
+	}
+}
+
+// SymplecticEuler updates the velocity first and moves the body along the
+// new one, a first-order method whose energy wobbles but doesn't drift, so
+// orbits stay closed. It is the world's integrator unless it is given
+// another, and costs one look at the field a substep.
+type SymplecticEuler struct{}
+
+func (SymplecticEuler) Kick(b *Body, a Acceleration, dt float64) {
+	b.Velocity = Add(b.Velocity, ScalarMult(a(b.Position), dt))
+}
+
+func (SymplecticEuler) Drift(b *Body, a Acceleration, dt float64) {
+	b.Position = Add(b.Position, ScalarMult(b.Velocity, dt))
+}
+
+// VelocityVerlet gives half the field's pull before the body moves and
+// half after, from where it lands. It is second order and, like symplectic
+// Euler, keeps energy from drifting, for two looks at the field a substep.
+type VelocityVerlet struct{}
+
+func (VelocityVerlet) Kick(b *Body, a Acceleration, dt float64) {
+	b.Velocity = Add(b.Velocity, ScalarMult(a(b.Position), dt/2))
+}
+
+func (VelocityVerlet) Drift(b *Body, a Acceleration, dt float64) {
+	b.Position = Add(b.Position, ScalarMult(b.Velocity, dt))
+	b.Velocity = Add(b.Velocity, ScalarMult(a(b.Position), dt/2))
+}
+
+// RK4 is the classic fourth-order Runge-Kutta method, the most accurate
+// over a substep for four looks at the field. It isn't symplectic, so over
+// very many orbits its small error in energy does build up. All its work
+// is done in Drift, so constraints see none of the field's pull before
+// their positions are corrected.
+type RK4 struct{}
+
+func (RK4) Kick(*Body, Acceleration, float64) {}
+
+func (RK4) Drift(b *Body, a Acceleration, dt float64) {
+	x, v := b.Position, b.Velocity
+	k1x, k1v := v, a(x)
+	k2x, k2v := Add(v, ScalarMult(k1v, dt/2)), a(Add(x, ScalarMult(k1x, dt/2)))
+	k3x, k3v := Add(v, ScalarMult(k2v, dt/2)), a(Add(x, ScalarMult(k2x, dt/2)))
+	k4x, k4v := Add(v, ScalarMult(k3v, dt)), a(Add(x, ScalarMult(k3x, dt)))
+	b.Position = Add(x, ScalarMult(Add(Add(k1x, ScalarMult(Add(k2x, k3x), 2)), k4x), dt/6))
+	b.Velocity = Add(v, ScalarMult(Add(Add(k1v, ScalarMult(Add(k2v, k3v), 2)), k4v), dt/6))
+}
+
+// integrator returns the integrator the world moves its bodies with.
+func (w *World) integrator() Integrator {
+	if w.Integrator == nil {
+		return SymplecticEuler{}
+	}
+	return w.Integrator
+}
+
+// bodyField is the Acceleration of body i among pulling. Each body sums
+// its pulls in index order on its own, so the result doesn't depend on
+// how many workers share the work.
+type bodyField struct {
+	w       *World
+	pulling []Body
+	i       int
+}
+
+func (f *bodyField) at(p Vector) Vector {
+	a := f.w.GravityAt(p)
+	if f.w.attraction != 0 {
+		a = Add(a, ScalarMult(pullAt(f.pulling, p, f.i), f.w.attraction))
+	}
+	return a
+}
@@ -0,0 +1,94 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestIntegratorEnergyDrift flies a ball round an eccentric orbit of a
+// heavy frozen body for some fifty laps with each integrator, and checks
+// how far the orbit's energy strays from where it started. The higher the
+// order, the less it strays.
+func TestIntegratorEnergyDrift(t *testing.T) {
+	const (
+		mass   = 300
+		radius = 150
+	)
+	centre := Vector{X: 320, Y: 240}
+	tests := []struct {
+		name       string
+		integrator Integrator
+		// tolerance is the most the energy may stray, as a fraction of it
+		tolerance float64
+	}{
+		{"symplectic euler", SymplecticEuler{}, 0.05},
+		{"velocity verlet", VelocityVerlet{}, 0.002},
+		{"rk4", RK4{}, 0.00001},
+	}
+
+	previous := math.Inf(1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Slower than a circular orbit, so the ball falls in close
+			objects := []Body{
+				{Position: centre, Mass: mass, Frozen: true},
+				{Position: Add(centre, Vector{X: radius}), Velocity: Vector{Y: -0.7 * math.Sqrt(mass/radius)}},
+			}
+			w := NewWorld(objects, Vector{}, WithAttraction(1), WithoutContacts(), WithIntegrator(tt.integrator))
+			defer w.Close()
+
+			// E = v²/2 - GM/r, with r softened as the pull is
+			energy := func() float64 {
+				b := w.Snapshot()[1]
+				offset := Subtract(b.Position, centre)
+				return 0.5*b.Velocity.MagnitudeSquared() - mass/math.Sqrt(offset.MagnitudeSquared()+attractionSoftening*attractionSoftening)
+			}
+			start := energy()
+			worst := 0.0
+			for range 20000 {
+				w.Step()
+				worst = math.Max(worst, math.Abs((energy()-start)/start))
+			}
+
+			if worst > tt.tolerance {
+				t.Errorf("energy strayed by %.6f of itself, want at most %v", worst, tt.tolerance)
+			}
+			if worst >= previous {
+				t.Errorf("energy strayed by %.6f of itself, want less than the %.6f of the integrator before", worst, previous)
+			}
+			previous = worst
+		})
+	}
+}
+
+// TestIntegratorFreeFall drops a thrown ball under uniform gravity, where
+// x = x₀ + v₀t + gt²/2 exactly. Velocity Verlet and RK4 follow it to
+// rounding; symplectic Euler lands ahead by gt/2 for each tick.
+func TestIntegratorFreeFall(t *testing.T) {
+	const steps = 30
+	start, velocity, gravity := Vector{X: 100, Y: 100}, Vector{X: 2, Y: -5}, Vector{Y: 0.4}
+	exact := Add(Add(start, ScalarMult(velocity, steps)), ScalarMult(gravity, steps*steps/2))
+	tests := []struct {
+		name       string
+		integrator Integrator
+		want       Vector
+	}{
+		{"symplectic euler", SymplecticEuler{}, Add(exact, ScalarMult(gravity, steps/2.0))},
+		{"velocity verlet", VelocityVerlet{}, exact},
+		{"rk4", RK4{}, exact},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld([]Body{{Position: start, Velocity: velocity}}, gravity, WithIntegrator(tt.integrator))
+			defer w.Close()
+			for range steps {
+				w.Step()
+			}
+
+			if got := w.Snapshot()[0].Position; !vectorsClose(got, tt.want) {
+				t.Errorf("after %d steps at %v, want %v", steps, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package physics
+
+// removeExpired takes every ball whose time is up out of objects,
+// returning the rest.
+func (w *World) removeExpired(objects []Body) []Body {
+	for i := len(objects) - 1; i >= 0; i-- {
+		if e := objects[i].Expires; e != 0 && e <= w.Steps {
+			objects = w.remove(objects, i)
+		}
+	}
+	return objects
+}
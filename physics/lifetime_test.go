@@ -0,0 +1,24 @@
+package physics
+
+import "testing"
+
+// TestExpiredBallsAreRemoved checks a ball is taken out on the step it
+// expires, and that balls without an expiry stay.
+func TestExpiredBallsAreRemoved(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 100, Y: 100}, Name: "lasting"},
+		{Position: Vector{X: 300, Y: 100}, Name: "brief", Expires: 10},
+	}, Vector{})
+	defer w.Close()
+
+	for w.Steps < 9 {
+		w.Step()
+	}
+	if n := len(w.Snapshot()); n != 2 {
+		t.Fatalf("%d balls before the expiry, want 2", n)
+	}
+	w.Step()
+	if objects := w.Snapshot(); len(objects) != 1 || objects[0].Name != "lasting" {
+		t.Errorf("bodies after the expiry %v, want only the lasting ball", objects)
+	}
+}
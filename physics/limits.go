@@ -0,0 +1,147 @@
+package physics
+
+import (
+	"fmt"
+	"math"
+)
+
+// LimitPolicy is what happens to a body that goes past the world's limits.
+type LimitPolicy int
+
+const (
+	// ClampToLimits slows the body to the top speed and holds it at the
+	// edge of the limits, moving no further out
+	ClampToLimits LimitPolicy = iota
+	// DestroyPastLimits takes the body out of the world
+	DestroyPastLimits
+	// WrapPastLimits brings the body back in at the opposite edge of the
+	// limits, as a torus arena does, and clamps its speed
+	WrapPastLimits
+	// ErrorPastLimits stops the world at the last step every body was
+	// within them, with Err saying which went past
+	ErrorPastLimits
+)
+
+// Limits bound how fast bodies may move and how far they may go, so a
+// runaway experiment comes to a stop, or loses a body, rather than flinging
+// it off to infinity. Speed is in units per tick and Distance in units
+// from the world's origin along either axis; a zero leaves that unbounded.
+type Limits struct {
+	Speed    float64
+	Distance float64
+	Policy   LimitPolicy
+}
+
+// LimitError says which body went past the world's limits and how.
+type LimitError struct {
+	Step  uint64
+	Body  int
+	State Body
+	// Speed is set if the body went too fast, and clear if too far
+	Speed bool
+}
+
+func (e *LimitError) Error() string {
+	if e.Speed {
+		return fmt.Sprintf("body %d went past the speed limit in step %d, moving %v", e.Body, e.Step, e.State.Velocity.Magnitude())
+	}
+	return fmt.Sprintf("body %d went past the distance limit in step %d, at %v", e.Body, e.Step, e.State.Position)
+}
+
+// bounds holds the world's limits and what they have done this step.
+type bounds struct {
+	Limits
+	// exceeded is what stopped the world under ErrorPastLimits, or nil
+	exceeded *LimitError
+}
+
+// WithLimits bounds the speed and position of every body, dealing with any
+// that go past as limits.Policy says after each step.
+func WithLimits(limits Limits) WorldOption {
+	return func(w *World) {
+		w.limits.Limits = limits
+	}
+}
+
+// Err returns what stopped the world, a *LimitError or the NaN guard's
+// *Diagnosis, or nil while it runs.
+func (w *World) Err() error {
+	switch {
+	case w.guard.halted != nil:
+		return w.guard.halted
+	case w.limits.exceeded != nil:
+		return w.limits.exceeded
+	}
+	return nil
+}
+
+// past reports whether a body is past the limits, and if so whether it is
+// going too fast rather than too far.
+func (l *Limits) past(b *Body) (bool, bool) {
+	if l.Speed > 0 && b.Velocity.Magnitude() > l.Speed {
+		return true, true
+	}
+	if l.Distance > 0 && (math.Abs(b.Position.X) > l.Distance || math.Abs(b.Position.Y) > l.Distance) {
+		return true, false
+	}
+	return false, false
+}
+
+// bound deals with every body of the step just taken past the limits,
+// returning the bodies left. It returns false if the world stops, in which
+// case the step mustn't be published.
+func (w *World) bound(objects []Body) ([]Body, bool) {
+	l := &w.limits
+	if l.Speed <= 0 && l.Distance <= 0 {
+		return objects, true
+	}
+	for i := range objects {
+		b := &objects[i]
+		past, speeding := l.past(b)
+		if !past {
+			continue
+		}
+		switch l.Policy {
+		case ErrorPastLimits:
+			l.exceeded = &LimitError{Step: w.Steps + 1, Body: i, State: *b, Speed: speeding}
+			return objects, false
+		case DestroyPastLimits:
+			// Removing renumbers the bodies, so they go once the rest
+			// are dealt with
+			continue
+		}
+		if speed := b.Velocity.Magnitude(); l.Speed > 0 && speed > l.Speed {
+			b.Velocity = ScalarMult(b.Velocity, l.Speed/speed)
+		}
+		if l.Distance > 0 {
+			if l.Policy == WrapPastLimits {
+				b.Position.X = wrapped(b.Position.X+l.Distance, 2*l.Distance) - l.Distance
+				b.Position.Y = wrapped(b.Position.Y+l.Distance, 2*l.Distance) - l.Distance
+			} else {
+				b.Position.X, b.Velocity.X = clampAxis(b.Position.X, b.Velocity.X, l.Distance)
+				b.Position.Y, b.Velocity.Y = clampAxis(b.Position.Y, b.Velocity.Y, l.Distance)
+			}
+		}
+	}
+
+	if l.Policy == DestroyPastLimits {
+		for i := len(objects) - 1; i >= 0; i-- {
+			if past, _ := l.past(&objects[i]); past {
+				objects = w.remove(objects, i)
+			}
+		}
+	}
+	return objects, true
+}
+
+// clampAxis holds a coordinate within limit of zero, stopping any motion
+// further out.
+func clampAxis(x, v, limit float64) (float64, float64) {
+	switch {
+	case x > limit:
+		return limit, min(v, 0)
+	case x < -limit:
+		return -limit, max(v, 0)
+	}
+	return x, v
+}
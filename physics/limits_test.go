@@ -0,0 +1,51 @@
+package physics
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestLimits throws a ball off towards the edge of the limits at twice the
+// top speed, beside one sitting still, and checks each policy deals with
+// the fast one and leaves the other alone.
+func TestLimits(t *testing.T) {
+	run := func(policy LimitPolicy, steps int) *World {
+		w := NewWorld([]Body{
+			{Position: Vector{X: 500, Y: 200}, Velocity: Vector{X: 20}},
+			{Position: Vector{X: 100, Y: 200}},
+		}, Vector{}, WithOpenArena(10000), WithLimits(Limits{Speed: 10, Distance: 600, Policy: policy}))
+		for range steps {
+			w.Step()
+		}
+		return w
+	}
+
+	w := run(ClampToLimits, 30)
+	objects := w.Snapshot()
+	if objects[0].Position.X != 600 || objects[0].Velocity.X != 0 {
+		t.Errorf("clamped ball at %v moving %v, want it held at the edge", objects[0].Position, objects[0].Velocity)
+	}
+	w.Close()
+
+	w = run(WrapPastLimits, 10)
+	if objects := w.Snapshot(); objects[0].Position.X >= 0 || objects[0].Position.X < -600 || objects[0].Velocity.X != 10 {
+		t.Errorf("wrapped ball at %v moving %v, want it brought in at the far edge at the top speed", objects[0].Position, objects[0].Velocity)
+	}
+	w.Close()
+
+	w = run(DestroyPastLimits, 1)
+	if objects := w.Snapshot(); len(objects) != 1 || objects[0].Position.X != 100 {
+		t.Errorf("%d bodies left after destroying, want only the still one", len(objects))
+	}
+	w.Close()
+
+	w = run(ErrorPastLimits, 3)
+	var err *LimitError
+	if !errors.As(w.Err(), &err) || err.Body != 0 || !err.Speed || err.Step != 1 || w.Steps != 0 {
+		t.Errorf("stopped with %v after %d steps, want body 0 too fast in the first", w.Err(), w.Steps)
+	}
+	if objects := w.Snapshot(); objects[0].Position.X != 500 {
+		t.Errorf("stopped world shows the ball at %v, want it where it started", objects[0].Position)
+	}
+	w.Close()
+}
@@ -0,0 +1,70 @@
+package physics
+
+// lodSettings enables a cheaper update for bodies far from every interest
+// point. Far bodies integrate only every stride steps with a stride-sized
+// timestep and skip the narrowphase against other far bodies. A zero value
+// leaves LOD disabled.
+type lodSettings struct {
+	radius float64
+	stride int
+}
+
+// WithLOD gives bodies further than radius from every interest point a
+// full update only once every stride steps.
+func WithLOD(radius float64, stride int) WorldOption {
+	return func(w *World) {
+		w.lod = lodSettings{radius: radius, stride: stride}
+	}
+}
+
+func (l lodSettings) enabled() bool {
+	return l.radius > 0 && l.stride > 1
+}
+
+// SetInterestPoints replaces the points bodies must be near to get a full
+// update, typically the centre of each camera.
+func (w *World) SetInterestPoints(points ...Vector) {
+	w.interestPoints = append(w.interestPoints[:0], points...)
+}
+
+// classifyLOD marks which bodies are far from every interest point.
+func (w *World) classifyLOD(objects []Body) {
+	w.far = append(w.far[:0], make([]bool, len(objects))...)
+	if !w.lod.enabled() {
+		return
+	}
+
+	w.pool.parallelFor(len(objects), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			w.far[i] = w.isFar(objects[i].Position)
+		}
+	})
+}
+
+func (w *World) isFar(p Vector) bool {
+	if len(w.interestPoints) == 0 {
+		return false
+	}
+
+	radiusSquared := w.lod.radius * w.lod.radius
+	for _, point := range w.interestPoints {
+		offset := Subtract(p, point)
+		if offset.MagnitudeSquared() <= radiusSquared {
+			return false
+		}
+	}
+	return true
+}
+
+// lodTimestep returns how far body i should be advanced this step: a full
+// tick when near, stride ticks on its turn when far, otherwise nothing.
+// Turns are staggered by index so far bodies don't all update at once.
+func (w *World) lodTimestep(i int) float64 {
+	if !w.far[i] {
+		return 1
+	}
+	if (w.Steps+uint64(i))%uint64(w.lod.stride) != 0 {
+		return 0
+	}
+	return float64(w.lod.stride)
+}
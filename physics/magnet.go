@@ -0,0 +1,117 @@
+package physics
+
+import "math"
+
+// magnetReach is the closest two dipoles are taken to be when working out
+// their pull, so the field stays finite at a dipole's centre.
+const magnetReach = BallRadius
+
+// StaticMagnet is an immovable bar magnet length long, along its moment.
+// Its field is that of a north and a south pole one at each end, so balls
+// are drawn to its ends rather than its middle.
+type StaticMagnet struct {
+	Position Vector
+	Moment   Vector
+	Length   float64
+}
+
+// WithMagnetism turns on the forces between magnetic dipoles, scaled by
+// strength: between the given bar magnets and every magnetised ball, and
+// between the balls themselves.
+func WithMagnetism(strength float64, magnets ...StaticMagnet) WorldOption {
+	return func(w *World) {
+		w.magnetism = strength
+		w.Magnets = append(w.Magnets, magnets...)
+	}
+}
+
+// poles returns where the magnet's north and south poles are, and how
+// strong each is.
+func (m StaticMagnet) poles() (Vector, Vector, float64) {
+	half := ScalarMult(UnitVector(m.Moment), m.Length/2)
+	return Add(m.Position, half), Subtract(m.Position, half), m.Moment.Magnitude() / m.Length
+}
+
+// poleField returns the field at offset from a pole of the given strength,
+// negative for a south pole.
+func poleField(strength float64, offset Vector) Vector {
+	distance := math.Max(offset.Magnitude(), magnetReach)
+	return ScalarMult(UnitVector(offset), strength/(distance*distance))
+}
+
+// poleForce returns the force on a dipole with moment m at offset from a
+// pole of the given strength.
+func poleForce(strength float64, m, offset Vector) Vector {
+	distance := math.Max(offset.Magnitude(), magnetReach)
+	direction := UnitVector(offset)
+	force := Subtract(m, ScalarMult(direction, 3*DotProduct(m, direction)))
+	return ScalarMult(force, strength/(distance*distance*distance))
+}
+
+// dipoleField returns the field at offset from a dipole with the given
+// moment.
+func dipoleField(moment, offset Vector) Vector {
+	distance := math.Max(offset.Magnitude(), magnetReach)
+	direction := UnitVector(offset)
+	along := DotProduct(moment, direction)
+	return ScalarMult(Subtract(ScalarMult(direction, 3*along), moment), 1/(distance*distance*distance))
+}
+
+// dipoleForce returns the force on a dipole with moment m at offset from
+// one with moment source. Dipoles end to end attract, and side by side
+// repel.
+func dipoleForce(source, m, offset Vector) Vector {
+	distance := math.Max(offset.Magnitude(), magnetReach)
+	direction := UnitVector(offset)
+	sourceAlong := DotProduct(source, direction)
+	mAlong := DotProduct(m, direction)
+
+	force := Add(ScalarMult(m, sourceAlong), ScalarMult(source, mAlong))
+	force = Add(force, ScalarMult(direction, DotProduct(source, m)-5*sourceAlong*mAlong))
+	return ScalarMult(force, 3/(distance*distance*distance*distance))
+}
+
+// magnetize pushes and turns every magnetised ball by the fields of the
+// bar magnets and of the other magnetised balls, which act as point dipoles, for dt ticks. A ball turns as
+// a solid sphere would, with a moment of inertia of 2/5 m r².
+func (w *World) magnetize(objects []Body, dt float64) {
+	if w.magnetism == 0 {
+		return
+	}
+
+	w.pool.parallelFor(len(objects), func(_, start, end int) {
+		for i := start; i < end; i++ {
+			currBall := &objects[i]
+			scale := w.lodTimestep(i)
+			if currBall.Moment == (Vector{}) || scale == 0 {
+				continue
+			}
+
+			var field, force Vector
+			for _, magnet := range w.Magnets {
+				north, south, strength := magnet.poles()
+				for _, pole := range [...]struct {
+					position Vector
+					strength float64
+				}{{north, strength}, {south, -strength}} {
+					offset := Subtract(currBall.Position, pole.position)
+					field = Add(field, poleField(pole.strength, offset))
+					force = Add(force, poleForce(pole.strength, currBall.Moment, offset))
+				}
+			}
+			for j := range objects {
+				if j == i || objects[j].Moment == (Vector{}) {
+					continue
+				}
+				offset := Subtract(currBall.Position, objects[j].Position)
+				field = Add(field, dipoleField(objects[j].Moment, offset))
+				force = Add(force, dipoleForce(objects[j].Moment, currBall.Moment, offset))
+			}
+
+			step := w.magnetism * dt * scale
+			currBall.Velocity = Add(currBall.Velocity, ScalarMult(force, currBall.inverseMass()*step))
+			torque := currBall.Moment.X*field.Y - currBall.Moment.Y*field.X
+			currBall.AngularVelocity += torque * currBall.inverseInertia() * step
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDipoleForces checks dipoles end to end attract, side by side repel,
+// and always push on each other equally and oppositely.
+func TestDipoleForces(t *testing.T) {
+	m := Vector{X: 100}
+	tests := []struct {
+		name   string
+		offset Vector
+		toward bool
+	}{
+		{"end to end", Vector{X: 80}, true},
+		{"side by side", Vector{Y: 80}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			force := dipoleForce(m, m, tt.offset)
+			if toward := DotProduct(force, tt.offset) < 0; toward != tt.toward {
+				t.Errorf("force %v at offset %v, want attraction %v", force, tt.offset, tt.toward)
+			}
+			back := dipoleForce(m, m, ScalarMult(tt.offset, -1))
+			if sum := Add(force, back); sum.Magnitude() > epsilon {
+				t.Errorf("forces %v and %v don't cancel", force, back)
+			}
+		})
+	}
+}
+
+// TestCompassTurnsToField holds a magnetised ball above the middle of a bar
+// magnet, pointing up, and checks it starts turning to point along the
+// field there, back from the bar's north pole towards its south.
+func TestCompassTurnsToField(t *testing.T) {
+	bar := StaticMagnet{Position: Vector{X: 320, Y: 300}, Moment: Vector{X: 3000}, Length: 120}
+	w := NewWorld([]Body{{Position: Vector{X: 320, Y: 200}, Moment: Vector{Y: -300}}}, Vector{}, WithMagnetism(1, bar))
+	defer w.Close()
+
+	for tick := 0; tick < 5; tick++ {
+		w.Step()
+	}
+	b := w.Snapshot()[0]
+	if b.AngularVelocity >= 0 || b.Moment.X >= 0 {
+		t.Errorf("moment %v turning at %v, want it turning anticlockwise to point left", b.Moment, b.AngularVelocity)
+	}
+	if got := b.Moment.Magnitude(); math.Abs(got-300) > 1e-9 {
+		t.Errorf("moment grew to %v as it turned, want 300", got)
+	}
+}
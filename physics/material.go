@@ -0,0 +1,85 @@
+package physics
+
+// Material is what a ball is made of, which decides how it sounds when it
+// hits something and how it sticks. The zero value is rubber.
+type Material int
+
+const (
+	Rubber Material = iota
+	Steel
+	Wood
+	Clay
+	Snow
+	// Materials counts the materials before it
+	Materials
+)
+
+var materialNames = [...]string{
+	Rubber: "rubber",
+	Steel:  "steel",
+	Wood:   "wood",
+	Clay:   "clay",
+	Snow:   "snow",
+}
+
+// adhesions holds how sticky each material is, as a speed in units per
+// tick. Balls meeting slower than it weld together, and the weld holds
+// until something tries to part them faster than it. The stickier of two
+// balls decides, so mud clings to anything.
+var adhesions = [Materials]float64{
+	Clay: 1.5,
+	Snow: 0.8,
+}
+
+// restitutionPoint is one point of a restitution curve: the share of the
+// closing speed that survives an impact at speed, in units per tick.
+type restitutionPoint struct {
+	speed       float64
+	restitution float64
+}
+
+// restitutionCurves holds how bouncy each material is by impact speed, as
+// points in order of speed. Soft materials give less back the harder they
+// are hit, as more of the impact goes into denting them. Between points
+// the curve runs straight, and past either end it stays level. A material
+// without a curve gives back everything, leaving the bounce to the wall's
+// and the ball's own restitution.
+var restitutionCurves = [Materials][]restitutionPoint{
+	Wood: {{speed: 2, restitution: 0.9}, {speed: 12, restitution: 0.6}},
+	Clay: {{speed: 1, restitution: 0.4}, {speed: 8, restitution: 0.1}},
+	Snow: {{speed: 1, restitution: 0.5}, {speed: 8, restitution: 0.15}},
+}
+
+// bounciness returns the share of its speed the ball gives back in an
+// impact at speed: its own restitution times its material's at that speed.
+func (b *Body) bounciness(speed float64) float64 {
+	e := b.Material.restitution(speed)
+	if !b.HasRestitution {
+		return e
+	}
+	return e * b.Restitution
+}
+
+// restitution returns the share of the closing speed the material gives
+// back in an impact at speed.
+func (m Material) restitution(speed float64) float64 {
+	curve := restitutionCurves[m]
+	if len(curve) == 0 {
+		return 1
+	}
+	if speed <= curve[0].speed {
+		return curve[0].restitution
+	}
+	for k := 1; k < len(curve); k++ {
+		if speed <= curve[k].speed {
+			from, to := curve[k-1], curve[k]
+			along := (speed - from.speed) / (to.speed - from.speed)
+			return from.restitution + along*(to.restitution-from.restitution)
+		}
+	}
+	return curve[len(curve)-1].restitution
+}
+
+func (m Material) String() string {
+	return materialNames[m]
+}
@@ -0,0 +1,90 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestRestitutionCurve checks a material's restitution follows its curve
+// between points and stays level past either end.
+func TestRestitutionCurve(t *testing.T) {
+	for _, c := range []struct {
+		m     Material
+		speed float64
+		want  float64
+	}{
+		{Rubber, 20, 1},
+		{Wood, 0.5, 0.9},
+		{Wood, 7, 0.75},
+		{Wood, 30, 0.6},
+		{Clay, 4.5, 0.25},
+	} {
+		if got := c.m.restitution(c.speed); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("%s at %v: restitution %v, want %v", c.m, c.speed, got, c.want)
+		}
+	}
+}
+
+// TestWoodBouncesLessWhenHitHarder throws wooden balls into a wall and at
+// each other, and checks the harder impacts give back a smaller share of
+// their speed.
+func TestWoodBouncesLessWhenHitHarder(t *testing.T) {
+	// off a wall
+	for _, speed := range []float64{1, 7, 20} {
+		w := NewWorld([]Body{{Position: Vector{X: ScreenWidth - BallRadius - speed/2, Y: 240}, Velocity: Vector{X: speed}, Material: Wood}}, Vector{})
+		w.Step()
+		w.Close()
+		got := -w.Snapshot()[0].Velocity.X / speed
+		if want := Wood.restitution(speed); math.Abs(got-want) > 1e-9 {
+			t.Errorf("wall at %v: kept %.3f of the speed, want %.3f", speed, got, want)
+		}
+	}
+
+	// head on, each closing at half the speed
+	for _, speed := range []float64{1, 7, 20} {
+		w := NewWorld([]Body{
+			{Position: Vector{X: 320 - BallRadius, Y: 240}, Velocity: Vector{X: speed / 2}, Material: Wood},
+			{Position: Vector{X: 320 + BallRadius, Y: 240}, Velocity: Vector{X: -speed / 2}, Material: Wood},
+		}, Vector{})
+		w.Step()
+		w.Close()
+		objects := w.Snapshot()
+		got := (objects[1].Velocity.X - objects[0].Velocity.X) / speed
+		if want := Wood.restitution(speed); math.Abs(got-want) > 1e-6 {
+			t.Errorf("pair at %v: kept %.3f of the speed, want %.3f", speed, got, want)
+		}
+	}
+}
+
+// TestBodyRestitutionBetweenBalls crashes pairs of balls head on and
+// checks they part at the geometric mean of their own restitutions, a
+// ball without one giving back everything and one set to zero nothing.
+func TestBodyRestitutionBetweenBalls(t *testing.T) {
+	// unset leaves a ball's restitution to its material
+	const unset = -1
+	ball := func(x, speed, e float64) Body {
+		b := Body{Position: Vector{X: x, Y: 240}, Velocity: Vector{X: speed}}
+		if e != unset {
+			b.Restitution, b.HasRestitution = e, true
+		}
+		return b
+	}
+	for _, c := range []struct {
+		a, b float64
+		want float64
+	}{
+		{unset, unset, 1},
+		{0.25, 0.25, 0.25},
+		{unset, 0.25, 0.5},
+		{0, 0, 0},
+		{0, unset, 0},
+	} {
+		w := NewWorld([]Body{ball(320-BallRadius, 1, c.a), ball(320+BallRadius, -1, c.b)}, Vector{})
+		w.Step()
+		w.Close()
+		objects := w.Snapshot()
+		if got := (objects[1].Velocity.X - objects[0].Velocity.X) / 2; math.Abs(got-c.want) > 1e-6 {
+			t.Errorf("restitutions %v and %v: kept %.3f of the speed, want %.3f", c.a, c.b, got, c.want)
+		}
+	}
+}
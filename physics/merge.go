@@ -0,0 +1,56 @@
+package physics
+
+import "math"
+
+// WithMerging makes two balls that collide closing at under speed merge
+// into one, as raindrops or planetesimals would. The merged ball has both
+// masses and their total momentum, and sits at their centre of mass; it
+// takes everything else from the heavier one. Balls held by rods or joints,
+// or frozen, are left alone. The merged ball is as big as both were
+// together.
+func WithMerging(speed float64) WorldOption {
+	return func(w *World) {
+		w.mergeSpeed = speed
+	}
+}
+
+// merge combines the pairs of balls in objects that collided gently
+// enough this step, returning what is left.
+func (w *World) merge(objects []Body) []Body {
+	if w.mergeSpeed == 0 || len(w.impacts) == 0 {
+		return objects
+	}
+	held := w.held()
+	gone := make(map[int]bool)
+	for _, hit := range w.impacts {
+		a, b := hit.A, hit.B
+		if b == NoBody || hit.Speed >= w.mergeSpeed || gone[a] || gone[b] || held[a] || held[b] || objects[a].Frozen || objects[b].Frozen {
+			continue
+		}
+		if objects[b].mass() > objects[a].mass() {
+			a, b = b, a
+		}
+		objects[a] = coalesce(&objects[a], &objects[b])
+		gone[b] = true
+	}
+
+	// Remove from the top so each removal leaves the rest where they were
+	for i := len(objects) - 1; i >= 0; i-- {
+		if gone[i] {
+			objects = w.remove(objects, i)
+		}
+	}
+	return objects
+}
+
+// coalesce returns keep with other merged into it.
+func coalesce(keep, other *Body) Body {
+	massA, massB := keep.mass(), other.mass()
+	mass := massA + massB
+	merged := *keep
+	merged.Mass = mass
+	merged.Radius = math.Hypot(keep.Size(), other.Size())
+	merged.Position = ScalarMult(Add(ScalarMult(keep.Position, massA), ScalarMult(other.Position, massB)), 1/mass)
+	merged.Velocity = ScalarMult(Add(ScalarMult(keep.Velocity, massA), ScalarMult(other.Velocity, massB)), 1/mass)
+	return merged
+}
@@ -0,0 +1,39 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGentleCollisionsMerge runs two balls into each other, once slowly and
+// once fast, and checks only the slow pair merges, keeping the mass and
+// momentum the two had between them.
+func TestGentleCollisionsMerge(t *testing.T) {
+	collide := func(speed float64) []Body {
+		w := NewWorld([]Body{
+			{Position: Vector{X: 280, Y: 240}, Velocity: Vector{X: speed}, Mass: 3},
+			{Position: Vector{X: 360, Y: 240}, Velocity: Vector{X: -speed, Y: 0.5}},
+		}, Vector{}, WithMerging(2))
+		defer w.Close()
+		for tick := 0; tick < 60; tick++ {
+			w.Step()
+		}
+		return w.Snapshot()
+	}
+
+	merged := collide(0.5)
+	if len(merged) != 1 {
+		t.Fatalf("%d balls after a gentle collision, want 1", len(merged))
+	}
+	if got := merged[0].Mass; got != 4 {
+		t.Errorf("merged mass %v, want 4", got)
+	}
+	want := Vector{X: (3*0.5 - 0.5) / 4, Y: 0.5 / 4}
+	if got := merged[0].Velocity; math.Abs(got.X-want.X) > 1e-9 || math.Abs(got.Y-want.Y) > 1e-9 {
+		t.Errorf("merged velocity %v, want %v", got, want)
+	}
+
+	if n := len(collide(3)); n != 2 {
+		t.Errorf("%d balls after a hard collision, want both still there", n)
+	}
+}
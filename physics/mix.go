@@ -0,0 +1,46 @@
+package physics
+
+import "image/color"
+
+// WithColorMixing makes every pair of balls that collide take on the blend
+// of their two colours, weighted by mass, so colour spreads through the
+// world the way heat or dye would. A ball without a colour of its own
+// counts as white.
+func WithColorMixing() WorldOption {
+	return func(w *World) {
+		w.mixColors = true
+	}
+}
+
+// mix blends the colours of every pair of balls in objects that collided
+// this step.
+func (w *World) mix(objects []Body) {
+	if !w.mixColors {
+		return
+	}
+	for _, hit := range w.impacts {
+		if hit.B == NoBody {
+			continue
+		}
+		a, b := &objects[hit.A], &objects[hit.B]
+		blended := blend(a.color(), b.color(), a.mass(), b.mass())
+		a.Color, b.Color = blended, blended
+	}
+}
+
+// color returns the colour the ball mixes as.
+func (b *Body) color() color.RGBA {
+	if b.Color.A == 0 {
+		return color.RGBA{0xff, 0xff, 0xff, 0xff}
+	}
+	return b.Color
+}
+
+// blend returns the average of two colours weighted by massA and massB.
+func blend(a, b color.RGBA, massA, massB float64) color.RGBA {
+	share := massA / (massA + massB)
+	channel := func(x, y uint8) uint8 {
+		return uint8(float64(x)*share + float64(y)*(1-share) + 0.5)
+	}
+	return color.RGBA{R: channel(a.R, b.R), G: channel(a.G, b.G), B: channel(a.B, b.B), A: channel(a.A, b.A)}
+}
@@ -0,0 +1,43 @@
+package physics
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestBlendWeighsByMass(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+	if got, want := blend(red, blue, 1, 1), (color.RGBA{0x80, 0, 0x80, 0xff}); got != want {
+		t.Errorf("even blend %v, want %v", got, want)
+	}
+	if got, want := blend(red, blue, 3, 1), (color.RGBA{0xbf, 0, 0x40, 0xff}); got != want {
+		t.Errorf("blend with red three times heavier %v, want %v", got, want)
+	}
+}
+
+// TestCollidingBallsMix runs a red ball into a blue one and checks both
+// come away the same blend, while a ball that only hit the wall keeps its
+// colour.
+func TestCollidingBallsMix(t *testing.T) {
+	red := color.RGBA{0xff, 0, 0, 0xff}
+	blue := color.RGBA{0, 0, 0xff, 0xff}
+	w := NewWorld([]Body{
+		{Position: Vector{X: 200, Y: 100}, Velocity: Vector{X: 2}, Color: red},
+		{Position: Vector{X: 300, Y: 100}, Velocity: Vector{X: -2}, Color: blue},
+		{Position: Vector{X: 320, Y: 400}, Velocity: Vector{Y: 3}, Color: red},
+	}, Vector{}, WithColorMixing())
+	defer w.Close()
+
+	for tick := 0; tick < 60; tick++ {
+		w.Step()
+	}
+	objects := w.Snapshot()
+	want := color.RGBA{0x80, 0, 0x80, 0xff}
+	if objects[0].Color != want || objects[1].Color != want {
+		t.Errorf("colliding balls %v and %v, want both %v", objects[0].Color, objects[1].Color, want)
+	}
+	if objects[2].Color != red {
+		t.Errorf("ball that hit the wall turned %v, want it still %v", objects[2].Color, red)
+	}
+}
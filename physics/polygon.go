@@ -0,0 +1,185 @@
+package physics
+
+import (
+	"math"
+	"slices"
+)
+
+// StaticPolygon is an immovable solid with any simple outline, convex or
+// not, such as a bowl or a winding track. Balls collide with the convex
+// Pieces it is cut into, each as they would a box.
+type StaticPolygon struct {
+	Points []Vector
+	// Pieces are convex polygons that together cover the outline, wound
+	// the same way
+	Pieces [][]Vector
+}
+
+// NewStaticPolygon outlines a solid with the given points, in either
+// winding, and cuts it into convex pieces.
+func NewStaticPolygon(points ...Vector) StaticPolygon {
+	return StaticPolygon{Points: points, Pieces: decompose(points)}
+}
+
+// WithStaticPolygons adds solid polygons to the world, cutting any that
+// haven't been into convex pieces.
+func WithStaticPolygons(polygons ...StaticPolygon) WorldOption {
+	return func(w *World) {
+		for _, p := range polygons {
+			if p.Pieces == nil {
+				p.Pieces = decompose(p.Points)
+			}
+			w.StaticPolygons = append(w.StaticPolygons, p)
+		}
+	}
+}
+
+// side returns which side of the line from a through b p is on: positive
+// to the left of it, as the inside of a piece is to every edge, negative
+// to the right and zero on it.
+func side(a, b, p Vector) float64 {
+	return (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+}
+
+// decompose cuts a simple polygon into convex pieces: it clips ears off
+// the outline into triangles, then joins neighbouring pieces back together
+// wherever what they make is still convex, as Hertel and Mehlhorn do,
+// which makes at most four times as many pieces as the fewest there could
+// be. Repeated and collinear points are dropped first.
+func decompose(points []Vector) [][]Vector {
+	var outline []Vector
+	for _, p := range points {
+		if len(outline) == 0 || p != outline[len(outline)-1] {
+			outline = append(outline, p)
+		}
+	}
+	for len(outline) > 1 && outline[0] == outline[len(outline)-1] {
+		outline = outline[:len(outline)-1]
+	}
+	for k := 0; k < len(outline) && len(outline) >= 3; {
+		n := len(outline)
+		if side(outline[(k+n-1)%n], outline[k], outline[(k+1)%n]) == 0 {
+			outline = slices.Delete(outline, k, k+1)
+			continue
+		}
+		k++
+	}
+	if len(outline) < 3 {
+		return nil
+	}
+	area := 0.0
+	for k, p := range outline {
+		q := outline[(k+1)%len(outline)]
+		area += p.X*q.Y - q.X*p.Y
+	}
+	if area < 0 {
+		slices.Reverse(outline)
+	}
+
+	pieces := clipEars(outline)
+	for joined := true; joined; {
+		joined = false
+		for i := 0; i < len(pieces) && !joined; i++ {
+			for j := i + 1; j < len(pieces) && !joined; j++ {
+				if merged, ok := join(pieces[i], pieces[j]); ok && convex(merged) {
+					pieces[i] = merged
+					pieces = slices.Delete(pieces, j, j+1)
+					joined = true
+				}
+			}
+		}
+	}
+	return pieces
+}
+
+// clipEars triangulates an outline wound so its inside is on the left,
+// cutting off one corner at a time that points outwards and holds no other
+// point of the outline.
+func clipEars(outline []Vector) [][]Vector {
+	left := slices.Clone(outline)
+	var triangles [][]Vector
+	for len(left) > 3 {
+		n := len(left)
+		clipped := false
+		for k := 0; k < n; k++ {
+			a, b, c := left[(k+n-1)%n], left[k], left[(k+1)%n]
+			if side(a, b, c) <= 0 {
+				continue
+			}
+			ear := true
+			for _, p := range left {
+				if p != a && p != b && p != c && side(a, b, p) >= 0 && side(b, c, p) >= 0 && side(c, a, p) >= 0 {
+					ear = false
+					break
+				}
+			}
+			if ear {
+				triangles = append(triangles, []Vector{a, b, c})
+				left = slices.Delete(left, k, k+1)
+				clipped = true
+				break
+			}
+		}
+		// An outline that crosses itself may run out of ears
+		if !clipped {
+			break
+		}
+	}
+	return append(triangles, left)
+}
+
+// join returns the polygon two pieces make together if they share an edge.
+func join(a, b []Vector) ([]Vector, bool) {
+	for i := range a {
+		u, v := a[i], a[(i+1)%len(a)]
+		for j := range b {
+			if b[j] != v || b[(j+1)%len(b)] != u {
+				continue
+			}
+			// Go round a from v to u, then on round b from u back to v
+			merged := make([]Vector, 0, len(a)+len(b)-2)
+			for k := 1; k <= len(a); k++ {
+				merged = append(merged, a[(i+k)%len(a)])
+			}
+			for k := 2; k < len(b); k++ {
+				merged = append(merged, b[(j+k)%len(b)])
+			}
+			return merged, true
+		}
+	}
+	return nil, false
+}
+
+// convex reports whether a polygon wound with its inside on the left turns
+// left, or goes straight on, at every corner.
+func convex(piece []Vector) bool {
+	n := len(piece)
+	for k := range piece {
+		if side(piece[(k+n-1)%n], piece[k], piece[(k+1)%n]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// closestOnPiece returns the point on a convex piece nearest to p, which is
+// p itself if it is inside.
+func closestOnPiece(piece []Vector, p Vector) Vector {
+	inside := true
+	closest, nearest := p, math.Inf(1)
+	for k, a := range piece {
+		b := piece[(k+1)%len(piece)]
+		if side(a, b, p) < 0 {
+			inside = false
+		}
+		c := StaticSegment{A: a, B: b}.Closest(p)
+		offset := Subtract(p, c)
+		if distance := offset.MagnitudeSquared(); distance < nearest {
+			closest, nearest = c, distance
+		}
+	}
+	if inside {
+		return p
+	}
+	return closest
+}
@@ -0,0 +1,70 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// area returns the area a polygon encloses, whichever way it is wound.
+func area(points []Vector) float64 {
+	sum := 0.0
+	for k, p := range points {
+		q := points[(k+1)%len(points)]
+		sum += p.X*q.Y - q.X*p.Y
+	}
+	return math.Abs(sum) / 2
+}
+
+// TestDecomposeIntoConvexPieces cuts concave outlines, wound both ways,
+// and checks the pieces are convex and cover exactly the same area.
+func TestDecomposeIntoConvexPieces(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Vector
+		most   int
+	}{
+		{"square", []Vector{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}, 1},
+		{"L", []Vector{{X: 0, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 4}, {X: 4, Y: 4}, {X: 4, Y: 10}, {X: 0, Y: 10}}, 2},
+		{"U the other way", []Vector{{X: 0, Y: 0}, {X: 0, Y: 10}, {X: 30, Y: 10}, {X: 30, Y: 0}, {X: 20, Y: 0}, {X: 20, Y: 6}, {X: 10, Y: 6}, {X: 10, Y: 0}}, 3},
+		{"repeated and collinear points", []Vector{{X: 0, Y: 0}, {X: 5, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 10}, {X: 0, Y: 10}}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pieces := NewStaticPolygon(tt.points...).Pieces
+			if len(pieces) == 0 || len(pieces) > tt.most {
+				t.Fatalf("cut into %d pieces, want 1 to %d", len(pieces), tt.most)
+			}
+			covered := 0.0
+			for k, piece := range pieces {
+				if !convex(piece) {
+					t.Errorf("piece %d %v is not convex", k, piece)
+				}
+				covered += area(piece)
+			}
+			if want := area(tt.points); math.Abs(covered-want) > epsilon {
+				t.Errorf("pieces cover %v, want %v", covered, want)
+			}
+		})
+	}
+}
+
+// TestBallSettlesInConcaveNotch drops a ball into the notch of a U-shaped
+// solid and checks it comes to rest on the notch's floor, between its
+// walls.
+func TestBallSettlesInConcaveNotch(t *testing.T) {
+	u := NewStaticPolygon(
+		Vector{X: 200, Y: 300}, Vector{X: 300, Y: 300}, Vector{X: 300, Y: 400}, Vector{X: 360, Y: 400},
+		Vector{X: 360, Y: 300}, Vector{X: 440, Y: 300}, Vector{X: 440, Y: 460}, Vector{X: 200, Y: 460},
+	)
+	w := NewWorld([]Body{{Position: Vector{X: 330, Y: 200}, Velocity: Vector{X: 1}}}, Vector{Y: .3}, WithStaticPolygons(u), WithWallRestitution(0.3))
+	defer w.Close()
+
+	for i := 0; i < 240; i++ {
+		w.Step()
+	}
+	p := w.Snapshot()[0].Position
+	if math.Abs(p.Y-(400-BallRadius)) > 1 || p.X < 300+BallRadius-1 || p.X > 360-BallRadius+1 {
+		t.Errorf("ball at %v, want it resting on the notch's floor", p)
+	}
+}
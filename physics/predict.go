@@ -0,0 +1,75 @@
+package physics
+
+import "slices"
+
+// PredictionSteps is how many ticks ahead a shot's path is predicted.
+const PredictionSteps = 90
+
+// preview returns a copy of the world on a single worker, to be stepped
+// ahead without touching the real one. Level of detail is left off so
+// every body moves exactly as it would up close. The caller must close
+// it.
+func (w *World) preview() *World {
+	p := NewWorld(w.Snapshot(), w.gravity, WithWorkers(1), WithSubsteps(w.substeps), WithWallRestitution(w.wallRestitution), WithCellSize(w.broadphase.cellSize))
+	p.Steps = w.Steps
+	p.GravityZones = w.GravityZones
+	p.GravitySources = w.GravitySources
+	p.Integrator = w.Integrator
+	p.Arena = w.Arena
+	p.Cloth = w.Cloth
+	p.correction = w.correction
+	p.atmosphere = w.atmosphere
+	p.attraction = w.attraction
+	p.magnetism = w.magnetism
+	p.Magnets = w.Magnets
+	p.flocking = w.flocking
+	p.Water = w.Water.clone()
+	for _, b := range w.Bands {
+		p.Bands = append(p.Bands, b.clone())
+	}
+	for _, r := range w.Ropes {
+		p.Ropes = append(p.Ropes, r.clone())
+	}
+	for _, c := range w.Cloths {
+		p.Cloths = append(p.Cloths, c.clone())
+	}
+	p.ignoreContacts = w.ignoreContacts
+	p.preSolve = w.preSolve
+	p.mergeSpeed = w.mergeSpeed
+	p.speedLimit = w.speedLimit
+	p.limits.Limits = w.limits.Limits
+	p.deterministic = w.deterministic
+	p.Constraints = slices.Clone(w.Constraints)
+	p.Welds = slices.Clone(w.Welds)
+	p.Wheels = slices.Clone(w.Wheels)
+	p.StaticCircles = w.StaticCircles
+	p.StaticSegments = w.StaticSegments
+	p.StaticBoxes = w.StaticBoxes
+	p.StaticPolygons = w.StaticPolygons
+	p.StaticChains = w.StaticChains
+	p.Heightfields = w.Heightfields
+	return p
+}
+
+// Predict returns where body i would be after each of the next steps
+// ticks if it were given velocity and spin now.
+func (w *World) Predict(i int, velocity, spin Vector, steps int) []Vector {
+	if i >= len(w.Snapshot()) {
+		return nil
+	}
+	p := w.preview()
+	defer p.Close()
+	p.Strike(i, velocity, spin)
+
+	bodies := len(p.Snapshot())
+	path := make([]Vector, 0, steps)
+	for range steps {
+		p.Step()
+		// Bodies leaving an open arena renumber the rest, so the path ends
+		if len(p.Snapshot()) != bodies {
+			break
+		}
+		path = append(path, p.Snapshot()[i].Position)
+	}
+	return path
+}
@@ -0,0 +1,154 @@
+package physics
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+)
+
+// replay is a recorded deterministic run: a starting state with the joints
+// between its bodies, how many steps to take, and the checksum the world
+// must reach at the end.
+type replay struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	Gravity     [2]float64         `json:"gravity"`
+	Bodies      []replayBody       `json:"bodies"`
+	Constraints []replayConstraint `json:"constraints,omitempty"`
+	Welds       []replayWeld       `json:"welds,omitempty"`
+	Wheels      []replayWheel      `json:"wheels,omitempty"`
+	Steps       int                `json:"steps"`
+	Checksum    string             `json:"checksum"`
+}
+
+type replayBody struct {
+	Position [2]float64 `json:"position"`
+	Velocity [2]float64 `json:"velocity"`
+}
+
+// replayConstraint is a rod, or a spring given a stiffness, from body a to
+// body b, or to anchor when b is NoBody.
+type replayConstraint struct {
+	A         int        `json:"a"`
+	B         int        `json:"b"`
+	Anchor    [2]float64 `json:"anchor"`
+	Length    float64    `json:"length"`
+	Stiffness float64    `json:"stiffness,omitempty"`
+	Damping   float64    `json:"damping,omitempty"`
+	Strength  float64    `json:"strength,omitempty"`
+}
+
+type replayWeld struct {
+	A        int        `json:"a"`
+	B        int        `json:"b"`
+	AnchorA  [2]float64 `json:"anchorA"`
+	AnchorB  [2]float64 `json:"anchorB"`
+	Angle    float64    `json:"angle,omitempty"`
+	Strength float64    `json:"strength,omitempty"`
+}
+
+type replayWheel struct {
+	A           int        `json:"a"`
+	B           int        `json:"b"`
+	Anchor      [2]float64 `json:"anchor"`
+	Axis        [2]float64 `json:"axis"`
+	Stiffness   float64    `json:"stiffness"`
+	Damping     float64    `json:"damping"`
+	MotorSpeed  float64    `json:"motorSpeed,omitempty"`
+	MotorTorque float64    `json:"motorTorque,omitempty"`
+}
+
+func loadReplay(path string) (*replay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var r replay
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &r, nil
+}
+
+func (r *replay) save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// run plays the replay from the start in deterministic mode and returns
+// the checksum of the final state, or what is wrong with the replay's
+// joints if any joins a body that isn't there.
+func (r *replay) run() (string, error) {
+	objects := make([]Body, len(r.Bodies))
+	for i, body := range r.Bodies {
+		objects[i] = Body{
+			Position: replayVector(body.Position),
+			Velocity: replayVector(body.Velocity),
+		}
+	}
+
+	options := []WorldOption{WithDeterminism()}
+	for _, c := range r.Constraints {
+		options = append(options, WithConstraints(DistanceConstraint{
+			A: c.A, B: c.B, Anchor: replayVector(c.Anchor), Length: c.Length,
+			Stiffness: c.Stiffness, Damping: c.Damping, Strength: c.Strength,
+		}))
+	}
+	for _, wd := range r.Welds {
+		options = append(options, WithWelds(Weld{
+			A: wd.A, B: wd.B, AnchorA: replayVector(wd.AnchorA), AnchorB: replayVector(wd.AnchorB),
+			Angle: wd.Angle, Strength: wd.Strength,
+		}))
+	}
+	for _, j := range r.Wheels {
+		options = append(options, WithWheelJoints(WheelJoint{
+			A: j.A, B: j.B, Anchor: replayVector(j.Anchor), Axis: replayVector(j.Axis),
+			Stiffness: j.Stiffness, Damping: j.Damping,
+			MotorSpeed: j.MotorSpeed, MotorTorque: j.MotorTorque,
+		}))
+	}
+
+	w := NewWorld(objects, replayVector(r.Gravity), options...)
+	defer w.Close()
+	if err := errors.Join(w.Validate()...); err != nil {
+		return "", err
+	}
+	for i := 0; i < r.Steps; i++ {
+		w.Step()
+	}
+	return w.Checksum(), nil
+}
+
+// replayVector returns the vector stored as x and y in a replay.
+func replayVector(xy [2]float64) Vector {
+	return Vector{X: xy[0], Y: xy[1]}
+}
+
+// Checksum hashes the exact bits of every body's state after the last
+// completed step, so any change in behaviour, however small, shows up.
+func (w *World) Checksum() string {
+	hash := fnv.New64a()
+	var buf [8]byte
+	write := func(f float64) {
+		bits := math.Float64bits(f)
+		for i := range buf {
+			buf[i] = byte(bits >> (8 * i))
+		}
+		hash.Write(buf[:])
+	}
+
+	for _, ball := range w.Snapshot() {
+		write(ball.Position.X)
+		write(ball.Position.Y)
+		write(ball.Velocity.X)
+		write(ball.Velocity.Y)
+	}
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
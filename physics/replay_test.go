@@ -0,0 +1,44 @@
+package physics
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+)
+
+var updateReplays = flag.Bool("update", false, "rewrite replay checksums in testdata")
+
+func TestReplays(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "replays", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no replays found in testdata/replays")
+	}
+
+	for _, path := range paths {
+		r, err := loadReplay(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Run(r.Name, func(t *testing.T) {
+			got, err := r.run()
+			if err != nil {
+				t.Fatalf("%s: %v", path, err)
+			}
+			if *updateReplays {
+				r.Checksum = got
+				if err := r.save(path); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if got != r.Checksum {
+				t.Errorf("%s: checksum after %d steps = %s, want %s", path, r.Steps, got, r.Checksum)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package physics
+
+// RewindSeconds is how far back the world can be rewound.
+const RewindSeconds = 5
+
+// Checkpoint is everything a step changes in the world, saved so the world
+// can be put back as it was. Static geometry belongs to whichever game
+// placed it, so it is left alone, as is everything outside the world.
+type Checkpoint struct {
+	Steps       uint64
+	Objects     []Body
+	Constraints []DistanceConstraint
+	Welds       []Weld
+	Wheels      []WheelJoint
+	water       *Water
+	bands       []*Band
+	ropes       []*Rope
+	cloths      []*Cloth
+}
+
+// History is a ring of checkpoints of the most recent steps, the oldest
+// at start. Once it is full each new one replaces the oldest.
+type History struct {
+	ring  []Checkpoint
+	start int
+	count int
+}
+
+func NewHistory(capacity int) *History {
+	return &History{ring: make([]Checkpoint, capacity)}
+}
+
+// Record saves the world as of its last step. It must not be called while
+// a step runs.
+func (h *History) Record(w *World) {
+	var c *Checkpoint
+	if h.count < len(h.ring) {
+		c = &h.ring[(h.start+h.count)%len(h.ring)]
+		h.count++
+	} else {
+		c = &h.ring[h.start]
+		h.start = (h.start + 1) % len(h.ring)
+	}
+	c.Capture(w)
+}
+
+// Rewind puts the world back one recorded step and forgets the step it
+// was at, so stepping on from there records a new future. It reports
+// false once there is nothing earlier to go back to. It must not be
+// called while a step runs.
+func (h *History) Rewind(w *World) bool {
+	if h.count < 2 {
+		return false
+	}
+	h.count--
+	w.Restore(&h.ring[(h.start+h.count-1)%len(h.ring)])
+	return true
+}
+
+// Seconds returns how far back the world can still be rewound.
+func (h *History) Seconds() float64 {
+	return float64(max(h.count-1, 0)) / TicksPerSecond
+}
+
+// Capture saves the world as of its last step into c, reusing c's slices.
+func (c *Checkpoint) Capture(w *World) {
+	c.Steps = w.Steps
+	c.Objects = append(c.Objects[:0], w.Snapshot()...)
+	c.Constraints = append(c.Constraints[:0], w.Constraints...)
+	c.Welds = append(c.Welds[:0], w.Welds...)
+	c.Wheels = append(c.Wheels[:0], w.Wheels...)
+	c.water = w.Water.clone()
+	c.bands = c.bands[:0]
+	for _, b := range w.Bands {
+		c.bands = append(c.bands, b.clone())
+	}
+	c.ropes = c.ropes[:0]
+	for _, r := range w.Ropes {
+		c.ropes = append(c.ropes, r.clone())
+	}
+	c.cloths = c.cloths[:0]
+	for _, cloth := range w.Cloths {
+		c.cloths = append(c.cloths, cloth.clone())
+	}
+}
+
+// Restore puts the world back to checkpoint c, leaving c as it was. It
+// must not be called while a step runs.
+func (w *World) Restore(c *Checkpoint) {
+	front := w.front.Load()
+	front.objects = append(front.objects[:0], c.Objects...)
+	w.Constraints = append(w.Constraints[:0], c.Constraints...)
+	w.Welds = append(w.Welds[:0], c.Welds...)
+	w.Wheels = append(w.Wheels[:0], c.Wheels...)
+	w.Water = c.water.clone()
+	w.Bands = w.Bands[:0]
+	for _, b := range c.bands {
+		w.Bands = append(w.Bands, b.clone())
+	}
+	w.Ropes = w.Ropes[:0]
+	for _, r := range c.ropes {
+		w.Ropes = append(w.Ropes, r.clone())
+	}
+	w.Cloths = w.Cloths[:0]
+	for _, cloth := range c.cloths {
+		w.Cloths = append(w.Cloths, cloth.clone())
+	}
+	w.Steps = c.Steps
+	// The impacts were from the step being undone
+	w.impacts = w.impacts[:0]
+
+	w.refile()
+}
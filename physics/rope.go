@@ -0,0 +1,172 @@
+package physics
+
+import (
+	"math"
+	"slices"
+)
+
+const (
+	// pointInverseMass makes each point of a rope or cloth a tenth of a
+	// unit-mass ball
+	pointInverseMass = 10
+	// pointThickness is how far a rope or cloth reaches beyond its points
+	pointThickness = 2
+	// ropeIterations is how many times a rope's links are pulled back to
+	// length per substep; more make a long rope stretch less
+	ropeIterations = 16
+)
+
+// Rope is a chain of light points joined by links of fixed length, hung
+// from an anchor at its first end. The points move by Verlet integration,
+// each keeping on from where it was by how far it last moved, so pulling
+// the links back to length after the move is enough to swing the rope and
+// no velocities need solving.
+type Rope struct {
+	anchor    Vector
+	positions []Vector
+	// previous is where each point was a substep ago
+	previous []Vector
+	// link is each link's length, and stiffness the share of a link's
+	// stretch taken out each time the links are pulled to length
+	link      float64
+	stiffness float64
+}
+
+// WithRope lays a rope straight from anchor to end in the given number of
+// links, hung from anchor, to swing from there. stiffness, from 0 to 1, is
+// how much of a link's stretch is taken out at a time: at 1 the rope
+// barely gives, and lower it stretches like a bungee.
+func WithRope(anchor, end Vector, segments int, stiffness float64) WorldOption {
+	return func(w *World) {
+		segments = max(segments, 1)
+		span := Subtract(end, anchor)
+		r := &Rope{
+			anchor:    anchor,
+			positions: make([]Vector, segments+1),
+			link:      span.Magnitude() / float64(segments),
+			stiffness: math.Max(0, math.Min(1, stiffness)),
+		}
+		for k := range r.positions {
+			r.positions[k] = Add(anchor, ScalarMult(span, float64(k)/float64(segments)))
+		}
+		r.previous = slices.Clone(r.positions)
+		w.Ropes = append(w.Ropes, r)
+	}
+}
+
+// Points appends the rope's points to dst, from the anchor to the free end.
+func (r *Rope) Points(dst []Vector) []Vector {
+	return append(dst, r.positions...)
+}
+
+// swingRopes moves every rope on by dt ticks and lets it push against the
+// balls it touches. It runs serially, after the contacts.
+func (w *World) swingRopes(objects []Body, dt float64) {
+	pull := ScalarMult(w.gravity, dt*dt)
+	for _, r := range w.Ropes {
+		for k := 1; k < len(r.positions); k++ {
+			p := r.positions[k]
+			r.positions[k] = Add(Add(p, Subtract(p, r.previous[k])), pull)
+			r.previous[k] = p
+		}
+		for range ropeIterations {
+			r.tighten()
+			w.pressPoint(objects, &r.positions[0], &r.previous[0], 0, dt)
+			for k := 1; k < len(r.positions); k++ {
+				w.pressPoint(objects, &r.positions[k], &r.previous[k], pointInverseMass, dt)
+			}
+		}
+	}
+}
+
+// tighten pulls every link of the rope back towards its length, each end
+// by half the error, the anchored end not at all. Going along the rope
+// one way and back the other carries corrections down it both ways, so
+// it settles in fewer passes.
+func (r *Rope) tighten() {
+	r.positions[0] = r.anchor
+	for k := 1; k < len(r.positions); k++ {
+		r.pullLink(k)
+	}
+	for k := len(r.positions) - 1; k >= 1; k-- {
+		r.pullLink(k)
+	}
+}
+
+// pullLink pulls the link from point k-1 to point k towards its length.
+func (r *Rope) pullLink(k int) {
+	offset := Subtract(r.positions[k], r.positions[k-1])
+	length := offset.Magnitude()
+	if length == 0 {
+		return
+	}
+	correction := ScalarMult(offset, r.stiffness*(length-r.link)/length)
+	if k == 1 {
+		r.positions[k] = Subtract(r.positions[k], correction)
+		return
+	}
+	half := ScalarMult(correction, 0.5)
+	r.positions[k-1] = Add(r.positions[k-1], half)
+	r.positions[k] = Subtract(r.positions[k], half)
+}
+
+// pressPoint pushes a point of a rope or cloth, at p and a substep of dt
+// ticks ago at before, and every ball it overlaps apart, by their shares
+// of inverse mass, and stops them closing on each other. A point held
+// still has no inverse mass and only pushes. Moving a point drags where it
+// was along with it, so being pushed doesn't fling it off; only its
+// closing speed is taken away. In a box arena the point is kept inside
+// too.
+func (w *World) pressPoint(objects []Body, p, before *Vector, invMassPoint, dt float64) {
+	pad := w.broadphase.reach() + pointThickness
+	corner := Vector{X: pad, Y: pad}
+	w.pointQuery = w.broadphase.query(Subtract(*p, corner), Add(*p, corner), w.pointQuery[:0])
+	for _, i := range w.pointQuery {
+		if i >= len(objects) {
+			continue
+		}
+		currBall := &objects[i]
+		reach := currBall.Size() + pointThickness
+		offset := Subtract(*p, currBall.Position)
+		distance := offset.Magnitude()
+		if distance >= reach || distance == 0 {
+			continue
+		}
+		normal := ScalarMult(offset, 1/distance)
+		invMass := currBall.inverseMass()
+		if invMassPoint+invMass == 0 {
+			continue
+		}
+		share := invMassPoint / (invMassPoint + invMass)
+
+		push := ScalarMult(normal, (reach-distance)*share)
+		*p, *before = Add(*p, push), Add(*before, push)
+		currBall.Position = Subtract(currBall.Position, ScalarMult(normal, (reach-distance)*(1-share)))
+
+		velocity := ScalarMult(Subtract(*p, *before), 1/dt)
+		closing := DotProduct(Subtract(velocity, currBall.Velocity), normal)
+		if closing < 0 {
+			*before = Add(*before, ScalarMult(normal, closing*share*dt))
+			currBall.Velocity = Add(currBall.Velocity, ScalarMult(normal, closing*(1-share)))
+		}
+	}
+
+	if size := w.Arena.size(); w.Arena.Shape == BoxArena {
+		if p.X < 0 || p.X > size.X {
+			p.X = math.Max(0, math.Min(size.X, p.X))
+			before.X = p.X
+		}
+		if p.Y < 0 || p.Y > size.Y {
+			p.Y = math.Max(0, math.Min(size.Y, p.Y))
+			before.Y = p.Y
+		}
+	}
+}
+
+// clone returns a copy of the rope that can be moved separately.
+func (r *Rope) clone() *Rope {
+	c := *r
+	c.positions = slices.Clone(r.positions)
+	c.previous = slices.Clone(r.previous)
+	return &c
+}
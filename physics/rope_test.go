@@ -0,0 +1,49 @@
+package physics
+
+import "testing"
+
+// TestRopeSwingsDown lays a rope out level from its anchor and checks it
+// swings down to hang below it, its links holding their length but for
+// the little they give as it whips round.
+func TestRopeSwingsDown(t *testing.T) {
+	anchor := Vector{X: 100, Y: 40}
+	w := NewWorld(nil, Vector{Y: 0.3}, WithRope(anchor, Vector{X: 400, Y: 40}, 30, 1))
+	defer w.Close()
+
+	lowest := 0.0
+	for range 600 {
+		w.Step()
+		points := w.Ropes[0].Points(nil)
+		if points[0] != anchor {
+			t.Fatalf("rope came off its anchor to %v", points[0])
+		}
+		length := 0.0
+		for k := 1; k < len(points); k++ {
+			link := Subtract(points[k], points[k-1])
+			length += link.Magnitude()
+		}
+		if length > 320 {
+			t.Fatalf("rope stretched to %.1f, want it within a few percent of 300", length)
+		}
+		lowest = max(lowest, points[len(points)-1].Y)
+	}
+	if lowest < 300 {
+		t.Errorf("rope's end swung down to y %.1f, want it hanging near 340", lowest)
+	}
+}
+
+// TestBallPushesRope throws a ball sideways into a rope hanging still in
+// empty space and checks the ball is slowed and the rope pushed aside.
+func TestBallPushesRope(t *testing.T) {
+	w := NewWorld([]Body{{Position: Vector{X: 100, Y: 250}, Velocity: Vector{X: 5}}}, Vector{}, WithRope(Vector{X: 320, Y: 20}, Vector{X: 320, Y: 320}, 30, 1))
+	defer w.Close()
+	for range 100 {
+		w.Step()
+	}
+	if b := w.Snapshot()[0]; b.Velocity.X >= 4.9 {
+		t.Errorf("ball moving %v after hitting the rope, want it slowed", b.Velocity)
+	}
+	if hit := w.Ropes[0].Points(nil)[23]; hit.X <= 330 {
+		t.Errorf("rope where the ball hit it at %v, want it pushed on past 330", hit)
+	}
+}
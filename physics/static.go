@@ -0,0 +1,216 @@
+package physics
+
+import (
+	"math"
+	"slices"
+)
+
+// StaticCircle is an immovable round obstacle, such as a peg.
+type StaticCircle struct {
+	Position Vector
+	Radius   float64
+}
+
+// StaticSegment is an immovable wall from a to b.
+type StaticSegment struct {
+	A Vector
+	B Vector
+}
+
+// StaticBox is an immovable box from min to max, turned through Angle
+// radians clockwise about its middle, so a tilted one makes a ramp. At
+// zero angle it is axis-aligned.
+type StaticBox struct {
+	Min   Vector
+	Max   Vector
+	Angle float64
+}
+
+// WithStaticCircles adds round obstacles to the world.
+func WithStaticCircles(circles ...StaticCircle) WorldOption {
+	return func(w *World) {
+		w.StaticCircles = append(w.StaticCircles, circles...)
+	}
+}
+
+// WithStaticSegments adds straight walls to the world.
+func WithStaticSegments(segments ...StaticSegment) WorldOption {
+	return func(w *World) {
+		w.StaticSegments = append(w.StaticSegments, segments...)
+	}
+}
+
+// WithStaticBoxes adds boxes to the world.
+func WithStaticBoxes(boxes ...StaticBox) WorldOption {
+	return func(w *World) {
+		w.StaticBoxes = append(w.StaticBoxes, boxes...)
+	}
+}
+
+// PlaceStaticBox moves box k of the world to b. It must not be called
+// while a step runs.
+func (w *World) PlaceStaticBox(k int, b StaticBox) {
+	if k < len(w.StaticBoxes) {
+		w.StaticBoxes[k] = b
+	}
+}
+
+// RemoveStaticBox takes the first box equal to b out of the world. It must
+// not be called while a step runs.
+func (w *World) RemoveStaticBox(b StaticBox) {
+	if k := slices.Index(w.StaticBoxes, b); k >= 0 {
+		w.StaticBoxes = slices.Delete(w.StaticBoxes, k, k+1)
+	}
+}
+
+// centre returns the middle of the box, which it is turned about.
+func (b StaticBox) centre() Vector {
+	return ScalarMult(Add(b.Min, b.Max), 0.5)
+}
+
+// turned returns p turned through angle about the box's middle, to go
+// between the world and the box as if it weren't turned.
+func (b StaticBox) turned(p Vector, angle float64) Vector {
+	if angle == 0 {
+		return p
+	}
+	c := b.centre()
+	return Add(c, RotateBy(Subtract(p, c), angle))
+}
+
+// Closest returns the point on the box nearest to p, which is p itself if
+// it is inside.
+func (b StaticBox) Closest(p Vector) Vector {
+	local := b.turned(p, -b.Angle)
+	closest := Vector{X: math.Max(b.Min.X, math.Min(b.Max.X, local.X)), Y: math.Max(b.Min.Y, math.Min(b.Max.Y, local.Y))}
+	return b.turned(closest, b.Angle)
+}
+
+// Contains reports whether p is on or inside the box.
+func (b StaticBox) Contains(p Vector) bool {
+	p = b.turned(p, -b.Angle)
+	return p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
+
+// Corners returns the box's corners in order round it, as it is turned.
+func (b StaticBox) Corners() [4]Vector {
+	return [4]Vector{
+		b.turned(b.Min, b.Angle),
+		b.turned(Vector{X: b.Max.X, Y: b.Min.Y}, b.Angle),
+		b.turned(b.Max, b.Angle),
+		b.turned(Vector{X: b.Min.X, Y: b.Max.Y}, b.Angle),
+	}
+}
+
+// Closest returns the point on the segment nearest to p.
+func (s StaticSegment) Closest(p Vector) Vector {
+	along := Subtract(s.B, s.A)
+	lengthSquared := along.MagnitudeSquared()
+	if lengthSquared == 0 {
+		return s.A
+	}
+	t := math.Max(0, math.Min(1, DotProduct(Subtract(p, s.A), along)/lengthSquared))
+	return Add(s.A, ScalarMult(along, t))
+}
+
+// collideStatic pushes every ball out of the static geometry, in parallel.
+// Obstacles bounce balls like the screen edges do. Each chunk of balls
+// collects its own impacts, which are then added in chunk order.
+func (w *World) collideStatic(objects []Body) {
+	if len(w.StaticCircles) == 0 && len(w.StaticSegments) == 0 && len(w.StaticBoxes) == 0 && len(w.StaticPolygons) == 0 && len(w.StaticChains) == 0 && len(w.Heightfields) == 0 {
+		return
+	}
+	chunks := w.pool.chunks(len(objects))
+	for len(w.chunkImpacts) < chunks {
+		w.chunkImpacts = append(w.chunkImpacts, nil)
+	}
+
+	w.pool.parallelFor(len(objects), func(chunk, start, end int) {
+		impacts := w.chunkImpacts[chunk][:0]
+		for i := start; i < end; i++ {
+			currBall := &objects[i]
+			for _, c := range w.StaticCircles {
+				if touch, speed := w.pushOut(currBall, c.Position, c.Radius); speed > 0 {
+					impacts = append(impacts, w.obstacleImpact(objects, i, touch, speed))
+				}
+			}
+			for _, s := range w.StaticSegments {
+				if touch, speed := w.pushOut(currBall, s.Closest(currBall.Position), 0); speed > 0 {
+					impacts = append(impacts, w.obstacleImpact(objects, i, touch, speed))
+				}
+			}
+			for _, b := range w.StaticBoxes {
+				if touch, speed := w.pushOut(currBall, b.Closest(currBall.Position), 0); speed > 0 {
+					impacts = append(impacts, w.obstacleImpact(objects, i, touch, speed))
+				}
+			}
+			for _, polygon := range w.StaticPolygons {
+				for _, piece := range polygon.Pieces {
+					if touch, speed := w.pushOut(currBall, closestOnPiece(piece, currBall.Position), 0); speed > 0 {
+						impacts = append(impacts, w.obstacleImpact(objects, i, touch, speed))
+					}
+				}
+			}
+			for _, c := range w.StaticChains {
+				// A second push frees a ball the first left in another
+				// edge, as in a corner
+				for pass := 0; pass < 2; pass++ {
+					closest, ok := c.Closest(currBall.Position)
+					if !ok {
+						break
+					}
+					if touch, speed := w.pushOut(currBall, closest, 0); speed > 0 {
+						impacts = append(impacts, w.obstacleImpact(objects, i, touch, speed))
+					}
+				}
+			}
+			for _, h := range w.Heightfields {
+				if touch, speed := w.collideHeightfield(currBall, h); speed > 0 {
+					impacts = append(impacts, w.obstacleImpact(objects, i, touch, speed))
+				}
+			}
+		}
+		w.chunkImpacts[chunk] = impacts
+	})
+
+	for _, impacts := range w.chunkImpacts[:chunks] {
+		w.impacts = append(w.impacts, impacts...)
+	}
+}
+
+// pushOut separates a ball from an obstacle whose nearest point is at
+// closest and which reaches radius beyond it, bouncing the ball off if it
+// was moving in. It returns where the two touch and how fast the ball was
+// moving in, or zero if it wasn't.
+func (w *World) pushOut(currBall *Body, closest Vector, radius float64) (Vector, float64) {
+	offset := Subtract(currBall.Position, closest)
+	reach := currBall.Size() + radius
+	distanceSquared := offset.MagnitudeSquared()
+	if !(distanceSquared < reach*reach) {
+		return Vector{}, 0
+	}
+
+	// A ball centred exactly on the obstacle is pushed up out of it
+	normal := Vector{Y: -1}
+	if distance := math.Sqrt(distanceSquared); distance > 0 {
+		normal = ScalarMult(offset, 1/distance)
+	}
+	return w.bounceOff(currBall, closest, normal, radius)
+}
+
+// bounceOff moves a ball out along normal until it just touches an obstacle
+// whose nearest point is at closest and which reaches radius beyond it,
+// bouncing the ball off if it was moving in. It returns the same as
+// pushOut.
+func (w *World) bounceOff(currBall *Body, closest, normal Vector, radius float64) (Vector, float64) {
+	currBall.Position = Add(closest, ScalarMult(normal, currBall.Size()+radius))
+
+	approach := DotProduct(currBall.Velocity, normal)
+	if approach >= 0 {
+		return Vector{}, 0
+	}
+	pushed := -(1 + w.restitution(currBall, -approach)) * approach
+	currBall.Velocity = Add(currBall.Velocity, ScalarMult(normal, pushed))
+	roll(currBall, normal, pushed)
+	return Add(closest, ScalarMult(normal, radius)), -approach
+}
@@ -0,0 +1,89 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSegmentClosest(t *testing.T) {
+	s := StaticSegment{A: Vector{X: 0, Y: 0}, B: Vector{X: 10, Y: 0}}
+	tests := []struct {
+		name string
+		p    Vector
+		want Vector
+	}{
+		{"above middle", Vector{X: 4, Y: -3}, Vector{X: 4}},
+		{"past a", Vector{X: -5, Y: 2}, Vector{}},
+		{"past b", Vector{X: 15, Y: 2}, Vector{X: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Closest(tt.p); !vectorsClose(got, tt.want) {
+				t.Errorf("closest(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestBallLandsOnPeg checks a ball dropped onto a peg comes to rest on or
+// beside it rather than falling through.
+func TestBallLandsOnPeg(t *testing.T) {
+	peg := StaticCircle{Position: Vector{X: 320, Y: 300}, Radius: 4}
+	objects := []Body{{Position: Vector{X: 320, Y: 100}}}
+	w := NewWorld(objects, Vector{Y: .3}, WithStaticCircles(peg), WithWallRestitution(0))
+	defer w.Close()
+
+	for i := 0; i < 60; i++ {
+		w.Step()
+		offset := Subtract(w.Snapshot()[0].Position, peg.Position)
+		if distance := offset.Magnitude(); distance < BallRadius+peg.Radius-epsilon {
+			t.Fatalf("step %d: ball is %v from the peg, inside it", i, distance)
+		}
+	}
+	if y := w.Snapshot()[0].Position.Y; math.Abs(y-(peg.Position.Y-BallRadius-peg.Radius)) > 1 {
+		t.Errorf("ball at y = %v, want it sitting on top of the peg", y)
+	}
+}
+
+// TestBallBouncesOffBox throws a ball at the side of a box and checks it
+// comes straight back, reporting an impact on the face it hit.
+func TestBallBouncesOffBox(t *testing.T) {
+	box := StaticBox{Min: Vector{X: 300, Y: 200}, Max: Vector{X: 400, Y: 240}}
+	w := NewWorld([]Body{{Position: Vector{X: 200, Y: 220}, Velocity: Vector{X: 4}}}, Vector{}, WithStaticBoxes(box))
+	defer w.Close()
+
+	var touch Vector
+	for i := 0; i < 40 && touch == (Vector{}); i++ {
+		w.Step()
+		for _, hit := range w.LastImpacts() {
+			touch = hit.Position
+		}
+	}
+	if touch.X != box.Min.X || !box.Contains(touch) {
+		t.Errorf("impact at %v, want it on the box's left face", touch)
+	}
+	if v := w.Snapshot()[0].Velocity; v != (Vector{X: -4}) {
+		t.Errorf("ball leaves at %v, want (-4, 0)", v)
+	}
+}
+
+// TestBallRollsDownTurnedBox drops a ball onto a box tilted down to the
+// right and checks it lands on the tilted top and slides off that way.
+func TestBallRollsDownTurnedBox(t *testing.T) {
+	ramp := StaticBox{Min: Vector{X: 200, Y: 290}, Max: Vector{X: 440, Y: 310}, Angle: 0.3}
+	w := NewWorld([]Body{{Position: Vector{X: 320, Y: 200}}}, Vector{Y: .3}, WithStaticBoxes(ramp), WithWallRestitution(0))
+	defer w.Close()
+
+	for i := 0; i < 40; i++ {
+		w.Step()
+		p := w.Snapshot()[0].Position
+		offset := Subtract(p, ramp.Closest(p))
+		if ramp.Contains(p) || offset.Magnitude() < BallRadius-1 {
+			t.Fatalf("step %d: ball at %v is inside the ramp", i, p)
+		}
+	}
+	if v := w.Snapshot()[0].Velocity; v.X <= 0 || v.Y <= 0 {
+		t.Errorf("ball moving at %v, want it sliding down and to the right", v)
+	}
+}
@@ -0,0 +1,121 @@
+package physics
+
+// Stats sums up the world as of its last completed step, for HUDs,
+// metrics and tests to read from the one place.
+type Stats struct {
+	Steps uint64
+	// Bodies counts every body, Frozen those frozen in place and Jointed
+	// those on the end of a rod, weld or wheel joint. Static counts the
+	// immovable shapes: circles, segments, boxes, polygons, chains and
+	// heightfields.
+	Bodies  int
+	Frozen  int
+	Jointed int
+	Static  int
+
+	// KineticEnergy is that of the bodies' motion, RotationalEnergy that
+	// of their turning, and PotentialEnergy theirs in the world's gravity,
+	// taken as zero at the top-left of the screen; Energy is all three
+	// together. Frozen bodies have none.
+	KineticEnergy    float64
+	RotationalEnergy float64
+	PotentialEnergy  float64
+	Energy           float64
+	Momentum         Vector
+
+	// Contacts counts the pairs of balls touching in the last substep, and
+	// Islands the groups of moving bodies that touch or are jointed to one
+	// another, each lone body making one of its own
+	Contacts int
+	Islands  int
+	// Collisions counts the impacts in the last second of steps, between
+	// bodies and against walls and static geometry. A resting contact is
+	// an impact every tick it pushes.
+	Collisions int
+
+	Timings PhaseTimings
+	// Penetration is the deepest two bodies overlapped, in world units
+	Penetration float64
+}
+
+// Stats sums up the world as of its last completed step. It must not be
+// called while a step runs.
+func (w *World) Stats() Stats {
+	state := w.front.Load()
+	objects := state.objects
+	s := Stats{
+		Steps:         w.Steps,
+		Bodies:        len(objects),
+		Static:        len(w.StaticCircles) + len(w.StaticSegments) + len(w.StaticBoxes) + len(w.StaticPolygons) + len(w.StaticChains) + len(w.Heightfields),
+		KineticEnergy: w.kineticEnergy(),
+		Momentum:      w.Momentum(),
+		Contacts:      len(w.contacts),
+		Islands:       w.islands(objects),
+		Timings:       state.timings,
+		Penetration:   state.penetration,
+	}
+	for i := range w.held() {
+		// Rods tied to an anchor are held at NoBody's end
+		if i != NoBody {
+			s.Jointed++
+		}
+	}
+	for i := range objects {
+		if objects[i].Frozen {
+			s.Frozen++
+			continue
+		}
+		s.RotationalEnergy += 0.5 * objects[i].inertia() * objects[i].AngularVelocity * objects[i].AngularVelocity
+		s.PotentialEnergy -= objects[i].mass() * DotProduct(w.gravity, objects[i].Position)
+	}
+	s.Energy = s.KineticEnergy + s.RotationalEnergy + s.PotentialEnergy
+	for _, n := range w.collisions {
+		s.Collisions += n
+	}
+	return s
+}
+
+// islands counts the groups of moving bodies joined by the last substep's
+// contacts or by rods, welds and wheel joints. A frozen body joins nothing
+// to anything, as a wall doesn't.
+func (w *World) islands(objects []Body) int {
+	parent := make([]int, len(objects))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(i int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+	// Bodies merged or removed since the contacts were found may leave
+	// some pointing past the end
+	link := func(a, b int) {
+		if a < 0 || b < 0 || a >= len(objects) || b >= len(objects) || objects[a].Frozen || objects[b].Frozen {
+			return
+		}
+		parent[root(a)] = root(b)
+	}
+	for _, c := range w.contacts {
+		link(c.A, c.B)
+	}
+	for _, c := range w.Constraints {
+		link(c.A, c.B)
+	}
+	for _, wd := range w.Welds {
+		link(wd.A, wd.B)
+	}
+	for _, j := range w.Wheels {
+		link(j.A, j.B)
+	}
+
+	islands := 0
+	for i := range objects {
+		if !objects[i].Frozen && root(i) == i {
+			islands++
+		}
+	}
+	return islands
+}
@@ -0,0 +1,75 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestStats builds a world of two balls resting against each other, a
+// pair on a rod, one hung from an anchor, a frozen one and a lone one,
+// and checks the counts, islands and energies a step later.
+func TestStats(t *testing.T) {
+	objects := []Body{
+		{Position: Vector{X: 100, Y: 100}},
+		{Position: Vector{X: 139, Y: 100}},
+		{Position: Vector{X: 300, Y: 100}, Velocity: Vector{X: 2}},
+		{Position: Vector{X: 400, Y: 100}, Velocity: Vector{X: 2}},
+		{Position: Vector{X: 500, Y: 200}},
+		{Position: Vector{X: 100, Y: 400}, Frozen: true},
+		{Position: Vector{X: 300, Y: 400}, Mass: 2, Velocity: Vector{Y: -3}},
+	}
+	w := NewWorld(objects, Vector{Y: 0.1},
+		WithConstraints(NewRod(objects, 2, 3), NewAnchoredRod(objects, 4, Vector{X: 500, Y: 100})),
+		WithStaticCircles(StaticCircle{Position: Vector{X: 600, Y: 50}, Radius: 10}),
+		WithStaticBoxes(StaticBox{Min: Vector{X: 0, Y: 460}, Max: Vector{X: 50, Y: 480}}),
+	)
+	defer w.Close()
+	w.Step()
+
+	s := w.Stats()
+	if s.Steps != 1 || s.Bodies != 7 || s.Frozen != 1 || s.Jointed != 3 || s.Static != 2 {
+		t.Errorf("counts = steps %d, bodies %d, frozen %d, jointed %d, static %d, want 1, 7, 1, 3, 2", s.Steps, s.Bodies, s.Frozen, s.Jointed, s.Static)
+	}
+	if s.Contacts != 1 {
+		t.Errorf("%d contacts, want the one between the first two balls", s.Contacts)
+	}
+	// The touching pair, the rod's pair, the hung ball and the lone one
+	if s.Islands != 4 {
+		t.Errorf("%d islands, want 4", s.Islands)
+	}
+
+	kinetic, potential := 0.0, 0.0
+	for _, b := range w.Snapshot() {
+		if b.Frozen {
+			continue
+		}
+		kinetic += 0.5 * b.mass() * b.Velocity.MagnitudeSquared()
+		potential -= b.mass() * 0.1 * b.Position.Y
+	}
+	if math.Abs(s.KineticEnergy-kinetic) > 1e-9 || math.Abs(s.PotentialEnergy-potential) > 1e-9 || math.Abs(s.Energy-kinetic-potential) > 1e-9 {
+		t.Errorf("energy = %v kinetic + %v potential = %v, want %v + %v", s.KineticEnergy, s.PotentialEnergy, s.Energy, kinetic, potential)
+	}
+	if !vectorsClose(s.Momentum, w.Momentum()) {
+		t.Errorf("momentum %v, want %v", s.Momentum, w.Momentum())
+	}
+}
+
+// TestStatsCollisionsLastASecond bounces a ball off the right wall and
+// checks the hit is counted for a second of steps, and then no longer.
+func TestStatsCollisionsLastASecond(t *testing.T) {
+	w := NewWorld([]Body{{Position: Vector{X: 600, Y: 240}, Velocity: Vector{X: 5}}}, Vector{})
+	defer w.Close()
+
+	for range 10 {
+		w.Step()
+	}
+	if got := w.Stats().Collisions; got != 1 {
+		t.Fatalf("%d collisions just after hitting the wall, want 1", got)
+	}
+	for range TicksPerSecond {
+		w.Step()
+	}
+	if got := w.Stats().Collisions; got != 0 {
+		t.Errorf("%d collisions a second after hitting the wall, want 0", got)
+	}
+}
@@ -0,0 +1,234 @@
+package physics
+
+import (
+	"fmt"
+	"math"
+)
+
+// overlapTolerance is how far, in world units, two static solids may overlap
+// before Validate reports them, so ones laid edge to edge pass.
+const overlapTolerance = 1e-6
+
+// Validate looks the world over before it is run and returns a problem
+// for every body or piece of static geometry that is set up wrong: a box
+// arena with no room inside, bodies with a negative mass or size, or
+// starting outside the arena, static shapes with no size or too few
+// points to make one, solids that overlap one another, and joints to
+// bodies that aren't there. Each
+// says what is wrong, where, and how to put it right. None of them stops
+// the world running, but each behaves other than was likely meant.
+func (w *World) Validate() []error {
+	var problems []error
+	report := func(format string, args ...any) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	if size := w.Arena.Size; size != (Vector{}) && (size.X <= 0 || size.Y <= 0) {
+		report("the arena is %g by %g; give it a width and height above zero", size.X, size.Y)
+	}
+
+	objects := w.Snapshot()
+	for i := range objects {
+		b := &objects[i]
+		if !finite(b.Position) || !finite(b.Velocity) {
+			report("body %d is at %v moving at %v; give it a position and velocity that are numbers", i, b.Position, b.Velocity)
+			continue
+		}
+		if b.Mass < 0 {
+			report("body %d has mass %g; give it a mass above zero, or zero for the default of one", i, b.Mass)
+		}
+		if b.Radius < 0 {
+			report("body %d has radius %g; give it a radius above zero, or zero for the default of %d", i, b.Radius, BallRadius)
+		}
+		if !w.Arena.holds(b.Position) {
+			report("body %d starts at %v, outside the arena; move it inside the walls or it is pulled in on the first step", i, b.Position)
+		}
+	}
+
+	for k, c := range w.StaticCircles {
+		if c.Radius <= 0 {
+			report("static circle %d at %v has radius %g; give it a radius above zero", k, c.Position, c.Radius)
+		}
+	}
+	for k, s := range w.StaticSegments {
+		if s.A == s.B {
+			report("static segment %d starts and ends at %v; give it two different ends", k, s.A)
+		}
+	}
+	for k, b := range w.StaticBoxes {
+		if b.Max.X <= b.Min.X || b.Max.Y <= b.Min.Y {
+			report("static box %d runs from %v to %v; Min must be above and left of Max", k, b.Min, b.Max)
+		}
+	}
+	for k, p := range w.StaticPolygons {
+		switch {
+		case len(p.Pieces) == 0:
+			report("static polygon %d has no area; give it at least three corners that aren't all in a line", k)
+		case !allConvex(p.Pieces):
+			report("static polygon %d crosses itself; list its corners in order round the outline", k)
+		}
+	}
+	for k, c := range w.StaticChains {
+		if c.edges() == 0 {
+			report("static chain %d has %d points; give it at least two", k, len(c.Points))
+		}
+	}
+	for k, h := range w.Heightfields {
+		if h.Spacing <= 0 || len(h.Heights) < 2 {
+			report("heightfield %d has %d heights %g apart; give it at least two, a distance above zero apart", k, len(h.Heights), h.Spacing)
+		}
+	}
+
+	w.validateOverlaps(report)
+
+	joined := func(kind string, a, b int) {
+		for _, i := range []int{a, b} {
+			if i != NoBody && (i < 0 || i >= len(objects)) {
+				report("a %s joins body %d, but there are only %d bodies; point it at one that exists", kind, i, len(objects))
+			}
+		}
+	}
+	for _, c := range w.Constraints {
+		joined("rod", c.A, c.B)
+	}
+	for _, wd := range w.Welds {
+		joined("weld", wd.A, wd.B)
+	}
+	for _, j := range w.Wheels {
+		joined("wheel joint", j.A, j.B)
+	}
+	return problems
+}
+
+// solid is a round or convex static shape Validate checks for overlaps,
+// with the name it reports it by. Round ones have no corners.
+type solid struct {
+	name    string
+	centre  Vector
+	radius  float64
+	corners []Vector
+}
+
+// validateOverlaps reports every pair of static circles, boxes and
+// polygons that overlap, which leaves a ball caught between them pushed
+// out of each into the other. Segments, chains and heightfields are thin,
+// or ground to stand solids on, so they may cross anything.
+func (w *World) validateOverlaps(report func(format string, args ...any)) {
+	var solids []solid
+	for k, c := range w.StaticCircles {
+		solids = append(solids, solid{name: fmt.Sprintf("static circle %d", k), centre: c.Position, radius: c.Radius})
+	}
+	for k, b := range w.StaticBoxes {
+		corners := b.Corners()
+		solids = append(solids, solid{name: fmt.Sprintf("static box %d", k), corners: corners[:]})
+	}
+	// The pieces of one polygon meet along their cuts, so only pieces of
+	// different polygons are compared
+	first := len(solids)
+	owner := map[int]int{}
+	for k, p := range w.StaticPolygons {
+		for _, piece := range p.Pieces {
+			owner[len(solids)] = k
+			solids = append(solids, solid{name: fmt.Sprintf("static polygon %d", k), corners: piece})
+		}
+	}
+
+	reported := map[[2]string]bool{}
+	for i := range solids {
+		for j := i + 1; j < len(solids); j++ {
+			if i >= first && owner[i] == owner[j] {
+				continue
+			}
+			a, b := &solids[i], &solids[j]
+			names := [2]string{a.name, b.name}
+			if reported[names] || !a.overlaps(b) {
+				continue
+			}
+			reported[names] = true
+			report("%s overlaps %s; move them apart or join them into one polygon", a.name, b.name)
+		}
+	}
+}
+
+// overlaps reports whether two solids overlap by more than
+// overlapTolerance.
+func (a *solid) overlaps(b *solid) bool {
+	switch {
+	case a.corners == nil && b.corners == nil:
+		offset := Subtract(a.centre, b.centre)
+		return offset.Magnitude() < a.radius+b.radius-overlapTolerance
+	case a.corners == nil:
+		return b.overlaps(a)
+	case b.corners == nil:
+		offset := Subtract(b.centre, closestOnPiece(a.corners, b.centre))
+		return offset.Magnitude() < b.radius-overlapTolerance
+	}
+	return !separated(a.corners, b.corners) && !separated(b.corners, a.corners)
+}
+
+// separated reports whether a line along one of a's sides keeps the two
+// convex shapes apart, or lets them no more than touch.
+func separated(a, b []Vector) bool {
+	for k, p := range a {
+		edge := Subtract(a[(k+1)%len(a)], p)
+		if edge == (Vector{}) {
+			continue
+		}
+		axis := UnitVector(Vector{X: -edge.Y, Y: edge.X})
+		minA, maxA := project(a, axis)
+		minB, maxB := project(b, axis)
+		if min(maxA, maxB)-max(minA, minB) <= overlapTolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// project returns how far along axis the shape's corners reach either way.
+func project(corners []Vector, axis Vector) (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, p := range corners {
+		d := DotProduct(p, axis)
+		lo, hi = min(lo, d), max(hi, d)
+	}
+	return lo, hi
+}
+
+// allConvex reports whether every piece is convex, as a polygon that
+// doesn't cross itself is cut into.
+func allConvex(pieces [][]Vector) bool {
+	for _, piece := range pieces {
+		if !convex(piece) {
+			return false
+		}
+	}
+	return true
+}
+
+// finite reports whether none of v's parts is infinite or not a number.
+func finite(v Vector) bool {
+	for _, x := range []float64{v.X, v.Y, v.Z} {
+		if math.IsInf(x, 0) || math.IsNaN(x) {
+			return false
+		}
+	}
+	return true
+}
+
+// holds reports whether p is inside the arena, or kept by one without
+// walls.
+func (a *Arena) holds(p Vector) bool {
+	size := a.size()
+	switch a.Shape {
+	case CircleArena:
+		offset := Subtract(p, a.Centre)
+		return offset.Magnitude() <= a.Radius
+	case PolygonArena:
+		return a.Contains(p)
+	case TorusArena:
+		return true
+	case OpenArena:
+		return p.X >= -a.margin && p.X <= size.X+a.margin && p.Y >= -a.margin && p.Y <= size.Y+a.margin
+	}
+	return p.X >= 0 && p.X <= size.X && p.Y >= 0 && p.Y <= size.Y
+}
@@ -0,0 +1,75 @@
+package physics
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestValidate builds a world with one of every problem Validate looks
+// for and checks each is reported once, naming what is wrong, and that a
+// world set up right, with solids only touching, has none.
+func TestValidate(t *testing.T) {
+	objects := []Body{
+		{Position: Vector{X: 100, Y: 100}, Mass: -1},
+		{Position: Vector{X: 200, Y: 100}, Radius: -5},
+		{Position: Vector{X: 900, Y: 100}},
+		{Position: Vector{X: math.NaN(), Y: 100}},
+	}
+	w := NewWorld(objects, Vector{Y: 0.3},
+		WithStaticCircles(StaticCircle{Position: Vector{X: 300, Y: 300}, Radius: 30}, StaticCircle{Position: Vector{X: 50, Y: 50}}),
+		WithStaticBoxes(StaticBox{Min: Vector{X: 320, Y: 290}, Max: Vector{X: 400, Y: 320}}, StaticBox{Min: Vector{X: 10, Y: 10}, Max: Vector{X: 5, Y: 20}}),
+		WithStaticSegments(StaticSegment{A: Vector{X: 1, Y: 1}, B: Vector{X: 1, Y: 1}}),
+		WithStaticPolygons(
+			NewStaticPolygon(Vector{X: 0, Y: 0}, Vector{X: 10, Y: 10}, Vector{X: 20, Y: 20}),
+			NewStaticPolygon(Vector{X: 100, Y: 400}, Vector{X: 200, Y: 460}, Vector{X: 200, Y: 400}, Vector{X: 100, Y: 460}),
+		),
+		WithStaticChains(StaticChain{Points: []Vector{{X: 5, Y: 5}}}),
+		WithHeightfields(Heightfield{Spacing: 0, Heights: []float64{1, 2}}),
+		WithConstraints(DistanceConstraint{A: 0, B: 7, Length: 10}),
+	)
+	defer w.Close()
+
+	var got []string
+	for _, problem := range w.Validate() {
+		got = append(got, problem.Error())
+	}
+	for _, want := range []string{
+		"body 0 has mass -1",
+		"body 1 has radius -5",
+		"body 2 starts at",
+		"body 3 is at",
+		"static circle 1 at",
+		"static box 1 runs from",
+		"static segment 0 starts and ends",
+		"static polygon 0 has no area",
+		"static polygon 1 crosses itself",
+		"static chain 0 has 1 points",
+		"heightfield 0 has 2 heights",
+		"static circle 0 overlaps static box 0",
+		"a rod joins body 7",
+	} {
+		found := 0
+		for _, line := range got {
+			if strings.HasPrefix(line, want) {
+				found++
+			}
+		}
+		if found != 1 {
+			t.Errorf("%q reported %d times, want once, in:\n%s", want, found, strings.Join(got, "\n"))
+		}
+	}
+	if len(got) != 13 {
+		t.Errorf("%d problems reported, want 13:\n%s", len(got), strings.Join(got, "\n"))
+	}
+
+	clean := NewWorld([]Body{{Position: Vector{X: 100, Y: 100}}}, Vector{Y: 0.3},
+		WithStaticBoxes(StaticBox{Min: Vector{X: 200, Y: 200}, Max: Vector{X: 300, Y: 250}}, StaticBox{Min: Vector{X: 300, Y: 200}, Max: Vector{X: 400, Y: 250}}),
+		WithStaticCircles(StaticCircle{Position: Vector{X: 250, Y: 280}, Radius: 30}),
+		WithStaticPolygons(NewStaticPolygon(Vector{X: 400, Y: 300}, Vector{X: 500, Y: 300}, Vector{X: 500, Y: 400}, Vector{X: 450, Y: 350}, Vector{X: 400, Y: 400})),
+	)
+	defer clean.Close()
+	if problems := clean.Validate(); len(problems) != 0 {
+		t.Errorf("world set up right reported %v", problems)
+	}
+}
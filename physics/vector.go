@@ -0,0 +1,134 @@
+package physics
+
+import (
+	"fmt"
+	"math"
+)
+
+type Vector struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+func NewVector(x float64, y float64, z float64) *Vector {
+	return &Vector{X: x, Y: y, Z: z}
+}
+
+func (v *Vector) Magnitude() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+}
+
+// MagnitudeSquared avoids the square root when only comparing lengths.
+func (v *Vector) MagnitudeSquared() float64 {
+	return v.X*v.X + v.Y*v.Y + v.Z*v.Z
+}
+
+// Angles returns the angle in degrees between v and each axis. The zero
+// vector has no direction, so all three angles are reported as 0.
+func (v *Vector) Angles() (float64, float64, float64) {
+	if v.Magnitude() == 0 {
+		return 0, 0, 0
+	}
+
+	// calculate angle between vector and x axis
+	i := NewVector(1, 0, 0)
+	x_angle := clamped_acos((v.X*i.X + v.Y*i.Y + v.Z*i.Z) / (v.Magnitude() * i.Magnitude()))
+
+	// calculate angle between vector and y axis
+	j := NewVector(0, 1, 0)
+	y_angle := clamped_acos((v.X*j.X + v.Y*j.Y + v.Z*j.Z) / (v.Magnitude() * j.Magnitude()))
+
+	// calculate angle between vector and z axis
+	k := NewVector(0, 0, 1)
+	z_angle := clamped_acos((v.X*k.X + v.Y*k.Y + v.Z*k.Z) / (v.Magnitude() * k.Magnitude()))
+
+	return x_angle * 180 / math.Pi, y_angle * 180 / math.Pi, z_angle * 180 / math.Pi
+}
+
+func (v *Vector) ToString() string {
+	return fmt.Sprintf("(%.2f,%.2f,%.2f)", v.X, v.Y, v.Z)
+}
+
+func Add(vect1 Vector, vect2 Vector) Vector {
+	return Vector{vect1.X + vect2.X, vect1.Y + vect2.Y, vect1.Z + vect2.Z}
+}
+
+func Subtract(vect1 Vector, vect2 Vector) Vector {
+	return Vector{vect1.X - vect2.X, vect1.Y - vect2.Y, vect1.Z - vect2.Z}
+}
+
+func ScalarMult(vect Vector, scalar float64) Vector {
+	return Vector{vect.X * scalar, vect.Y * scalar, vect.Z * scalar}
+}
+
+func CrossProduct(vect1 Vector, vect2 Vector) Vector {
+	return Vector{
+		vect1.Y*vect2.Z - vect1.Z*vect2.Y,
+		vect1.Z*vect2.X - vect1.X*vect2.Z,
+		vect1.X*vect2.Y - vect1.Y*vect2.X,
+	}
+}
+
+// UnitVector returns v scaled to length 1, or the zero vector if v has
+// no length to scale.
+func UnitVector(v Vector) Vector {
+	magnitude := v.Magnitude()
+	if magnitude == 0 {
+		return Vector{}
+	}
+	return Vector{v.X / magnitude, v.Y / magnitude, v.Z / magnitude}
+}
+
+func DotProduct(vect1 Vector, vect2 Vector) float64 {
+	return vect1.X*vect2.X + vect1.Y*vect2.Y + vect1.Z*vect2.Z
+}
+
+// AngleBetweenVectors returns the angle in degrees between two vectors,
+// or 0 if either is the zero vector.
+func AngleBetweenVectors(vect1 Vector, vect2 Vector) float64 {
+	dot := DotProduct(vect1, vect2)
+	magnitude_product := vect1.Magnitude() * vect2.Magnitude()
+	if magnitude_product == 0 {
+		return 0
+	}
+	return clamped_acos(dot/magnitude_product) * 180 / math.Pi
+}
+
+// clamped_acos is math.Acos with its input clamped to [-1, 1], so rounding
+// error on nearly parallel vectors can't produce NaN.
+func clamped_acos(x float64) float64 {
+	return math.Acos(math.Max(-1, math.Min(1, x)))
+}
+
+// Projection returns vect1 projected onto vect2, or the zero vector when
+// projecting onto the zero vector.
+func Projection(vect1 Vector, vect2 Vector) Vector {
+	dot := DotProduct(vect1, vect2)
+	magnitude_squared := DotProduct(vect2, vect2)
+	if magnitude_squared == 0 {
+		return Vector{}
+	}
+	scale := dot / magnitude_squared
+	return ScalarMult(vect2, scale)
+}
+
+func Reflect(vect Vector, normal Vector) Vector {
+	dot := DotProduct(vect, normal)
+	return Subtract(vect, ScalarMult(normal, 2*dot))
+}
+
+// RotateBetween turns v in the xy plane through the angle that takes unit
+// vector from onto unit vector to.
+func RotateBetween(v Vector, from Vector, to Vector) Vector {
+	cos := DotProduct(from, to)
+	sin := from.X*to.Y - from.Y*to.X
+	return Vector{v.X*cos - v.Y*sin, v.X*sin + v.Y*cos, v.Z}
+}
+
+// RotateBy turns v in the xy plane through angle radians, clockwise on
+// screen.
+func RotateBy(v Vector, angle float64) Vector {
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	return Vector{v.X*cos - v.Y*sin, v.X*sin + v.Y*cos, v.Z}
+}
@@ -0,0 +1,268 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+const epsilon = 1e-9
+
+func vectorsClose(a, b Vector) bool {
+	return math.Abs(a.X-b.X) < epsilon && math.Abs(a.Y-b.Y) < epsilon && math.Abs(a.Z-b.Z) < epsilon
+}
+
+func hasNaN(v Vector) bool {
+	return math.IsNaN(v.X) || math.IsNaN(v.Y) || math.IsNaN(v.Z)
+}
+
+func TestAddSubtract(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Vector
+		sum  Vector
+		diff Vector
+	}{
+		{"basic", Vector{1, 2, 3}, Vector{4, 5, 6}, Vector{5, 7, 9}, Vector{-3, -3, -3}},
+		{"zero", Vector{1, 2, 3}, Vector{}, Vector{1, 2, 3}, Vector{1, 2, 3}},
+		{"opposite", Vector{1, -2, 3}, Vector{-1, 2, -3}, Vector{}, Vector{2, -4, 6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Add(tt.a, tt.b); !vectorsClose(got, tt.sum) {
+				t.Errorf("add(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.sum)
+			}
+			if got := Subtract(tt.a, tt.b); !vectorsClose(got, tt.diff) {
+				t.Errorf("subtract(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.diff)
+			}
+		})
+	}
+}
+
+func TestScalarMult(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      Vector
+		scalar float64
+		want   Vector
+	}{
+		{"scale up", Vector{1, -2, 3}, 2, Vector{2, -4, 6}},
+		{"negate", Vector{1, -2, 3}, -1, Vector{-1, 2, -3}},
+		{"by zero", Vector{1, -2, 3}, 0, Vector{}},
+		{"zero vector", Vector{}, 5, Vector{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScalarMult(tt.v, tt.scalar); !vectorsClose(got, tt.want) {
+				t.Errorf("scalar_mult(%v, %v) = %v, want %v", tt.v, tt.scalar, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDotProduct(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Vector
+		want float64
+	}{
+		{"basic", Vector{1, 2, 3}, Vector{4, 5, 6}, 32},
+		{"perpendicular", Vector{1, 0, 0}, Vector{0, 1, 0}, 0},
+		{"parallel", Vector{2, 0, 0}, Vector{3, 0, 0}, 6},
+		{"anti-parallel", Vector{2, 0, 0}, Vector{-3, 0, 0}, -6},
+		{"zero", Vector{1, 2, 3}, Vector{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DotProduct(tt.a, tt.b); math.Abs(got-tt.want) > epsilon {
+				t.Errorf("dot_product(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrossProduct(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Vector
+		want Vector
+	}{
+		{"x cross y", Vector{1, 0, 0}, Vector{0, 1, 0}, Vector{0, 0, 1}},
+		{"y cross x", Vector{0, 1, 0}, Vector{1, 0, 0}, Vector{0, 0, -1}},
+		{"parallel", Vector{1, 2, 3}, Vector{2, 4, 6}, Vector{}},
+		{"zero", Vector{1, 2, 3}, Vector{}, Vector{}},
+		{"general", Vector{1, 2, 3}, Vector{4, 5, 6}, Vector{-3, 6, -3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CrossProduct(tt.a, tt.b)
+			if !vectorsClose(got, tt.want) {
+				t.Errorf("cross_product(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+
+			// The result is perpendicular to both inputs
+			if DotProduct(got, tt.a) > epsilon || DotProduct(got, tt.b) > epsilon {
+				t.Errorf("cross_product(%v, %v) = %v is not perpendicular to its inputs", tt.a, tt.b, got)
+			}
+		})
+	}
+}
+
+func TestUnitVector(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Vector
+		want Vector
+	}{
+		{"axis", Vector{5, 0, 0}, Vector{1, 0, 0}},
+		{"3-4-5", Vector{3, 4, 0}, Vector{0.6, 0.8, 0}},
+		{"negative", Vector{0, -2, 0}, Vector{0, -1, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnitVector(tt.v)
+			if !vectorsClose(got, tt.want) {
+				t.Errorf("unit_vector(%v) = %v, want %v", tt.v, got, tt.want)
+			}
+			if math.Abs(got.Magnitude()-1) > epsilon {
+				t.Errorf("unit_vector(%v) has magnitude %v", tt.v, got.Magnitude())
+			}
+		})
+	}
+}
+
+func TestZeroVectorIsSafe(t *testing.T) {
+	zero := Vector{}
+	one := Vector{1, 1, 1}
+
+	if got := UnitVector(zero); got != zero {
+		t.Errorf("unit_vector(zero) = %v, want zero", got)
+	}
+	if got := AngleBetweenVectors(zero, one); got != 0 {
+		t.Errorf("angle_between_vectors(zero, v) = %v, want 0", got)
+	}
+	if got := AngleBetweenVectors(one, zero); got != 0 {
+		t.Errorf("angle_between_vectors(v, zero) = %v, want 0", got)
+	}
+	if got := Projection(one, zero); got != zero {
+		t.Errorf("projection(v, zero) = %v, want zero", got)
+	}
+	if x, y, z := zero.Angles(); x != 0 || y != 0 || z != 0 {
+		t.Errorf("zero.angles() = %v, %v, %v, want 0, 0, 0", x, y, z)
+	}
+}
+
+func TestAngleBetweenNearlyParallel(t *testing.T) {
+	// Rounding pushes the cosine just past 1 for these inputs
+	a := Vector{0.1, 0.1, 0.2}
+	b := ScalarMult(a, 5)
+	if got := AngleBetweenVectors(a, b); math.IsNaN(got) {
+		t.Errorf("angle_between_vectors(%v, %v) = NaN", a, b)
+	}
+}
+
+func TestProjection(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Vector
+		want Vector
+	}{
+		{"onto axis", Vector{3, 4, 0}, Vector{1, 0, 0}, Vector{3, 0, 0}},
+		{"onto scaled axis", Vector{3, 4, 0}, Vector{0, 10, 0}, Vector{0, 4, 0}},
+		{"perpendicular", Vector{0, 4, 0}, Vector{1, 0, 0}, Vector{}},
+		{"parallel", Vector{2, 2, 0}, Vector{1, 1, 0}, Vector{2, 2, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Projection(tt.a, tt.b); !vectorsClose(got, tt.want) {
+				t.Errorf("projection(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReflect(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      Vector
+		normal Vector
+		want   Vector
+	}{
+		{"floor", Vector{1, 2, 0}, Vector{0, -1, 0}, Vector{1, -2, 0}},
+		{"wall", Vector{3, 1, 0}, Vector{1, 0, 0}, Vector{-3, 1, 0}},
+		{"head on", Vector{0, 5, 0}, Vector{0, 1, 0}, Vector{0, -5, 0}},
+		{"grazing", Vector{5, 0, 0}, Vector{0, 1, 0}, Vector{5, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Reflect(tt.v, tt.normal)
+			if !vectorsClose(got, tt.want) {
+				t.Errorf("reflect(%v, %v) = %v, want %v", tt.v, tt.normal, got, tt.want)
+			}
+
+			// Reflection off a unit normal preserves speed
+			if math.Abs(got.Magnitude()-tt.v.Magnitude()) > epsilon {
+				t.Errorf("reflect(%v, %v) changed magnitude to %v", tt.v, tt.normal, got.Magnitude())
+			}
+		})
+	}
+}
+
+func TestAngleBetweenVectors(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Vector
+		want float64
+	}{
+		{"perpendicular", Vector{1, 0, 0}, Vector{0, 3, 0}, 90},
+		{"parallel", Vector{1, 1, 0}, Vector{2, 2, 0}, 0},
+		{"anti-parallel", Vector{1, 0, 0}, Vector{-1, 0, 0}, 180},
+		{"forty five", Vector{1, 0, 0}, Vector{1, 1, 0}, 45},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Acos is ill-conditioned near parallel inputs, so allow more slack
+			if got := AngleBetweenVectors(tt.a, tt.b); math.Abs(got-tt.want) > 1e-5 {
+				t.Errorf("angle_between_vectors(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMagnitude(t *testing.T) {
+	v := Vector{3, 4, 12}
+	if got := v.Magnitude(); got != 13 {
+		t.Errorf("magnitude(%v) = %v, want 13", v, got)
+	}
+	if got := v.MagnitudeSquared(); got != 169 {
+		t.Errorf("magnitudeSquared(%v) = %v, want 169", v, got)
+	}
+}
+
+func TestNaNPropagation(t *testing.T) {
+	nan := Vector{math.NaN(), 0, 0}
+	one := Vector{1, 1, 1}
+
+	if got := Add(nan, one); !hasNaN(got) {
+		t.Errorf("add with NaN = %v, want NaN", got)
+	}
+	if got := Subtract(one, nan); !hasNaN(got) {
+		t.Errorf("subtract with NaN = %v, want NaN", got)
+	}
+	if got := ScalarMult(one, math.NaN()); !hasNaN(got) {
+		t.Errorf("scalar_mult by NaN = %v, want NaN", got)
+	}
+	if got := DotProduct(nan, one); !math.IsNaN(got) {
+		t.Errorf("dot_product with NaN = %v, want NaN", got)
+	}
+	if got := CrossProduct(nan, one); !hasNaN(got) {
+		t.Errorf("cross_product with NaN = %v, want NaN", got)
+	}
+}
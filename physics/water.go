@@ -0,0 +1,157 @@
+package physics
+
+import (
+	"math"
+	"slices"
+)
+
+// WaterSpacing is how far apart, in world units, the columns of a water surface
+// are.
+const WaterSpacing = 8
+
+// Water is a pool filling the bottom of the world from left to right, up
+// to level at rest. Its surface is a row of columns, each sprung back to
+// level and pulling on its neighbours, so a disturbance spreads out as
+// waves. Balls in it are buoyed up and slowed down, and stir up the
+// surface as they go through it.
+type Water struct {
+	Left  float64
+	Right float64
+	Level float64
+
+	// Displacements is how far below level each column's surface is, and
+	// speeds how fast it is moving down
+	Displacements []float64
+	speeds        []float64
+	scratch       []float64
+
+	// stiffness and damping spring each column back to level; spread is
+	// how hard each pulls on its neighbours
+	stiffness float64
+	damping   float64
+	spread    float64
+	// buoyancy is the water's density against that of a unit-mass ball,
+	// so a unit-mass ball floats with 1/buoyancy of itself under
+	buoyancy float64
+	// drag is the share of a fully submerged ball's speed lost per tick
+	drag float64
+	// splash is the share of the difference between a ball's vertical
+	// speed and the surface's that the surface it passes through picks up
+	// per tick
+	splash float64
+}
+
+// WithWater fills the world with water between left and right up to
+// level.
+func WithWater(left, right, level float64) WorldOption {
+	return func(w *World) {
+		columns := int(math.Ceil((right-left)/WaterSpacing)) + 1
+		w.Water = &Water{
+			Left:          left,
+			Right:         right,
+			Level:         level,
+			Displacements: make([]float64, columns),
+			speeds:        make([]float64, columns),
+			stiffness:     0.02,
+			damping:       0.03,
+			spread:        0.2,
+			buoyancy:      2,
+			drag:          0.03,
+			splash:        0.05,
+		}
+	}
+}
+
+// surface returns the height of the water surface at x, between the
+// columns either side of it, or false if x is outside the pool.
+func (p *Water) surface(x float64) (float64, bool) {
+	if x < p.Left || x > p.Right {
+		return 0, false
+	}
+	at := (x - p.Left) / WaterSpacing
+	k := min(int(at), len(p.Displacements)-2)
+	t := at - float64(k)
+	return p.Level + p.Displacements[k]*(1-t) + p.Displacements[k+1]*t, true
+}
+
+// submerged returns the share of a ball of radius r centred at depth below
+// the surface that is under water: the area of the circle's segment below
+// the surface over the whole circle.
+func submerged(depth, r float64) float64 {
+	// under is how far the ball reaches below the surface
+	under := math.Max(0, math.Min(2*r, depth+r))
+	if under == 0 {
+		return 0
+	}
+	h := r - under
+	area := r*r*math.Acos(h/r) - h*math.Sqrt(r*r-h*h)
+	return area / (math.Pi * r * r)
+}
+
+// soak buoys up, slows and splashes every ball in the water for dt ticks,
+// then moves the surface on. It runs serially, since every ball can stir
+// the same columns.
+func (w *World) soak(objects []Body, dt float64) {
+	p := w.Water
+	if p == nil {
+		return
+	}
+
+	for i := range objects {
+		currBall := &objects[i]
+		scale := w.lodTimestep(i)
+		level, ok := p.surface(currBall.Position.X)
+		if !ok || scale == 0 {
+			continue
+		}
+		under := submerged(currBall.Position.Y-level, currBall.Size())
+		if under == 0 {
+			continue
+		}
+		step := dt * scale
+
+		lift := ScalarMult(w.gravity, -p.buoyancy*under*currBall.inverseMass()*step)
+		currBall.Velocity = Add(currBall.Velocity, lift)
+		currBall.Velocity = ScalarMult(currBall.Velocity, math.Max(0, 1-p.drag*under*step))
+
+		// A ball only stirs the surface while it is crossing it
+		if under < 1 {
+			first := max(0, int(math.Ceil((currBall.Position.X-currBall.Size()-p.Left)/WaterSpacing)))
+			last := min(len(p.speeds)-1, int((currBall.Position.X+currBall.Size()-p.Left)/WaterSpacing))
+			for k := first; k <= last; k++ {
+				push := p.splash * (currBall.Velocity.Y - p.speeds[k]) * step
+				p.speeds[k] += push
+				currBall.Velocity.Y -= push * currBall.inverseMass() / float64(last-first+1)
+			}
+		}
+	}
+
+	// Each column springs back to level and evens out with its neighbours,
+	// all from where they stood at the start of the substep
+	p.scratch = append(p.scratch[:0], p.Displacements...)
+	for k := range p.speeds {
+		pull := -p.stiffness*p.scratch[k] - p.damping*p.speeds[k]
+		if k > 0 {
+			pull += p.spread * (p.scratch[k-1] - p.scratch[k])
+		}
+		if k < len(p.scratch)-1 {
+			pull += p.spread * (p.scratch[k+1] - p.scratch[k])
+		}
+		p.speeds[k] += pull * dt
+	}
+	for k := range p.Displacements {
+		p.Displacements[k] += p.speeds[k] * dt
+	}
+}
+
+// clone returns a copy of the pool that can be stirred separately.
+func (p *Water) clone() *Water {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	c.Displacements = slices.Clone(p.Displacements)
+	c.speeds = slices.Clone(p.speeds)
+	c.scratch = nil
+	return &c
+}
@@ -0,0 +1,55 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSubmerged(t *testing.T) {
+	cases := []struct {
+		depth float64
+		want  float64
+	}{
+		{-2 * BallRadius, 0},
+		{-BallRadius, 0},
+		{0, 0.5},
+		{BallRadius, 1},
+		{2 * BallRadius, 1},
+	}
+	for _, c := range cases {
+		if got := submerged(c.depth, BallRadius); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("submerged(%v) = %v, want %v", c.depth, got, c.want)
+		}
+	}
+}
+
+// TestBallsFloatByMass drops a light and a heavy ball into still water and
+// checks the light one comes to rest floating at the surface, the heavy one
+// on the bottom, and that they made waves going in.
+func TestBallsFloatByMass(t *testing.T) {
+	const level = 300
+	w := NewWorld([]Body{
+		{Position: Vector{X: 160, Y: 100}, Mass: 1},
+		{Position: Vector{X: 480, Y: 100}, Mass: 6},
+	}, Vector{Y: .3}, WithWater(0, ScreenWidth, level))
+	defer w.Close()
+
+	stirred := 0.0
+	for tick := 0; tick < 1500; tick++ {
+		w.Step()
+		for _, d := range w.Water.Displacements {
+			stirred = math.Max(stirred, math.Abs(d))
+		}
+	}
+	if stirred < 1 {
+		t.Errorf("surface moved at most %.2f px, want the balls to make waves", stirred)
+	}
+
+	objects := w.Snapshot()
+	if floating := objects[0]; math.Abs(floating.Position.Y-level) > BallRadius/2 || math.Abs(floating.Velocity.Y) > 0.1 {
+		t.Errorf("unit-mass ball at y %.1f moving %.2f, want it floating half under at %d", floating.Position.Y, floating.Velocity.Y, level)
+	}
+	if sunk := objects[1]; sunk.Position.Y < ScreenHeight-BallRadius-1 {
+		t.Errorf("heavy ball at y %.1f, want it on the bottom", sunk.Position.Y)
+	}
+}
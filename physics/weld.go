@@ -0,0 +1,230 @@
+package physics
+
+import "slices"
+
+// weldBias is the share of a weld's drift the velocity pass steers out
+// per tick, ahead of the position pass. The floor only pushes the bottom
+// of a welded stack back up, and without steering the rest after it the
+// stack sags and slowly tips over.
+const weldBias = 0.2
+
+// Weld holds ball B to ball A as one rigid body: B keeps its place beside
+// A however A turns, and the two turn together. A weld with a strength
+// breaks once it takes a harder impulse than that in a substep to hold;
+// one without never breaks. Welded balls don't collide with each other,
+// and welds don't wrap round a torus.
+type Weld struct {
+	A int
+	B int
+	// AnchorA and AnchorB run from each ball's middle to the point they
+	// are welded at, as if the ball were at angle zero, and Angle is how
+	// far B is turned past A
+	AnchorA Vector
+	AnchorB Vector
+	Angle   float64
+
+	Strength float64
+	// impulse is what the weld pushed with last substep, in x and y and
+	// then turn. Pushing with it again first gives a stack of welds a
+	// head start on bearing its weight, which the passes alone are too
+	// few to build up
+	impulse Vector
+}
+
+// NewWeld welds two balls together as they are now, at the point between
+// them where their edges meet or would if they grew to touch.
+func NewWeld(objects []Body, a, b int, strength float64) Weld {
+	currBall, otherBall := &objects[a], &objects[b]
+	offset := Subtract(otherBall.Position, currBall.Position)
+	point := Add(currBall.Position, ScalarMult(offset, currBall.Size()/(currBall.Size()+otherBall.Size())))
+	return Weld{
+		A:        a,
+		B:        b,
+		AnchorA:  RotateBy(Subtract(point, currBall.Position), -currBall.Angle),
+		AnchorB:  RotateBy(Subtract(point, otherBall.Position), -otherBall.Angle),
+		Angle:    otherBall.Angle - currBall.Angle,
+		Strength: strength,
+	}
+}
+
+// WithWelds adds welds to the world.
+func WithWelds(welds ...Weld) WorldOption {
+	return func(w *World) {
+		w.Welds = append(w.Welds, welds...)
+	}
+}
+
+// arms returns where the welded point is from each ball's middle, as the
+// balls are turned now.
+func (wd *Weld) arms(objects []Body) (Vector, Vector) {
+	return RotateBy(wd.AnchorA, objects[wd.A].Angle), RotateBy(wd.AnchorB, objects[wd.B].Angle)
+}
+
+// solve returns the impulse, in x and y and then turn, that cancels a
+// miss of the welded point's motion or place on B relative to A, in x and
+// y, and of B's turn relative to A's. It is the weld's 3×3 effective mass
+// applied to the miss, zero if neither ball can move.
+func (wd *Weld) solve(objects []Body, rA, rB, miss Vector) Vector {
+	mA, mB := objects[wd.A].inverseMass(), objects[wd.B].inverseMass()
+	iA, iB := objects[wd.A].inverseInertia(), objects[wd.B].inverseInertia()
+	ex := Vector{X: mA + mB + rA.Y*rA.Y*iA + rB.Y*rB.Y*iB, Y: -rA.Y*rA.X*iA - rB.Y*rB.X*iB, Z: -rA.Y*iA - rB.Y*iB}
+	ey := Vector{X: ex.Y, Y: mA + mB + rA.X*rA.X*iA + rB.X*rB.X*iB, Z: rA.X*iA + rB.X*iB}
+	ez := Vector{X: ex.Z, Y: ey.Z, Z: iA + iB}
+
+	// Cramer's rule
+	det := DotProduct(ex, CrossProduct(ey, ez))
+	if det == 0 {
+		return Vector{}
+	}
+	return ScalarMult(Vector{
+		X: DotProduct(miss, CrossProduct(ey, ez)),
+		Y: DotProduct(ex, CrossProduct(miss, ez)),
+		Z: DotProduct(ex, CrossProduct(ey, miss)),
+	}, 1/det)
+}
+
+// push applies impulse, in x and y and then turn, to A at the welded
+// point and its opposite to B. Applied to positions rather than
+// velocities it moves and turns them instead.
+func (wd *Weld) push(objects []Body, rA, rB, impulse Vector, positions bool) {
+	currBall, otherBall := &objects[wd.A], &objects[wd.B]
+	linear := Vector{X: impulse.X, Y: impulse.Y}
+	moveA := ScalarMult(linear, currBall.inverseMass())
+	moveB := ScalarMult(linear, -otherBall.inverseMass())
+	turnA := currBall.inverseInertia() * (CrossProduct(rA, linear).Z + impulse.Z)
+	turnB := -otherBall.inverseInertia() * (CrossProduct(rB, linear).Z + impulse.Z)
+	if positions {
+		currBall.Position = Add(currBall.Position, moveA)
+		otherBall.Position = Add(otherBall.Position, moveB)
+		currBall.turn(turnA)
+		otherBall.turn(turnB)
+		return
+	}
+	currBall.Velocity = Add(currBall.Velocity, moveA)
+	otherBall.Velocity = Add(otherBall.Velocity, moveB)
+	currBall.AngularVelocity += turnA
+	otherBall.AngularVelocity += turnB
+}
+
+// warmStart pushes with last substep's impulse again.
+func (wd *Weld) warmStart(objects []Body) {
+	rA, rB := wd.arms(objects)
+	wd.push(objects, rA, rB, wd.impulse, false)
+}
+
+// broken reports whether the weld has given.
+func (wd *Weld) broken() bool {
+	held := Vector{X: wd.impulse.X, Y: wd.impulse.Y}
+	return wd.Strength > 0 && held.Magnitude() > wd.Strength
+}
+
+// solveVelocity stops the welded point on each ball moving apart and the
+// balls turning at different rates, bar steering out some of the drift.
+func (wd *Weld) solveVelocity(objects []Body) {
+	if wd.broken() {
+		return
+	}
+	rA, rB := wd.arms(objects)
+	currBall, otherBall := &objects[wd.A], &objects[wd.B]
+	pointA := Add(currBall.Velocity, CrossProduct(Vector{Z: currBall.AngularVelocity}, rA))
+	pointB := Add(otherBall.Velocity, CrossProduct(Vector{Z: otherBall.AngularVelocity}, rB))
+	slip := Subtract(pointB, pointA)
+	slip.Z = otherBall.AngularVelocity - currBall.AngularVelocity
+	gap := Subtract(Add(otherBall.Position, rB), Add(currBall.Position, rA))
+	gap.Z = otherBall.Angle - currBall.Angle - wd.Angle
+	slip = Add(slip, ScalarMult(gap, weldBias))
+	impulse := wd.solve(objects, rA, rB, slip)
+	wd.impulse = Add(wd.impulse, impulse)
+
+	// A weld that gives lets go at once rather than slowing the balls
+	// first
+	if wd.broken() {
+		return
+	}
+	wd.push(objects, rA, rB, impulse, false)
+}
+
+// solvePosition moves and turns the balls back to where the weld holds
+// them, removing the drift that turning along a tangent builds up.
+func (wd *Weld) solvePosition(objects []Body) {
+	rA, rB := wd.arms(objects)
+	currBall, otherBall := &objects[wd.A], &objects[wd.B]
+	gap := Subtract(Add(otherBall.Position, rB), Add(currBall.Position, rA))
+	gap.Z = otherBall.Angle - currBall.Angle - wd.Angle
+	wd.push(objects, rA, rB, wd.solve(objects, rA, rB, gap), true)
+}
+
+// joinedPairs returns which pairs of balls are welded together or make a
+// wheel and its chassis, or nil if none are.
+func (w *World) joinedPairs() map[pair]bool {
+	if len(w.Welds) == 0 && len(w.Wheels) == 0 {
+		return nil
+	}
+	joined := make(map[pair]bool, len(w.Welds)+len(w.Wheels))
+	for _, wd := range w.Welds {
+		joined[pair{a: min(wd.A, wd.B), b: max(wd.A, wd.B)}] = true
+	}
+	for _, j := range w.Wheels {
+		joined[pair{a: min(j.A, j.B), b: max(j.A, j.B)}] = true
+	}
+	return joined
+}
+
+// stickyRod sticks balls a and b of objects together just touching until
+// they are pulled apart faster than strength.
+func stickyRod(objects []Body, a, b int, strength float64) DistanceConstraint {
+	return DistanceConstraint{A: a, B: b, Length: objects[a].Size() + objects[b].Size(), Strength: strength}
+}
+
+// adhesion returns how sticky a contact between two balls is.
+func adhesion(currBall, otherBall *Body) float64 {
+	return max(adhesions[currBall.Material], adhesions[otherBall.Material])
+}
+
+// stick welds together every touching pair of sticky balls meeting slower
+// than their adhesion, unless they are welded already. It runs before the
+// contacts are solved, while the closing speeds are still those they met
+// at. Rods don't wrap, so nothing sticks on a torus.
+func (w *World) stick(objects []Body) {
+	if w.Arena.Shape == TorusArena {
+		return
+	}
+	var welded map[pair]bool
+	for _, c := range w.contacts {
+		currBall, otherBall := &objects[c.A], &objects[c.B]
+		sticky := adhesion(currBall, otherBall)
+		if sticky == 0 {
+			continue
+		}
+		speed := -DotProduct(Subtract(currBall.Velocity, otherBall.Velocity), c.Normal)
+		if speed >= sticky {
+			continue
+		}
+
+		if welded == nil {
+			welded = make(map[pair]bool)
+			for _, r := range w.Constraints {
+				if r.Strength > 0 {
+					welded[pair{a: min(r.A, r.B), b: max(r.A, r.B)}] = true
+				}
+			}
+		}
+		key := pair{a: min(c.A, c.B), b: max(c.A, c.B)}
+		if welded[key] {
+			continue
+		}
+		welded[key] = true
+		w.Constraints = append(w.Constraints, stickyRod(objects, c.A, c.B, sticky))
+	}
+}
+
+// breakWelds drops every weld the velocity pass found being pulled apart
+// harder than it stands.
+func (w *World) breakWelds() {
+	w.Constraints = slices.DeleteFunc(w.Constraints, func(c DistanceConstraint) bool {
+		return c.Strength > 0 && c.pull > c.Strength
+	})
+	w.Welds = slices.DeleteFunc(w.Welds, func(wd Weld) bool {
+		return wd.broken()
+	})
+}
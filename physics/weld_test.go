@@ -0,0 +1,112 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestClaySticksUntilPulledHard rolls two balls gently together and checks
+// clay sticks where rubber doesn't, then yanks the clay apart and checks
+// the weld gives.
+func TestClaySticksUntilPulledHard(t *testing.T) {
+	meet := func(m Material) *World {
+		w := NewWorld([]Body{
+			{Position: Vector{X: 280, Y: 240}, Velocity: Vector{X: 0.4}, Material: m},
+			{Position: Vector{X: 360, Y: 240}, Velocity: Vector{X: -0.4}, Material: m},
+		}, Vector{})
+		for tick := 0; tick < 120; tick++ {
+			w.Step()
+		}
+		return w
+	}
+
+	rubbery := meet(Rubber)
+	defer rubbery.Close()
+	if n := len(rubbery.Constraints); n != 0 {
+		t.Errorf("rubber balls made %d welds, want none", n)
+	}
+
+	w := meet(Clay)
+	defer w.Close()
+	if n := len(w.Constraints); n != 1 {
+		t.Fatalf("clay balls made %d welds, want 1", n)
+	}
+	objects := w.Snapshot()
+	if gap := Subtract(objects[0].Position, objects[1].Position); gap.Magnitude() > 2*BallRadius+0.5 {
+		t.Errorf("welded balls %.1f apart, want them touching", gap.Magnitude())
+	}
+
+	w.Strike(1, Vector{X: 2 * adhesions[Clay]}, Vector{})
+	w.Step()
+	if n := len(w.Constraints); n != 0 {
+		t.Errorf("%d welds left after pulling the balls apart, want none", n)
+	}
+}
+
+// TestWeldHoldsShape hits one end of a welded L of three balls side on,
+// and checks the L keeps its shape and tumbles as one body, with the
+// momentum of the hit.
+func TestWeldHoldsShape(t *testing.T) {
+	objects := []Body{
+		{Position: Vector{X: 280, Y: 240}},
+		{Position: Vector{X: 320, Y: 240}},
+		{Position: Vector{X: 320, Y: 280}},
+	}
+	w := NewWorld(objects, Vector{}, WithWelds(NewWeld(objects, 0, 1, 0), NewWeld(objects, 1, 2, 0)))
+	defer w.Close()
+	w.Strike(0, Vector{Y: -2}, Vector{})
+	for range 60 {
+		w.Step()
+	}
+
+	objects = w.Snapshot()
+	arm := Subtract(objects[0].Position, objects[1].Position)
+	leg := Subtract(objects[2].Position, objects[1].Position)
+	if math.Abs(arm.Magnitude()-40) > 0.5 || math.Abs(leg.Magnitude()-40) > 0.5 || math.Abs(DotProduct(arm, leg)) > 40 {
+		t.Errorf("L bent out of shape: arms %v and %v", arm, leg)
+	}
+	spin := objects[1].AngularVelocity
+	if math.Abs(spin) < 1e-3 {
+		t.Fatalf("L not turning after a blow on one end")
+	}
+	for i := range objects {
+		if math.Abs(objects[i].AngularVelocity-spin) > 1e-3 {
+			t.Errorf("ball %d turning at %v, want the L's %v", i, objects[i].AngularVelocity, spin)
+		}
+	}
+	if p := w.Momentum(); math.Abs(p.X) > 1e-6 || math.Abs(p.Y+2) > 1e-6 {
+		t.Errorf("momentum %v, want the blow's %v", p, Vector{Y: -2})
+	}
+	if len(w.LastImpacts()) != 0 {
+		t.Errorf("welded balls collided with each other: %v", w.LastImpacts())
+	}
+}
+
+// TestWeldBreaksWhenHitHard nudges and then slams one of two balls welded
+// with a strength, and checks the weld holds the first time and gives the
+// second.
+func TestWeldBreaksWhenHitHard(t *testing.T) {
+	objects := []Body{
+		{Position: Vector{X: 300, Y: 240}},
+		{Position: Vector{X: 340, Y: 240}},
+	}
+	w := NewWorld(objects, Vector{}, WithWelds(NewWeld(objects, 0, 1, 3)))
+	defer w.Close()
+
+	w.Strike(1, Vector{X: 1, Y: 1}, Vector{})
+	w.Step()
+	if len(w.Welds) != 1 {
+		t.Fatalf("weld broke on a nudge")
+	}
+	w.Strike(1, Vector{X: 8}, Vector{})
+	for range 10 {
+		w.Step()
+	}
+	objects = w.Snapshot()
+	if len(w.Welds) != 0 {
+		t.Fatalf("weld held a slam")
+	}
+	if gap := Subtract(objects[1].Position, objects[0].Position); gap.Magnitude() < 60 {
+		t.Errorf("balls %.1f apart after the weld broke, want them flying apart", gap.Magnitude())
+	}
+}
@@ -0,0 +1,177 @@
+package physics
+
+import "math"
+
+// WheelJoint holds wheel ball B to chassis ball A as a car's suspension
+// does: B may only travel along a line through an anchor on A, which
+// turns with A, and a damped spring holds it towards the anchor. The wheel
+// rolls freely about its middle unless the motor drives it, and it only
+// grips the ground with a Grip of its own. A wheel and its chassis don't
+// collide with each other.
+type WheelJoint struct {
+	A int
+	B int
+	// Anchor is where on A the wheel's middle rests, and Axis the unit
+	// direction the suspension lets it travel, both as if A were at angle
+	// zero
+	Anchor Vector
+	Axis   Vector
+	// Stiffness is the spring's force per unit the wheel is off its
+	// anchor, and Damping its force per unit per tick it is moving off
+	// it
+	Stiffness float64
+	Damping   float64
+	// MotorSpeed is how fast the motor tries to turn the wheel past A, in
+	// radians per tick clockwise, with a torque of at most MotorTorque;
+	// zero torque leaves the wheel to roll freely. Both may be changed
+	// between steps, as a driver would.
+	MotorSpeed  float64
+	MotorTorque float64
+
+	// motorLimit is the most the motor can push with in the current
+	// substep, and motorImpulse how much it has pushed with so far
+	motorLimit   float64
+	motorImpulse float64
+}
+
+// NewWheelJoint hangs wheel b off chassis a where it is now, travelling
+// along axis with a spring of the given stiffness and damping.
+func NewWheelJoint(objects []Body, a, b int, axis Vector, stiffness, damping float64) WheelJoint {
+	chassis := &objects[a]
+	return WheelJoint{
+		A:         a,
+		B:         b,
+		Anchor:    RotateBy(Subtract(objects[b].Position, chassis.Position), -chassis.Angle),
+		Axis:      RotateBy(UnitVector(axis), -chassis.Angle),
+		Stiffness: stiffness,
+		Damping:   damping,
+	}
+}
+
+// WithWheelJoints adds wheel joints to the world.
+func WithWheelJoints(joints ...WheelJoint) WorldOption {
+	return func(w *World) {
+		w.Wheels = append(w.Wheels, joints...)
+	}
+}
+
+// frame returns the anchor's offset from A's middle, the wheel's offset
+// from the anchor, and the suspension's axis and the line across it, all
+// as A is turned now.
+func (j *WheelJoint) frame(objects []Body) (rA, d, axis, across Vector) {
+	chassis, wheel := &objects[j.A], &objects[j.B]
+	rA = RotateBy(j.Anchor, chassis.Angle)
+	d = Subtract(Subtract(wheel.Position, chassis.Position), rA)
+	axis = RotateBy(j.Axis, chassis.Angle)
+	return rA, d, axis, Vector{X: -axis.Y, Y: axis.X}
+}
+
+// pushAlong applies impulse along direction to the wheel, and its opposite
+// to the chassis at the wheel's middle, turning the chassis by arm times
+// it. Applied to positions rather than velocities it moves and turns them
+// instead.
+func (j *WheelJoint) pushAlong(objects []Body, direction Vector, arm, impulse float64, positions bool) {
+	chassis, wheel := &objects[j.A], &objects[j.B]
+	moveA := ScalarMult(direction, -impulse*chassis.inverseMass())
+	moveB := ScalarMult(direction, impulse*wheel.inverseMass())
+	turnA := -impulse * arm * chassis.inverseInertia()
+	if positions {
+		chassis.Position = Add(chassis.Position, moveA)
+		wheel.Position = Add(wheel.Position, moveB)
+		chassis.turn(turnA)
+		return
+	}
+	chassis.Velocity = Add(chassis.Velocity, moveA)
+	wheel.Velocity = Add(wheel.Velocity, moveB)
+	chassis.AngularVelocity += turnA
+}
+
+// along returns how hard it is to push the wheel and chassis apart along
+// direction, given the arm that push turns the chassis by.
+func (j *WheelJoint) along(objects []Body, arm float64) float64 {
+	chassis, wheel := &objects[j.A], &objects[j.B]
+	return chassis.inverseMass() + wheel.inverseMass() + chassis.inverseInertia()*arm*arm
+}
+
+// sliding returns how fast the wheel is moving off the anchor along
+// direction, given the arm the chassis turns it by.
+func (j *WheelJoint) sliding(objects []Body, direction Vector, arm float64) float64 {
+	chassis, wheel := &objects[j.A], &objects[j.B]
+	return DotProduct(direction, Subtract(wheel.Velocity, chassis.Velocity)) - arm*chassis.AngularVelocity
+}
+
+// spring pushes the wheel back towards its anchor along the axis for dt
+// ticks, and readies the motor's torque for the substep.
+func (j *WheelJoint) spring(objects []Body, dt float64) {
+	j.motorLimit = j.MotorTorque * dt
+	j.motorImpulse = 0
+	if j.along(objects, 0) == 0 {
+		return
+	}
+	rA, d, axis, _ := j.frame(objects)
+	arm := CrossProduct(Add(d, rA), axis).Z
+	force := -j.Stiffness*DotProduct(d, axis) - j.Damping*j.sliding(objects, axis, arm)
+	j.pushAlong(objects, axis, arm, force*dt, false)
+}
+
+// solveVelocity turns the wheel towards the motor's speed within its
+// torque, then stops the wheel moving off the axis.
+func (j *WheelJoint) solveVelocity(objects []Body) {
+	chassis, wheel := &objects[j.A], &objects[j.B]
+	if j.motorLimit > 0 {
+		if turning := chassis.inverseInertia() + wheel.inverseInertia(); turning > 0 {
+			impulse := (j.MotorSpeed - (wheel.AngularVelocity - chassis.AngularVelocity)) / turning
+			total := math.Max(-j.motorLimit, math.Min(j.motorLimit, j.motorImpulse+impulse))
+			impulse, j.motorImpulse = total-j.motorImpulse, total
+			chassis.AngularVelocity -= impulse * chassis.inverseInertia()
+			wheel.AngularVelocity += impulse * wheel.inverseInertia()
+		}
+	}
+
+	rA, d, _, across := j.frame(objects)
+	arm := CrossProduct(Add(d, rA), across).Z
+	if stiffness := j.along(objects, arm); stiffness > 0 {
+		j.pushAlong(objects, across, arm, -j.sliding(objects, across, arm)/stiffness, false)
+	}
+}
+
+// solvePosition moves the wheel and chassis back onto the axis, removing
+// the drift that turning along a tangent builds up.
+func (j *WheelJoint) solvePosition(objects []Body) {
+	rA, d, _, across := j.frame(objects)
+	arm := CrossProduct(Add(d, rA), across).Z
+	if stiffness := j.along(objects, arm); stiffness > 0 {
+		j.pushAlong(objects, across, arm, -DotProduct(d, across)/stiffness, true)
+	}
+}
+
+// suspend runs every spring, and every wheel joint's, for dt ticks.
+func (w *World) suspend(objects []Body, dt float64) {
+	for i := range w.Constraints {
+		w.Constraints[i].spring(objects, dt)
+	}
+	for i := range w.Wheels {
+		w.Wheels[i].spring(objects, dt)
+	}
+}
+
+// roll grips the ground with a ball's Grip where it touches along normal,
+// once a bounce has changed its speed along normal by pushed. Friction at
+// the point of contact, at most Grip times the push, turns the ball's
+// slip along the ground into spin and its spin into speed, as a tyre does
+// on a road.
+func roll(currBall *Body, normal Vector, pushed float64) {
+	if currBall.Grip == 0 || currBall.Frozen {
+		return
+	}
+	r := ScalarMult(normal, -currBall.Size())
+	tangent := Vector{X: -normal.Y, Y: normal.X}
+	slip := DotProduct(Add(currBall.Velocity, CrossProduct(Vector{Z: currBall.AngularVelocity}, r)), tangent)
+	arm := CrossProduct(r, tangent).Z
+	invMass, invInertia := currBall.inverseMass(), currBall.inverseInertia()
+
+	limit := currBall.Grip * pushed / invMass
+	impulse := math.Max(-limit, math.Min(limit, -slip/(invMass+invInertia*arm*arm)))
+	currBall.Velocity = Add(currBall.Velocity, ScalarMult(tangent, impulse*invMass))
+	currBall.AngularVelocity += impulse * arm * invInertia
+}
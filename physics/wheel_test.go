@@ -0,0 +1,78 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// TestGripRollsASpinningBall sets a gripping ball spinning on the floor
+// and checks the floor drives it along until it rolls without slipping,
+// where a ball without grip just spins in place.
+func TestGripRollsASpinningBall(t *testing.T) {
+	spin := func(grip float64) Body {
+		w := NewWorld([]Body{{
+			Position:        Vector{X: 320, Y: ScreenHeight - BallRadius},
+			AngularVelocity: 0.1,
+			Grip:            grip,
+		}}, Vector{Y: .3}, WithWallRestitution(0))
+		defer w.Close()
+		for range 60 {
+			w.Step()
+		}
+		return w.Snapshot()[0]
+	}
+
+	if slid := spin(0); slid.Velocity.X != 0 || slid.AngularVelocity != 0.1 {
+		t.Errorf("ball without grip moving at %v and turning at %v, want it spinning in place", slid.Velocity, slid.AngularVelocity)
+	}
+	rolled := spin(1)
+	if rolled.Velocity.X <= 0 {
+		t.Fatalf("gripping ball moving at %v, want it driven right", rolled.Velocity)
+	}
+	if slip := rolled.Velocity.X - rolled.AngularVelocity*BallRadius; math.Abs(slip) > 1e-3 {
+		t.Errorf("ball moving at %.3f turning at %.4f, slipping at %.4f, want it rolling", rolled.Velocity.X, rolled.AngularVelocity, slip)
+	}
+}
+
+// TestWheelJointDrivesACar drives a chassis on two sprung wheels along the
+// floor and checks it goes forward, each wheel stays on its axis below
+// the chassis, and the springs give under the chassis's weight.
+func TestWheelJointDrivesACar(t *testing.T) {
+	const ride = 40
+	floor := float64(ScreenHeight - BallRadius)
+	objects := []Body{
+		{Position: Vector{X: 100, Y: floor - ride}, Mass: 2},
+		{Position: Vector{X: 70, Y: floor}, Grip: 1},
+		{Position: Vector{X: 130, Y: floor}, Grip: 1},
+	}
+	wheels := []WheelJoint{
+		NewWheelJoint(objects, 0, 1, Vector{Y: 1}, 0.2, 0.2),
+		NewWheelJoint(objects, 0, 2, Vector{Y: 1}, 0.2, 0.2),
+	}
+	for k := range wheels {
+		wheels[k].MotorSpeed, wheels[k].MotorTorque = 0.15, 1
+	}
+	w := NewWorld(objects, Vector{Y: .3}, WithWheelJoints(wheels...), WithWallRestitution(0))
+	defer w.Close()
+	for range 120 {
+		w.Step()
+	}
+
+	objects = w.Snapshot()
+	if objects[0].Position.X < 150 {
+		t.Errorf("car got to x=%.1f, want it driven well along", objects[0].Position.X)
+	}
+	squeezed := 0.0
+	for k, j := range w.Wheels {
+		_, d, axis, across := j.frame(objects)
+		if off := DotProduct(d, across); math.Abs(off) > 0.5 {
+			t.Errorf("wheel %d %.2f off its axis", k, off)
+		}
+		squeezed -= DotProduct(d, axis)
+	}
+
+	// Between them the springs hold up the chassis's weight
+	if want := 2 * 0.3 / 0.2; math.Abs(squeezed-want) > want/2 {
+		t.Errorf("springs squeezed by %.2f between them, want about %.2f", squeezed, want)
+	}
+}
@@ -0,0 +1,100 @@
+package physics
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelThreshold is the smallest phase worth splitting across workers;
+// below it the hand-off costs more than the work.
+const parallelThreshold = 256
+
+// workerPool runs the chunks of every parallel phase on one fixed set of
+// goroutines, so stepping never starts goroutines of its own.
+type workerPool struct {
+	size  int
+	tasks chan func()
+}
+
+// newWorkerPool starts size workers, or GOMAXPROCS workers if size < 1.
+func newWorkerPool(size int) *workerPool {
+	if size < 1 {
+		size = runtime.GOMAXPROCS(0)
+	}
+
+	p := &workerPool{size: size, tasks: make(chan func())}
+	for i := 1; i < size; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *workerPool) work() {
+	for task := range p.tasks {
+		task()
+	}
+}
+
+// parallelFor splits [0, n) into at most one contiguous chunk per worker
+// and blocks until fn has run on all of them. The calling goroutine runs
+// the first chunk itself.
+func (p *workerPool) parallelFor(n int, fn func(chunk, start, end int)) {
+	if p.size == 1 || n < parallelThreshold {
+		fn(0, 0, n)
+		return
+	}
+
+	size := (n + p.size - 1) / p.size
+	var wg sync.WaitGroup
+	for chunk := 1; chunk*size < n; chunk++ {
+		start := chunk * size
+		end := min(start+size, n)
+		wg.Add(1)
+		p.tasks <- func() {
+			defer wg.Done()
+			fn(chunk, start, end)
+		}
+	}
+	fn(0, 0, min(size, n))
+	wg.Wait()
+}
+
+// chunks returns how many chunks parallelFor will use for n items.
+func (p *workerPool) chunks(n int) int {
+	if p.size == 1 || n < parallelThreshold {
+		return 1
+	}
+	size := (n + p.size - 1) / p.size
+	return (n + size - 1) / size
+}
+
+func (p *workerPool) close() {
+	close(p.tasks)
+}
+
+// reductionBlock is the fixed block size used by sum. It does not depend on
+// the number of workers, so the additions happen in the same order however
+// the work is split.
+const reductionBlock = 128
+
+// sum adds fn(i) for i in [0, n). Each block of reductionBlock items is
+// summed in index order and the block totals are then added in block
+// order, so the result is bit-identical for any pool size.
+func (p *workerPool) sum(n int, fn func(i int) float64) float64 {
+	blocks := (n + reductionBlock - 1) / reductionBlock
+	partials := make([]float64, blocks)
+
+	p.parallelFor(blocks, func(_, start, end int) {
+		for block := start; block < end; block++ {
+			for i := block * reductionBlock; i < min((block+1)*reductionBlock, n); i++ {
+				partials[block] += fn(i)
+			}
+		}
+	})
+
+	total := 0.0
+	for _, partial := range partials {
+		total += partial
+	}
+	return total
+}
@@ -0,0 +1,719 @@
+package physics
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// worldState holds one copy of every body in the world. The world keeps two
+// of these and flips between them each step.
+type worldState struct {
+	objects []Body
+	timings PhaseTimings
+	// penetration is the deepest two bodies overlapped during the step
+	penetration float64
+}
+
+// PhaseTimings records how long each phase of a step took.
+type PhaseTimings struct {
+	Integration time.Duration
+	Broadphase  time.Duration
+	Narrowphase time.Duration
+	Solver      time.Duration
+}
+
+// World owns the simulation. Bodies are double-buffered: step writes into
+// the back buffer and then atomically publishes it, so a reader on another
+// goroutine always sees a fully computed step.
+type World struct {
+	buffers [2]worldState
+	front   atomic.Pointer[worldState]
+	gravity Vector
+	Steps   uint64
+	// GravityZones change gravity for the bodies inside them
+	GravityZones []GravityZone
+	// GravitySources are layered over the world's gravity and its zones
+	GravitySources []GravitySource
+	// Integrator moves the bodies through each substep, or symplectic
+	// Euler if nil
+	Integrator Integrator
+	// drifting holds the bodies where they started drifting, for their pull
+	drifting []Body
+
+	lod            lodSettings
+	interestPoints []Vector
+	far            []bool
+
+	Arena           Arena
+	Cloth           ClothSettings
+	atmosphere      atmosphere
+	wallRestitution float64
+	attraction      float64
+	magnetism       float64
+	Magnets         []StaticMagnet
+	flocking        FlockSettings
+	Water           *Water
+	Bands           []*Band
+	bandQuery       []int
+	Ropes           []*Rope
+	Cloths          []*Cloth
+	pointQuery      []int
+	// flockVelocities holds every body's velocity as flocking found it
+	flockVelocities []Vector
+	ignoreContacts  bool
+	// mergeSpeed is the closing speed under which colliding balls merge,
+	// or no merging if zero
+	mergeSpeed float64
+	mixColors  bool
+
+	// mu guards the broadphase so it can be queried while a step runs
+	mu         sync.Mutex
+	broadphase *broadphase
+	pairs      []pair
+	chunkPairs [][]pair
+	contacts   []Contact
+	// chunkContacts holds each chunk's contacts as the narrowphase finds
+	// them, and batches splits them up for solving in parallel
+	chunkContacts [][]Contact
+	batches       contactBatches
+	solved        []solvedContact
+	preSolve      PreSolve
+	correction    positionCorrection
+	// deepest is the deepest overlap found so far this step
+	deepest float64
+
+	impacts      []Impact
+	chunkImpacts [][]Impact
+	// collisions counts each of the last second of steps' impacts, by
+	// step number
+	collisions     [TicksPerSecond]int
+	impactHandlers []impactHandler
+	// collisionHandlers hear every impact, on any body
+	collisionHandlers []CollisionHandler
+
+	Constraints    []DistanceConstraint
+	Welds          []Weld
+	Wheels         []WheelJoint
+	StaticCircles  []StaticCircle
+	StaticSegments []StaticSegment
+	StaticBoxes    []StaticBox
+	StaticPolygons []StaticPolygon
+	StaticChains   []StaticChain
+	Heightfields   []Heightfield
+
+	workers       int
+	pool          *workerPool
+	deterministic bool
+	substeps      int
+	// BodyLimit is the most bodies the world holds, or no limit if zero
+	BodyLimit int
+	// speedLimit is the fastest a body may move, or no limit if zero
+	speedLimit float64
+	guard      guard
+	limits     bounds
+}
+
+// WorldOption configures optional World behaviour in NewWorld.
+type WorldOption func(*World)
+
+func NewWorld(objects []Body, gravity Vector, options ...WorldOption) *World {
+	w := &World{
+		gravity:         gravity,
+		wallRestitution: 1,
+		correction:      defaultCorrection,
+		broadphase:      newBroadphase(2 * BallRadius),
+	}
+	for _, option := range options {
+		option(w)
+	}
+	w.pool = newWorkerPool(w.workers)
+	w.buffers[0].objects = append([]Body(nil), objects...)
+	w.buffers[1].objects = make([]Body, 0, len(objects))
+	w.front.Store(&w.buffers[0])
+	w.refile()
+	return w
+}
+
+// WithWorkers sets how many goroutines the parallel phases of a step share.
+// Zero or less means GOMAXPROCS.
+func WithWorkers(n int) WorldOption {
+	return func(w *World) {
+		w.workers = n
+	}
+}
+
+// WithCellSize sets how wide the broadphase grid's cells are, which are
+// otherwise as wide as the largest ball. Only balls in neighbouring cells
+// are tested against each other, so cells as wide as the balls test the
+// fewest pairs; wider ones refile fast balls less often as they move, at
+// the cost of testing more. Cells narrower than the widest ball are
+// widened to fit it, and zero or less leaves them as they are.
+func WithCellSize(size float64) WorldOption {
+	return func(w *World) {
+		if size > 0 {
+			w.broadphase.resize(size)
+		}
+	}
+}
+
+// WithSubsteps splits every step into n smaller ones. Chains of rods lose
+// energy in proportion to the step size, so substeps keep them swinging
+// far longer. Zero or less means a single substep.
+func WithSubsteps(n int) WorldOption {
+	return func(w *World) {
+		w.substeps = n
+	}
+}
+
+// WithWallRestitution sets how much of a ball's speed into a wall survives
+// the bounce, from 0 for a dead stop to the default of 1 for a perfect
+// rebound.
+func WithWallRestitution(e float64) WorldOption {
+	return func(w *World) {
+		w.wallRestitution = e
+	}
+}
+
+// WithSpeedLimit caps how fast, in world units per tick, any body may move, so
+// a runaway force field can't fling bodies far enough in one step to blow
+// the simulation up.
+func WithSpeedLimit(speed float64) WorldOption {
+	return func(w *World) {
+		w.speedLimit = speed
+	}
+}
+
+// WithDeterminism orders candidate pairs by body index each step, so the
+// result depends only on body state and never on how the broadphase was
+// filled or how many workers ran. Serial, parallel and restored worlds
+// then step bit-identically.
+func WithDeterminism() WorldOption {
+	return func(w *World) {
+		w.deterministic = true
+	}
+}
+
+// WithBodyLimit caps the world at n bodies. Spawning into a full world
+// first removes the oldest body not held by a rod, so an emitter can run
+// forever.
+func WithBodyLimit(n int) WorldOption {
+	return func(w *World) {
+		w.BodyLimit = n
+	}
+}
+
+// Close stops the world's worker goroutines. The world must not be stepped
+// afterwards.
+func (w *World) Close() {
+	w.pool.close()
+}
+
+// Snapshot returns the bodies as of the last completed step. The slice is
+// read-only and stays valid until the step after the next one begins, so a
+// renderer must be done with it within one step.
+func (w *World) Snapshot() []Body {
+	return w.front.Load().objects
+}
+
+// Spawn adds a body to the world and returns its index. It must not be
+// called while a step runs.
+func (w *World) Spawn(b Body) int {
+	if w.BodyLimit > 0 && len(w.Snapshot()) >= w.BodyLimit {
+		w.removeOldest()
+	}
+	front := w.front.Load()
+	front.objects = append(front.objects, b)
+	w.refile()
+	return len(front.objects) - 1
+}
+
+// Remove takes body i out of the world. Later bodies move down one index,
+// so a loop removing several should walk from the last down; constraints,
+// welds, wheel joints, impact handlers and the last step's impacts are
+// renumbered to match and any on body i are dropped. It must not be called
+// while a step runs.
+func (w *World) Remove(i int) {
+	front := w.front.Load()
+	if i >= len(front.objects) {
+		return
+	}
+	front.objects = w.remove(front.objects, i)
+	w.refile()
+}
+
+// remove deletes body i from objects and renumbers everything that refers
+// to a body to match, the last step's impacts included, returning what is
+// left of objects.
+func (w *World) remove(objects []Body, i int) []Body {
+	objects = slices.Delete(objects, i, i+1)
+	kept := w.Constraints[:0]
+	for _, c := range w.Constraints {
+		if c.A == i || c.B == i {
+			continue
+		}
+		if c.A > i {
+			c.A--
+		}
+		if c.B > i {
+			c.B--
+		}
+		kept = append(kept, c)
+	}
+	w.Constraints = kept
+
+	welds := w.Welds[:0]
+	for _, wd := range w.Welds {
+		if wd.A == i || wd.B == i {
+			continue
+		}
+		if wd.A > i {
+			wd.A--
+		}
+		if wd.B > i {
+			wd.B--
+		}
+		welds = append(welds, wd)
+	}
+	w.Welds = welds
+
+	wheels := w.Wheels[:0]
+	for _, j := range w.Wheels {
+		if j.A == i || j.B == i {
+			continue
+		}
+		if j.A > i {
+			j.A--
+		}
+		if j.B > i {
+			j.B--
+		}
+		wheels = append(wheels, j)
+	}
+	w.Wheels = wheels
+
+	handlers := w.impactHandlers[:0]
+	for _, h := range w.impactHandlers {
+		if h.body == i {
+			continue
+		}
+		if h.body > i {
+			h.body--
+		}
+		handlers = append(handlers, h)
+	}
+	w.impactHandlers = handlers
+
+	impacts := w.impacts[:0]
+	for _, hit := range w.impacts {
+		if hit.A == i || hit.B == i {
+			continue
+		}
+		if hit.A > i {
+			hit.A--
+		}
+		if hit.B > i {
+			hit.B--
+		}
+		impacts = append(impacts, hit)
+	}
+	w.impacts = impacts
+	return objects
+}
+
+// refile files the published bodies in the broadphase. Steps do so as
+// they go, but anything that adds, removes or moves bodies between steps
+// must too, or views querying it for what to draw or pick miss them.
+func (w *World) refile() {
+	w.mu.Lock()
+	w.broadphase.update(w.front.Load().objects)
+	w.mu.Unlock()
+}
+
+// removeOldest removes the body that has been in the world longest, not
+// counting bodies held by rods or joints. Bodies keep their order as others
+// come and go, so that is the lowest index free of them.
+func (w *World) removeOldest() {
+	held := w.held()
+	for i := range w.Snapshot() {
+		if !held[i] {
+			w.Remove(i)
+			return
+		}
+	}
+}
+
+// held returns which bodies are on the end of a rod, weld or wheel joint.
+func (w *World) held() map[int]bool {
+	held := make(map[int]bool, 2*(len(w.Constraints)+len(w.Welds)+len(w.Wheels)))
+	for _, c := range w.Constraints {
+		held[c.A] = true
+		held[c.B] = true
+	}
+	for _, wd := range w.Welds {
+		held[wd.A] = true
+		held[wd.B] = true
+	}
+	for _, j := range w.Wheels {
+		held[j.A] = true
+		held[j.B] = true
+	}
+	return held
+}
+
+// LastTimings returns the phase timings of the last completed step.
+func (w *World) LastTimings() PhaseTimings {
+	return w.front.Load().timings
+}
+
+// LastPenetration returns the deepest two bodies overlapped during the
+// last completed step, in world units.
+func (w *World) LastPenetration() float64 {
+	return w.front.Load().penetration
+}
+
+// QueryRect appends the index of every body that may overlap the rectangle
+// from min to max. Results come from the broadphase, so they can include a
+// few bodies just outside the rectangle.
+func (w *World) QueryRect(min, max Vector, dst []int) []int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	reach := w.broadphase.reach()
+	pad := Vector{X: reach, Y: reach}
+	return w.broadphase.query(Subtract(min, pad), Add(max, pad), dst)
+}
+
+// BoundingBoxes appends the fat bounding box the broadphase keeps for
+// each body, in body order, as of the last step.
+func (w *World) BoundingBoxes(dst []AABB) []AABB {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append(dst, w.broadphase.boxes...)
+}
+
+// Overlapping appends the index of every body that a ball of the given
+// radius centred at position would overlap.
+func (w *World) Overlapping(position Vector, radius float64, dst []int) []int {
+	reach := Vector{X: radius, Y: radius}
+	start := len(dst)
+	dst = w.QueryRect(Subtract(position, reach), Add(position, reach), dst)
+
+	objects := w.Snapshot()
+	kept := slices.DeleteFunc(dst[start:], func(i int) bool {
+		if i >= len(objects) {
+			return true
+		}
+		offset := Subtract(objects[i].Position, position)
+		return offset.Magnitude() >= radius+objects[i].Size()
+	})
+	return dst[:start+len(kept)]
+}
+
+// back returns the buffer that is not currently published.
+func (w *World) back() *worldState {
+	if w.front.Load() == &w.buffers[0] {
+		return &w.buffers[1]
+	}
+	return &w.buffers[0]
+}
+
+// Step advances the simulation by one tick and publishes the result.
+func (w *World) Step() {
+	if w.Err() != nil {
+		return
+	}
+	front := w.front.Load()
+	back := w.back()
+	back.objects = append(back.objects[:0], front.objects...)
+	back.timings = PhaseTimings{}
+	objects := back.objects
+	w.impacts = w.impacts[:0]
+	w.deepest = 0
+
+	w.classifyLOD(objects)
+	substeps := max(w.substeps, 1)
+	for i := 0; i < substeps; i++ {
+		w.substep(objects, 1/float64(substeps), &back.timings)
+	}
+
+	// A stopped world keeps its last good step published and steps no more.
+	// Bodies are changed and removed before the step is published, so a
+	// reader never sees them shift under it
+	filed := len(objects)
+	if w.guard.enabled && !w.inspect(front.objects, objects) {
+		return
+	}
+	objects, ok := w.bound(objects)
+	if !ok {
+		return
+	}
+	w.Steps++
+	back.penetration = w.deepest
+	w.collisions[w.Steps%TicksPerSecond] = len(w.impacts)
+	w.mix(objects)
+	objects = w.merge(objects)
+	objects = w.removeEscaped(objects)
+	objects = w.removeExpired(objects)
+	if back.objects = objects; len(objects) != filed {
+		w.mu.Lock()
+		w.broadphase.update(objects)
+		w.mu.Unlock()
+	}
+	w.front.Store(back)
+	w.reportImpacts()
+}
+
+// substep advances the bodies by dt ticks, adding the time spent in each
+// phase to timings.
+func (w *World) substep(objects []Body, dt float64, timings *PhaseTimings) {
+	// Kick every velocity with the integrator, then let constraints cancel
+	// any velocity that would stretch them before the integrator drifts
+	// the positions. Springs go first, so their damping reads the velocity
+	// at the start of the substep rather than gravity's pull and a hanging
+	// weight rests where its weight balances the spring
+	started := time.Now()
+	w.suspend(objects, dt)
+	w.kick(objects, dt)
+	w.magnetize(objects, dt)
+	w.flock(objects, dt)
+	w.soak(objects, dt)
+	timings.Integration += lap(&started)
+	w.solveConstraintVelocities(objects)
+	w.breakWelds()
+	timings.Solver += lap(&started)
+	w.drift(objects, dt)
+	timings.Integration += lap(&started)
+
+	// Find candidate pairs, only refiling bodies that changed cell
+	w.mu.Lock()
+	w.broadphase.update(objects)
+	w.findPairs()
+	w.mu.Unlock()
+	timings.Broadphase += lap(&started)
+
+	w.findContacts(objects)
+	timings.Narrowphase += lap(&started)
+
+	w.preSolveContacts(objects)
+	w.stick(objects)
+	w.solveContacts(objects)
+	w.correctPositions(objects)
+	w.solveConstraintPositions(objects)
+	w.collideStatic(objects)
+	w.stretchBands(objects, dt)
+	w.swingRopes(objects, dt)
+	w.swingCloths(objects, dt)
+	for i := range objects {
+		w.constrainToBounds(objects, i)
+	}
+	timings.Solver += lap(&started)
+}
+
+// lap returns the time since *started and restarts the clock.
+func lap(started *time.Time) time.Duration {
+	now := time.Now()
+	elapsed := now.Sub(*started)
+	*started = now
+	return elapsed
+}
+
+// integrate moves every body due an update this step with update, in
+// parallel, under the field it feels among pulling.
+func (w *World) integrate(objects, pulling []Body, dt float64, update func(currBall *Body, a Acceleration, dt float64)) {
+	w.pool.parallelFor(len(objects), func(_, start, end int) {
+		field := bodyField{w: w, pulling: pulling}
+		at := field.at
+		for i := start; i < end; i++ {
+			if scale := w.lodTimestep(i); scale > 0 {
+				field.i = i
+				update(&objects[i], at, dt*scale)
+			}
+		}
+	})
+}
+
+// kick runs the first half of the integrator on every ball for dt ticks,
+// then slows it by any cloth friction or air drag.
+func (w *World) kick(objects []Body, dt float64) {
+	integrator, floor := w.integrator(), w.Arena.Extent().Max.Y
+	w.integrate(objects, objects, dt, func(currBall *Body, a Acceleration, dt float64) {
+		if currBall.Frozen {
+			return
+		}
+		integrator.Kick(currBall, a, dt)
+		if w.Cloth.Enabled() {
+			w.Cloth.apply(currBall, dt)
+		}
+		if w.atmosphere.enabled() {
+			w.atmosphere.apply(currBall, floor, dt)
+		}
+	})
+}
+
+// drift runs the second half of the integrator on every ball for dt ticks,
+// once it has been slowed to its speed limit, and turns it by its spin.
+// Frozen balls stay put, losing any velocity the forces gave them.
+func (w *World) drift(objects []Body, dt float64) {
+	// The bodies pull from where they started, so moving one first
+	// doesn't change the pull on another
+	pulling := objects
+	if w.attraction != 0 {
+		w.drifting = append(w.drifting[:0], objects...)
+		pulling = w.drifting
+	}
+	integrator := w.integrator()
+	w.integrate(objects, pulling, dt, func(currBall *Body, a Acceleration, dt float64) {
+		if currBall.Frozen {
+			currBall.Velocity = Vector{}
+			return
+		}
+		w.limitSpeed(currBall)
+		integrator.Drift(currBall, a, dt)
+		if currBall.AngularVelocity != 0 {
+			currBall.turn(currBall.AngularVelocity * dt)
+		}
+	})
+}
+
+// limitSpeed slows a ball moving faster than its own speed limit or the
+// world's, whichever is lower, keeping its direction.
+func (w *World) limitSpeed(currBall *Body) {
+	limit := w.speedLimit
+	if currBall.SpeedLimit > 0 && (limit == 0 || currBall.SpeedLimit < limit) {
+		limit = currBall.SpeedLimit
+	}
+	if limit == 0 {
+		return
+	}
+	if speed := currBall.Velocity.Magnitude(); speed > limit {
+		currBall.Velocity = ScalarMult(currBall.Velocity, limit/speed)
+	}
+}
+
+// findPairs collects the broadphase pairs in parallel, one list per chunk,
+// then joins the lists in chunk order.
+func (w *World) findPairs() {
+	n := len(w.broadphase.bodyCell)
+	chunks := w.pool.chunks(n)
+	for len(w.chunkPairs) < chunks {
+		w.chunkPairs = append(w.chunkPairs, nil)
+	}
+
+	w.pool.parallelFor(n, func(chunk, start, end int) {
+		w.chunkPairs[chunk] = w.broadphase.pairs(start, end, w.chunkPairs[chunk][:0])
+	})
+
+	w.pairs = w.pairs[:0]
+	for _, chunkPairs := range w.chunkPairs[:chunks] {
+		w.pairs = append(w.pairs, chunkPairs...)
+	}
+
+	if w.deterministic {
+		slices.SortFunc(w.pairs, func(p, q pair) int {
+			if p.a != q.a {
+				return cmp.Compare(p.a, q.a)
+			}
+			return cmp.Compare(p.b, q.b)
+		})
+	}
+}
+
+// kineticEnergy returns the total kinetic energy of the last completed
+// step.
+func (w *World) kineticEnergy() float64 {
+	objects := w.Snapshot()
+	return w.pool.sum(len(objects), func(i int) float64 {
+		return 0.5 * objects[i].mass() * objects[i].Velocity.MagnitudeSquared()
+	})
+}
+
+// Momentum returns the total linear momentum of the last completed step.
+func (w *World) Momentum() Vector {
+	objects := w.Snapshot()
+	return Vector{
+		X: w.pool.sum(len(objects), func(i int) float64 { return objects[i].mass() * objects[i].Velocity.X }),
+		Y: w.pool.sum(len(objects), func(i int) float64 { return objects[i].mass() * objects[i].Velocity.Y }),
+	}
+}
+
+// constrainToBounds keeps ball i inside the arena, by default a box,
+// bouncing it off the edges.
+func (w *World) constrainToBounds(objects []Body, i int) {
+	switch w.Arena.Shape {
+	case CircleArena:
+		w.constrainToCircle(objects, i)
+		return
+	case PolygonArena:
+		w.constrainToPolygon(objects, i)
+		return
+	case TorusArena:
+		w.wrap(objects, i)
+		return
+	case OpenArena:
+		return
+	}
+	currBall := &objects[i]
+	radius, size := currBall.Size(), w.Arena.size()
+
+	// If we are out of bounds left side
+	if currBall.Position.X-radius < 0 {
+		currBall.Position.X = radius
+		w.bounce(objects, i, Vector{X: 1})
+
+		// If we are out bounds right side
+	} else if currBall.Position.X+radius > size.X {
+		currBall.Position.X = size.X - radius
+		w.bounce(objects, i, Vector{X: -1})
+	}
+
+	// If we are out bounds Bottom Side
+	if currBall.Position.Y-radius < 0 {
+		currBall.Position.Y = radius
+		w.bounce(objects, i, Vector{Y: 1})
+
+		// If We are out of bounds Top Side
+	} else if currBall.Position.Y+radius > size.Y {
+		currBall.Position.Y = size.Y - radius
+		w.bounce(objects, i, Vector{Y: -1})
+	}
+}
+
+// cushionGrip is the share of a ball's side spin a wall turns into
+// sideways speed when it bounces.
+const cushionGrip = 0.2
+
+// restitution returns how much of a ball's speed into a wall or obstacle
+// survives a bounce at speed: the wall's restitution times the ball's.
+func (w *World) restitution(currBall *Body, speed float64) float64 {
+	return w.wallRestitution * currBall.bounciness(speed)
+}
+
+// bounce reverses ball i's velocity across a wall with the given inward
+// normal, keeping its restitution of it. Side spin grips the wall and
+// kicks the ball along it, which is how english changes a rebound angle.
+func (w *World) bounce(objects []Body, i int, normal Vector) {
+	currBall := &objects[i]
+	speed := -DotProduct(currBall.Velocity, normal)
+	if speed > 0 {
+		touch := Subtract(currBall.Position, ScalarMult(normal, currBall.Size()))
+		w.impacts = append(w.impacts, w.obstacleImpact(objects, i, touch, speed))
+	}
+
+	e := w.restitution(currBall, speed)
+	if normal.X != 0 {
+		currBall.Velocity.X *= -e
+	} else {
+		currBall.Velocity.Y *= -e
+	}
+	if speed > 0 {
+		roll(currBall, normal, (1+e)*speed)
+	}
+
+	if currBall.Spin.Z != 0 {
+		kick := CrossProduct(Vector{Z: currBall.Spin.Z}, normal)
+		currBall.Velocity = Add(currBall.Velocity, ScalarMult(kick, cushionGrip))
+		currBall.Spin.Z *= 1 - cushionGrip
+	}
+}
@@ -0,0 +1,434 @@
+package physics
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"math/rand"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestCoincidentBallsSeparate(t *testing.T) {
+	objects := []Body{
+		{Position: Vector{X: 100, Y: 100}},
+		{Position: Vector{X: 100, Y: 100}},
+	}
+
+	c, ok := testPair(objects, pair{a: 0, b: 1})
+	if !ok {
+		t.Fatal("coincident balls were not reported as touching")
+	}
+	if hasNaN(c.Normal) || math.Abs(c.Normal.Magnitude()-1) > epsilon {
+		t.Fatalf("contact normal = %v, want a unit vector", c.Normal)
+	}
+
+	w := NewWorld(objects, Vector{})
+	defer w.Close()
+	w.Step()
+	for _, ball := range w.Snapshot() {
+		if hasNaN(ball.Position) || hasNaN(ball.Velocity) {
+			t.Fatalf("ball state became NaN: %+v", ball)
+		}
+	}
+}
+
+// TestRemoveRenumbersConstraints checks a removed body takes its rods with
+// it and the rods on later bodies follow them down.
+func TestRemoveRenumbersConstraints(t *testing.T) {
+	objects := []Body{
+		{Position: Vector{X: 100, Y: 100}},
+		{Position: Vector{X: 200, Y: 100}},
+		{Position: Vector{X: 300, Y: 100}},
+	}
+	w := NewWorld(objects, Vector{}, WithConstraints(
+		NewRod(objects, 0, 1),
+		NewAnchoredRod(objects, 2, Vector{X: 300, Y: 0}),
+	))
+	defer w.Close()
+
+	w.Remove(1)
+	if got := len(w.Snapshot()); got != 2 {
+		t.Fatalf("%d bodies after removing one of three, want 2", got)
+	}
+	if len(w.Constraints) != 1 || w.Constraints[0].A != 1 || w.Constraints[0].B != NoBody {
+		t.Fatalf("constraints = %+v, want just the anchored rod on body 1", w.Constraints)
+	}
+	w.Step()
+}
+
+// TestBodyLimitRemovesOldest fills a capped world and checks spawning
+// removes the oldest free body, passing over one held by a rod.
+func TestBodyLimitRemovesOldest(t *testing.T) {
+	objects := []Body{
+		{Position: Vector{X: 100, Y: 100}, Name: "held"},
+		{Position: Vector{X: 200, Y: 100}, Name: "oldest"},
+		{Position: Vector{X: 300, Y: 100}, Name: "newer"},
+	}
+	w := NewWorld(objects, Vector{}, WithBodyLimit(3), WithConstraints(
+		NewAnchoredRod(objects, 0, Vector{X: 100, Y: 0}),
+	))
+	defer w.Close()
+
+	if i := w.Spawn(Body{Name: "newest"}); i != 2 {
+		t.Errorf("spawned at %d, want 2", i)
+	}
+	var names []string
+	for _, b := range w.Snapshot() {
+		names = append(names, b.Name)
+	}
+	if want := []string{"held", "newer", "newest"}; !slices.Equal(names, want) {
+		t.Errorf("bodies %v, want %v", names, want)
+	}
+}
+
+// benchmarkWorld scatters n balls over the screen with small random
+// velocities, seeded so every run measures the same scene.
+func benchmarkWorld(n int, options ...WorldOption) *World {
+	rng := rand.New(rand.NewSource(1))
+	objects := make([]Body, n)
+	for i := range objects {
+		objects[i] = Body{
+			Position: Vector{X: rng.Float64() * ScreenWidth, Y: rng.Float64() * ScreenHeight},
+			Velocity: Vector{X: rng.Float64()*4 - 2, Y: rng.Float64()*4 - 2},
+		}
+	}
+	return NewWorld(objects, Vector{X: 0, Y: .3}, options...)
+}
+
+func BenchmarkWorldStep(b *testing.B) {
+	for _, n := range []int{8, 100, 500} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			w := benchmarkWorld(n)
+			defer w.Close()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				w.Step()
+			}
+		})
+	}
+}
+
+// pileWorld drops n balls into a round arena with room for about four
+// times as many, seeded so every run is the same, and lets them settle
+// into a pile thick with contacts.
+func pileWorld(n int, options ...WorldOption) *World {
+	rng := rand.New(rand.NewSource(1))
+	radius := 4 * BallRadius * math.Sqrt(float64(n)/math.Pi)
+	objects := make([]Body, n)
+	for i := range objects {
+		angle, reach := rng.Float64()*2*math.Pi, radius*math.Sqrt(rng.Float64())
+		objects[i] = Body{Position: Vector{X: reach * math.Cos(angle), Y: reach * math.Sin(angle)}}
+	}
+	w := NewWorld(objects, Vector{Y: .3}, append(options, WithCircleArena(Vector{}, radius))...)
+	for range 300 {
+		w.Step()
+	}
+	return w
+}
+
+// TestWorkersStepIdentically steps the same pile with one worker and with
+// several and checks every body ends up exactly where it does serially,
+// with the same impacts, so solving contacts in parallel batches changes
+// nothing but the time it takes.
+func TestWorkersStepIdentically(t *testing.T) {
+	serial := pileWorld(1000, WithWorkers(1), WithDeterminism())
+	defer serial.Close()
+	parallel := pileWorld(1000, WithWorkers(4), WithDeterminism())
+	defer parallel.Close()
+	if contacts := len(parallel.contacts); contacts < parallelThreshold {
+		t.Fatalf("pile has %d contacts, too few to solve in parallel", contacts)
+	}
+	if len(parallel.batches.ends) < 2 {
+		t.Errorf("contacts solved in %d batches, want them split", len(parallel.batches.ends))
+	}
+
+	for range 20 {
+		serial.Step()
+		parallel.Step()
+	}
+	if !slices.Equal(serial.Snapshot(), parallel.Snapshot()) {
+		t.Error("bodies stepped with four workers differ from one")
+	}
+	if !slices.Equal(serial.LastImpacts(), parallel.LastImpacts()) {
+		t.Error("impacts with four workers differ from one")
+	}
+	if a, b := serial.Stats(), parallel.Stats(); a.KineticEnergy != b.KineticEnergy || a.Momentum != b.Momentum {
+		t.Errorf("four workers sum energy %v and momentum %v, one sums %v and %v", b.KineticEnergy, b.Momentum, a.KineticEnergy, a.Momentum)
+	}
+}
+
+// TestWorkersSumIdentically sums the energy and momentum of enough bodies
+// that the blocks are shared among the workers, and checks every pool size
+// adds them up to the same bits.
+func TestWorkersSumIdentically(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	objects := make([]Body, 2*parallelThreshold*reductionBlock)
+	for i := range objects {
+		objects[i] = Body{
+			Position: Vector{X: rng.Float64() * ScreenWidth, Y: rng.Float64() * ScreenHeight},
+			Velocity: Vector{X: rng.NormFloat64() * 10, Y: rng.NormFloat64() * 10},
+			Mass:     rng.Float64()*1e3 + 1e-3,
+		}
+	}
+
+	serial := NewWorld(objects, Vector{}, WithWorkers(1), WithDeterminism())
+	defer serial.Close()
+	energy, momentum := serial.kineticEnergy(), serial.Momentum()
+	for _, workers := range []int{2, 3, 8} {
+		w := NewWorld(objects, Vector{}, WithWorkers(workers), WithDeterminism())
+		if chunks := w.pool.chunks(len(objects) / reductionBlock); chunks < 2 {
+			t.Errorf("%d workers sum in %d chunk, want the blocks shared", workers, chunks)
+		}
+		if got := w.kineticEnergy(); got != energy {
+			t.Errorf("%d workers sum kinetic energy %v, one sums %v", workers, got, energy)
+		}
+		if got := w.Momentum(); got != momentum {
+			t.Errorf("%d workers sum momentum %v, one sums %v", workers, got, momentum)
+		}
+		w.Close()
+	}
+}
+
+// TestStepPublishesFinishedBodies checks the bodies a step publishes are
+// the ones it leaves, with no pass still removing or changing them after a
+// reader could see them. A ball resting on a post hears an impact every
+// tick, and its handler keeps what was published as the step ends.
+func TestStepPublishesFinishedBodies(t *testing.T) {
+	post := StaticCircle{Position: Vector{X: 320, Y: 300}, Radius: 40}
+	resting := Body{Position: Vector{X: 320, Y: 240}, Restitution: 0.01, HasRestitution: true}
+	tests := []struct {
+		name    string
+		objects []Body
+		options []WorldOption
+		// acted reports whether the pass has done its work
+		acted func(objects []Body) bool
+	}{
+		{
+			name:    "escape",
+			objects: []Body{resting, {Position: Vector{X: 600, Y: 100}, Velocity: Vector{X: 20}}},
+			options: []WorldOption{WithOpenArena(10)},
+			acted:   func(objects []Body) bool { return len(objects) < 2 },
+		},
+		{
+			name: "merge",
+			objects: []Body{resting,
+				{Position: Vector{X: 100, Y: 100}, Velocity: Vector{X: 1}},
+				{Position: Vector{X: 150, Y: 100}, Velocity: Vector{X: -1}},
+			},
+			options: []WorldOption{WithMerging(5)},
+			acted:   func(objects []Body) bool { return len(objects) < 3 },
+		},
+		{
+			name:    "expire",
+			objects: []Body{resting, {Position: Vector{X: 100, Y: 100}, Expires: 3}},
+			acted:   func(objects []Body) bool { return len(objects) < 2 },
+		},
+		{
+			name: "mix",
+			objects: []Body{resting,
+				{Position: Vector{X: 100, Y: 100}, Velocity: Vector{X: 1}, Color: color.RGBA{R: 0xff, A: 0xff}},
+				{Position: Vector{X: 150, Y: 100}, Velocity: Vector{X: -1}, Color: color.RGBA{B: 0xff, A: 0xff}},
+			},
+			options: []WorldOption{WithColorMixing()},
+			acted:   func(objects []Body) bool { return objects[1].Color == objects[2].Color },
+		},
+		{
+			name:    "destroy",
+			objects: []Body{resting, {Position: Vector{X: 100, Y: 100}, Velocity: Vector{X: 12}}},
+			options: []WorldOption{WithLimits(Limits{Speed: 10, Policy: DestroyPastLimits})},
+			acted:   func(objects []Body) bool { return len(objects) < 2 },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewWorld(tt.objects, Vector{Y: .3}, append(tt.options, WithStaticCircles(post))...)
+			defer w.Close()
+			var published []Body
+			w.OnCollision(func(*Body, *Body, Impact) {
+				published = slices.Clone(w.Snapshot())
+			})
+			for step := range 20 {
+				published = nil
+				w.Step()
+				if published == nil {
+					t.Fatalf("step %d: the resting ball heard no impact", step)
+				}
+				if !slices.Equal(published, w.Snapshot()) {
+					t.Fatalf("step %d: the bodies changed after they were published", step)
+				}
+			}
+			if !tt.acted(w.Snapshot()) {
+				t.Error("the pass never acted on the bodies")
+			}
+		})
+	}
+}
+
+// BenchmarkParallelStep steps piles of thousands of balls with more and
+// more workers, showing how the step scales across cores.
+func BenchmarkParallelStep(b *testing.B) {
+	for _, n := range []int{1000, 4000} {
+		counts := []int{1, 2, 4}
+		if cores := runtime.NumCPU(); !slices.Contains(counts, cores) {
+			counts = append(counts, cores)
+		}
+		for _, workers := range counts {
+			b.Run(fmt.Sprintf("balls=%d/workers=%d", n, workers), func(b *testing.B) {
+				w := pileWorld(n, WithWorkers(workers))
+				defer w.Close()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					w.Step()
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkCellSize steps a crowded world with the grid's cells at the
+// width of a ball and at several times it.
+func BenchmarkCellSize(b *testing.B) {
+	for _, size := range []float64{2 * BallRadius, 4 * BallRadius, 8 * BallRadius} {
+		b.Run(strconv.FormatFloat(size, 'f', -1, 64), func(b *testing.B) {
+			w := benchmarkWorld(1000, WithCellSize(size))
+			defer w.Close()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				w.Step()
+			}
+		})
+	}
+}
+
+// TestCellSizeWidensToFitBalls sets cells narrower than the balls and
+// checks two balls meeting still collide, then that a wider size is kept.
+func TestCellSizeWidensToFitBalls(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 100, Y: 100}, Velocity: Vector{X: 2}},
+		{Position: Vector{X: 100 + 2*BallRadius + 10, Y: 100}},
+	}, Vector{}, WithCellSize(BallRadius/2))
+	defer w.Close()
+
+	for i := 0; i < 20; i++ {
+		w.Step()
+	}
+	if v := w.Snapshot()[1].Velocity.X; v <= 0 {
+		t.Errorf("struck ball moving at %v, want it knocked on", v)
+	}
+	if size := w.broadphase.cellSize; size != 2*BallRadius {
+		t.Errorf("cells %v wide, want widened to %v", size, 2*BallRadius)
+	}
+
+	wide := NewWorld([]Body{{Position: Vector{X: 100, Y: 100}}}, Vector{}, WithCellSize(6*BallRadius))
+	defer wide.Close()
+	wide.Step()
+	if size := wide.broadphase.cellSize; size != 6*BallRadius {
+		t.Errorf("cells %v wide, want %v", size, 6*BallRadius)
+	}
+}
+
+// BenchmarkNarrowphaseMiss measures the common case of a broadphase pair
+// that turns out not to be touching.
+func BenchmarkNarrowphaseMiss(b *testing.B) {
+	objects := []Body{
+		{Position: Vector{X: 100, Y: 100}},
+		{Position: Vector{X: 100 + 2*BallRadius + 1, Y: 100}},
+	}
+	for i := 0; i < b.N; i++ {
+		testPair(objects, pair{a: 0, b: 1})
+	}
+}
+
+// TestSpeedLimit drops two balls under heavy gravity and checks neither
+// falls faster than its limit: the world's for one, its own lower one for
+// the other.
+func TestSpeedLimit(t *testing.T) {
+	w := NewWorld([]Body{
+		{Position: Vector{X: 200, Y: BallRadius}},
+		{Position: Vector{X: 400, Y: BallRadius}, SpeedLimit: 2},
+	}, Vector{Y: 5}, WithSpeedLimit(8))
+	defer w.Close()
+
+	for tick := 0; tick < 30; tick++ {
+		w.Step()
+	}
+	for i, want := range []float64{8, 2} {
+		v := w.Snapshot()[i].Velocity
+		if got := v.Magnitude(); math.Abs(got-want) > 1e-9 {
+			t.Errorf("ball %d falling at %v, want its limit %v", i, got, want)
+		}
+	}
+}
+
+// TestBoundingBoxesRefitOnlyOnEscape moves a ball slowly along and checks
+// its fat bounding box always holds it, but stays put until the ball has
+// wandered out of it.
+func TestBoundingBoxesRefitOnlyOnEscape(t *testing.T) {
+	w := NewWorld([]Body{{Position: Vector{X: 200, Y: 240}, Velocity: Vector{X: 1}}}, Vector{})
+	defer w.Close()
+
+	w.Step()
+	first := w.BoundingBoxes(nil)[0]
+	refits := 0
+	previous := first
+	for range 20 {
+		w.Step()
+		box := w.BoundingBoxes(nil)[0]
+		if !box.contains(ballBox(&w.Snapshot()[0])) {
+			t.Fatalf("box %v doesn't hold the ball at %v", box, w.Snapshot()[0].Position)
+		}
+		if box != previous {
+			refits++
+		}
+		previous = box
+	}
+	// Moving a pixel a tick, the ball leaves its box every margin's worth
+	// of ticks
+	if want := 20 / int(aabbMargin+1); refits < want-1 || refits > want+1 {
+		t.Errorf("box refitted %d times in 20 ticks, want about %d", refits, want)
+	}
+}
+
+// TestBallsOfDifferentSizes fires a small light ball at a big heavy one,
+// and checks they meet at the sum of their radii with momentum kept, and
+// that the big one rests on the floor by its own radius.
+func TestBallsOfDifferentSizes(t *testing.T) {
+	w := NewWorld([]Body{
+		NewBall(Vector{X: 100, Y: 240}, Vector{X: 5}, 1, 10),
+		NewBall(Vector{X: 300, Y: 240}, Vector{}, 9, 60),
+	}, Vector{})
+	defer w.Close()
+
+	closest := math.Inf(1)
+	for range 60 {
+		w.Step()
+		objects := w.Snapshot()
+		closest = min(closest, objects[1].Position.X-objects[0].Position.X)
+	}
+	objects := w.Snapshot()
+	if closest < 69 || closest > 75 {
+		t.Errorf("balls came within %.2f of each other, want them to meet at 70", closest)
+	}
+	if objects[1].Velocity.X <= 0 {
+		t.Fatalf("big ball at rest after being hit, %v", objects[1].Velocity)
+	}
+	if p := w.Momentum(); math.Abs(p.X-5) > 1e-9 || math.Abs(p.Y) > 1e-9 {
+		t.Errorf("momentum %v after the hit, want %v", p, Vector{X: 5})
+	}
+	if i, ok := w.BodyAt(Add(objects[1].Position, Vector{Y: 50})); !ok || i != 1 {
+		t.Errorf("BodyAt 50 pixels from the big ball's middle found %d, %v", i, ok)
+	}
+
+	w = NewWorld([]Body{NewBall(Vector{X: 320, Y: 100}, Vector{}, 9, 60)}, Vector{Y: .5}, WithWallRestitution(0))
+	defer w.Close()
+	for range 300 {
+		w.Step()
+	}
+	if y := w.Snapshot()[0].Position.Y; math.Abs(y-(ScreenHeight-60)) > 1 {
+		t.Errorf("big ball resting at y=%.2f, want it %v above the floor", y, 60)
+	}
+}